@@ -33,20 +33,29 @@ func OrientationIndex(vectorOrigin, vectorEnd, point geom.Coord) orientation.Typ
 		return index
 	}
 
-	var dx1, dy1, dx2, dy2 big.Float
-
-	// 归一化坐标
-	dx1.SetFloat64(vectorEnd[0]).Add(&dx1, big.NewFloat(-vectorOrigin[0]))
-	dy1.SetFloat64(vectorEnd[1]).Add(&dy1, big.NewFloat(-vectorOrigin[1]))
-	dx2.SetFloat64(point[0]).Add(&dx2, big.NewFloat(-vectorEnd[0]))
-	dy2.SetFloat64(point[1]).Add(&dy2, big.NewFloat(-vectorEnd[1]))
+	// 使用有理数精确计算，big.Float 的默认精度只有53位，接近共线时不能保证结果的符号正确
+	var dx1, dy1, dx2, dy2, tmp big.Rat
+	if !setDifference(&dx1, vectorEnd[0], vectorOrigin[0], &tmp) || !setDifference(&dy1, vectorEnd[1], vectorOrigin[1], &tmp) ||
+		!setDifference(&dx2, point[0], vectorEnd[0], &tmp) || !setDifference(&dy2, point[1], vectorEnd[1], &tmp) {
+		// 坐标为无穷大或 NaN
+		return orientation.Collinear
+	}
 
 	// 计算因子.  计算的性能主要体现在 dx1 上
 	dx1.Mul(&dx1, &dy2)
 	dy1.Mul(&dy1, &dx2)
 	dx1.Sub(&dx1, &dy1)
 
-	return orientationBasedOnSignForBig(dx1)
+	return orientationBasedOnSignForBig(&dx1)
+}
+
+// setDifference 函数精确地计算 a-b，a 或 b 不是有限的数时返回 false
+func setDifference(z *big.Rat, a, b float64, tmp *big.Rat) bool {
+	if z.SetFloat64(a) == nil || tmp.SetFloat64(b) == nil {
+		return false
+	}
+	z.Sub(z, tmp)
+	return true
 }
 
 // Intersection函数 使用数学计算两条直线的交点。大浮点运算.
@@ -100,6 +109,44 @@ func Intersection(line1Start, line1End, line2Start, line2End geom.Coord) geom.Co
 	return geom.Coord{x, y}
 }
 
+// IsInCircle函数 检测点 p 是否严格位于三角形 a-b-c 的外接圆之内，a、b、c 必须为逆时针方向。
+// 先使用浮点数计算行列式，结果不可靠时使用有理数精确计算，因此点接近外接圆时结果也是准确的。
+// 点位于外接圆上时返回 false
+func IsInCircle(a, b, c, p geom.Coord) bool {
+	adx, ady := a[0]-p[0], a[1]-p[1]
+	bdx, bdy := b[0]-p[0], b[1]-p[1]
+	cdx, cdy := c[0]-p[0], c[1]-p[1]
+	alift, blift, clift := adx*adx+ady*ady, bdx*bdx+bdy*bdy, cdx*cdx+cdy*cdy
+	bc, ca, ab := bdx*cdy-cdx*bdy, cdx*ady-adx*cdy, adx*bdy-bdx*ady
+	det := alift*bc + blift*ca + clift*ab
+	permanent := alift*(math.Abs(bdx*cdy)+math.Abs(cdx*bdy)) +
+		blift*(math.Abs(cdx*ady)+math.Abs(adx*cdy)) +
+		clift*(math.Abs(adx*bdy)+math.Abs(bdx*ady))
+	if errBound := 1e-14 * permanent; det > errBound || -det > errBound {
+		return det > 0
+	}
+
+	// 使用有理数精确计算
+	var d [3][2]big.Rat
+	var tmp big.Rat
+	for i, q := range [3]geom.Coord{a, b, c} {
+		if !setDifference(&d[i][0], q[0], p[0], &tmp) || !setDifference(&d[i][1], q[1], p[1], &tmp) {
+			// 坐标为无穷大或 NaN
+			return false
+		}
+	}
+	var sum, lift, cross, t big.Rat
+	for i := 0; i < 3; i++ {
+		u, v, w := &d[i], &d[(i+1)%3], &d[(i+2)%3]
+		lift.Mul(&u[0], &u[0])
+		lift.Add(&lift, t.Mul(&u[1], &u[1]))
+		cross.Mul(&v[0], &w[1])
+		cross.Sub(&cross, t.Mul(&w[0], &v[1]))
+		sum.Add(&sum, lift.Mul(&lift, &cross))
+	}
+	return sum.Sign() > 0
+}
+
 /////////////////  实现 /////////////////////////////////
 
 // 一种计算三坐标方位指数的过滤器。
@@ -152,10 +199,7 @@ func orientationBasedOnSign(x float64) orientation.Type {
 	}
 	return orientation.Collinear
 }
-func orientationBasedOnSignForBig(x big.Float) orientation.Type {
-	if x.IsInf() {
-		return orientation.Collinear
-	}
+func orientationBasedOnSignForBig(x *big.Rat) orientation.Type {
 	switch x.Sign() {
 	case -1:
 		return orientation.Clockwise
@@ -58,6 +58,25 @@ func TestOrientationIndex(t *testing.T) {
 			point:        geom.Coord{-17.1041375307579, 42.3147318674446},
 			result:       orientation.Clockwise,
 		},
+		// 以下几个点几乎共线，53位精度的 big.Float 会得到错误的结果
+		{
+			vectorOrigin: geom.Coord{46.88898449024232, 28.303415118044516},
+			vectorEnd:    geom.Coord{29.310185733681575, 67.90846759202162},
+			point:        geom.Coord{52.94208285932235, 14.665777992920331},
+			result:       orientation.CounterClockwise,
+		},
+		{
+			vectorOrigin: geom.Coord{20.318687664732284, 36.0871416856906},
+			vectorEnd:    geom.Coord{57.06732760710226, 86.24914374478864},
+			point:        geom.Coord{15.884697227630834, 30.034731643177338},
+			result:       orientation.CounterClockwise,
+		},
+		{
+			vectorOrigin: geom.Coord{68.68230728671094, 6.563701921747622},
+			vectorEnd:    geom.Coord{15.651925473279125, 9.696951891448457},
+			point:        geom.Coord{73.84027652305895, 6.258948186231205},
+			result:       orientation.Clockwise,
+		},
 	} {
 		orientationIndex := bigxy.OrientationIndex(testData.vectorOrigin, testData.vectorEnd, testData.point)
 		if orientationIndex != testData.result {
@@ -120,3 +139,64 @@ func TestIntersection(t *testing.T) {
 		}
 	}
 }
+
+func TestIsInCircle(t *testing.T) {
+	for i, tc := range []struct {
+		desc       string
+		a, b, c, p geom.Coord
+		result     bool
+	}{
+		{
+			desc:   "inside",
+			a:      geom.Coord{0, 0},
+			b:      geom.Coord{2, 0},
+			c:      geom.Coord{0, 2},
+			p:      geom.Coord{1, 1},
+			result: true,
+		},
+		{
+			desc:   "outside",
+			a:      geom.Coord{0, 0},
+			b:      geom.Coord{2, 0},
+			c:      geom.Coord{0, 2},
+			p:      geom.Coord{3, 3},
+			result: false,
+		},
+		{
+			desc:   "on circle",
+			a:      geom.Coord{0, 0},
+			b:      geom.Coord{2, 0},
+			c:      geom.Coord{0, 2},
+			p:      geom.Coord{2, 2},
+			result: false,
+		},
+		{
+			desc:   "vertex",
+			a:      geom.Coord{0, 0},
+			b:      geom.Coord{2, 0},
+			c:      geom.Coord{0, 2},
+			p:      geom.Coord{0, 0},
+			result: false,
+		},
+		{
+			desc:   "nearly on circle inside",
+			a:      geom.Coord{1e15, 0},
+			b:      geom.Coord{1e15 + 2, 0},
+			c:      geom.Coord{1e15, 2},
+			p:      geom.Coord{1e15 + 2, 2 - 1.0/(1<<20)},
+			result: true,
+		},
+		{
+			desc:   "nearly on circle outside",
+			a:      geom.Coord{1e15, 0},
+			b:      geom.Coord{1e15 + 2, 0},
+			c:      geom.Coord{1e15, 2},
+			p:      geom.Coord{1e15 + 2, 2 + 1.0/(1<<20)},
+			result: false,
+		},
+	} {
+		if result := bigxy.IsInCircle(tc.a, tc.b, tc.c, tc.p); result != tc.result {
+			t.Errorf("Test %v (%v) Failed. Expected: %v but was %v", i+1, tc.desc, tc.result, result)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package ewkt 实现了扩展的著名文本(EWKT)格式的编码和解码，即带有 SRID=<srid>; 前缀的 WKT.
+// See https://postgis.net/docs/using_postgis_dbmanagement.html#EWKB_EWKT.
+package ewkt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+)
+
+// Marshal函数 编码任意几何图形。当几何图形的 SRID 不为0时，添加 SRID=<srid>; 前缀
+func Marshal(g geom.T) (string, error) {
+	s, err := wkt.Marshal(g)
+	if err != nil {
+		return "", err
+	}
+	if srid := g.SRID(); srid != 0 {
+		return "SRID=" + strconv.Itoa(srid) + ";" + s, nil
+	}
+	return s, nil
+}
+
+// Unmarshal函数 解码任意 EWKT 格式的几何图形，SRID 前缀是可选的
+func Unmarshal(s string) (geom.T, error) {
+	srid, offset, err := parseSRID(s)
+	if err != nil {
+		return nil, err
+	}
+	g, err := wkt.Unmarshal(s[offset:])
+	if err != nil {
+		if e, ok := err.(wkt.ErrSyntax); ok {
+			e.Pos += offset
+			return nil, e
+		}
+		return nil, err
+	}
+	return setSRID(g, srid)
+}
+
+// parseSRID 函数解析 SRID=<srid>; 前缀，返回 SRID 和 WKT 部分在 s 中的偏移量
+func parseSRID(s string) (int, int, error) {
+	i := 0
+	for i < len(s) && isSpace(s[i]) {
+		i++
+	}
+	if len(s)-i < 5 || !strings.EqualFold(s[i:i+5], "SRID=") {
+		return 0, 0, nil
+	}
+	start := i + 5
+	end := strings.IndexByte(s[start:], ';')
+	if end == -1 {
+		return 0, 0, wkt.ErrSyntax{Pos: start, Msg: "missing ';' after SRID"}
+	}
+	end += start
+	srid, err := strconv.Atoi(strings.TrimSpace(s[start:end]))
+	if err != nil {
+		return 0, 0, wkt.ErrSyntax{Pos: start, Msg: fmt.Sprintf("invalid SRID %q", s[start:end])}
+	}
+	return srid, end + 1, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func setSRID(g geom.T, srid int) (geom.T, error) {
+	switch g := g.(type) {
+	case *geom.Point:
+		return g.SetSRID(srid), nil
+	case *geom.LineString:
+		return g.SetSRID(srid), nil
+	case *geom.Polygon:
+		return g.SetSRID(srid), nil
+	case *geom.MultiPoint:
+		return g.SetSRID(srid), nil
+	case *geom.MultiLineString:
+		return g.SetSRID(srid), nil
+	case *geom.MultiPolygon:
+		return g.SetSRID(srid), nil
+	case *geom.GeometryCollection:
+		return g.SetSRID(srid), nil
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
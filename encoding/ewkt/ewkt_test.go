@@ -0,0 +1,86 @@
+package ewkt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+)
+
+func Test(t *testing.T) {
+	for _, tc := range []struct {
+		g geom.T
+		s string
+	}{
+		{
+			g: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}),
+			s: "POINT (1 2)",
+		},
+		{
+			g: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}).SetSRID(4326),
+			s: "SRID=4326;POINT (1 2)",
+		},
+		{
+			g: geom.NewLineString(geom.XYZ).MustSetCoords([]geom.Coord{{1, 2, 3}, {4, 5, 6}}).SetSRID(4326),
+			s: "SRID=4326;LINESTRING Z (1 2 3, 4 5 6)",
+		},
+		{
+			g: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}).SetSRID(3857),
+			s: "SRID=3857;POLYGON ((0 0, 1 0, 1 1, 0 0))",
+		},
+		{
+			g: geom.NewMultiPoint(geom.XYM).MustSetCoords([]geom.Coord{{1, 2, 3}}).SetSRID(4326),
+			s: "SRID=4326;MULTIPOINT M (1 2 3)",
+		},
+		{
+			g: geom.NewMultiLineString(geom.XY).SetSRID(4326),
+			s: "SRID=4326;MULTILINESTRING EMPTY",
+		},
+		{
+			g: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}).SetSRID(32631),
+			s: "SRID=32631;MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)))",
+		},
+		{
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}),
+			).SetSRID(4326),
+			s: "SRID=4326;GEOMETRYCOLLECTION (POINT (1 2))",
+		},
+	} {
+		if got, err := Marshal(tc.g); err != nil || got != tc.s {
+			t.Errorf("Marshal(%#v) == %q, %v, want %q, nil", tc.g, got, err, tc.s)
+		}
+		if got, err := Unmarshal(tc.s); err != nil || !reflect.DeepEqual(got, tc.g) {
+			t.Errorf("Unmarshal(%q) == %#v, %v, want %#v, nil", tc.s, got, err, tc.g)
+		}
+	}
+}
+
+func TestUnmarshalLenient(t *testing.T) {
+	want := geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}).SetSRID(4326)
+	for _, s := range []string{
+		"srid=4326;POINT(1 2)",
+		" SRID=4326 ; POINT (1 2)",
+	} {
+		if got, err := Unmarshal(s); err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("Unmarshal(%q) == %#v, %v, want %#v, nil", s, got, err, want)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		pos int
+	}{
+		{s: "SRID=4326 POINT (1 2)", pos: 5},
+		{s: "SRID=abc;POINT (1 2)", pos: 5},
+		{s: "SRID=4326;POINT (1)", pos: 17},
+	} {
+		_, err := Unmarshal(tc.s)
+		if e, ok := err.(wkt.ErrSyntax); !ok || e.Pos != tc.pos {
+			t.Errorf("Unmarshal(%q) == _, %v, want _, wkt.ErrSyntax{Pos: %d, ...}", tc.s, err, tc.pos)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package wkt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind 是词法单元的类型
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenNumber
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of input"
+	case tokenWord:
+		return "word"
+	case tokenNumber:
+		return "number"
+	case tokenLParen:
+		return "'('"
+	case tokenRParen:
+		return "')'"
+	case tokenComma:
+		return "','"
+	default:
+		return fmt.Sprintf("tokenKind(%d)", int(k))
+	}
+}
+
+// token 是一个词法单元，pos 为其在输入中的字节偏移量
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenWord, tokenNumber:
+		return fmt.Sprintf("%q", t.text)
+	default:
+		return t.kind.String()
+	}
+}
+
+// ErrSyntax 将会被返回，当 WKT 文本不符合语法时。Pos 为出错位置的字节偏移量
+type ErrSyntax struct {
+	Pos int
+	Msg string
+}
+
+func (e ErrSyntax) Error() string {
+	return fmt.Sprintf("wkt: syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// lexer 将 WKT 文本切分为词法单元
+type lexer struct {
+	s   string
+	pos int
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isLetter(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || c == '_'
+}
+
+func isNumberStart(c byte) bool {
+	return '0' <= c && c <= '9' || c == '-' || c == '+' || c == '.'
+}
+
+func isNumberPart(c byte) bool {
+	return isNumberStart(c) || isLetter(c)
+}
+
+// next 函数返回下一个词法单元，输入结束时返回 tokenEOF
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.s) && isSpace(l.s[l.pos]) {
+		l.pos++
+	}
+	start := l.pos
+	if start == len(l.s) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+	c := l.s[start]
+	switch {
+	case c == '(':
+		l.pos++
+		return token{kind: tokenLParen, text: "(", pos: start}, nil
+	case c == ')':
+		l.pos++
+		return token{kind: tokenRParen, text: ")", pos: start}, nil
+	case c == ',':
+		l.pos++
+		return token{kind: tokenComma, text: ",", pos: start}, nil
+	case isLetter(c):
+		for l.pos < len(l.s) && (isLetter(l.s[l.pos]) || '0' <= l.s[l.pos] && l.s[l.pos] <= '9') {
+			l.pos++
+		}
+		return token{kind: tokenWord, text: l.s[start:l.pos], pos: start}, nil
+	case isNumberStart(c):
+		for l.pos < len(l.s) && isNumberPart(l.s[l.pos]) {
+			// 指数部分之外的正负号表示下一个数字的开始
+			if c := l.s[l.pos]; (c == '-' || c == '+') && l.pos > start && !strings.ContainsRune("eE", rune(l.s[l.pos-1])) {
+				break
+			}
+			l.pos++
+		}
+		return token{kind: tokenNumber, text: l.s[start:l.pos], pos: start}, nil
+	default:
+		return token{}, ErrSyntax{Pos: start, Msg: fmt.Sprintf("unexpected character %q", c)}
+	}
+}
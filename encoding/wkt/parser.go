@@ -0,0 +1,387 @@
+package wkt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// Unmarshal函数 解码任意 WKT 格式的几何图形.
+func Unmarshal(s string) (geom.T, error) {
+	p := &parser{l: lexer{s: s}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	g, err := p.parseGeometry(geom.NoLayout)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected(tokenEOF.String())
+	}
+	return g, nil
+}
+
+// parser 是一个递归下降的 WKT 语法分析器，tok 为当前的前瞻词法单元
+type parser struct {
+	l   lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.l.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected(want string) error {
+	return ErrSyntax{Pos: p.tok.pos, Msg: fmt.Sprintf("got %s, want %s", p.tok, want)}
+}
+
+func (p *parser) expect(kind tokenKind) error {
+	if p.tok.kind != kind {
+		return p.unexpected(kind.String())
+	}
+	return p.advance()
+}
+
+// isWord 函数检测当前词法单元是否为指定的关键字，不区分大小写
+func (p *parser) isWord(word string) bool {
+	return p.tok.kind == tokenWord && strings.EqualFold(p.tok.text, word)
+}
+
+// parseLayout 函数解析可选的 Z、M、ZM 维度关键字，不存在时返回 NoLayout
+func (p *parser) parseLayout() (geom.Layout, error) {
+	layout := geom.NoLayout
+	switch {
+	case p.isWord("Z"):
+		layout = geom.XYZ
+	case p.isWord("M"):
+		layout = geom.XYM
+	case p.isWord("ZM"):
+		layout = geom.XYZM
+	default:
+		return layout, nil
+	}
+	return layout, p.advance()
+}
+
+// parseEmpty 函数在当前词法单元为 EMPTY 时将其消耗并返回true
+func (p *parser) parseEmpty() (bool, error) {
+	if !p.isWord("EMPTY") {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+// parseGeometry 函数解析一个带类型关键字的几何图形。defaultLayout 为没有维度关键字时
+// 使用的视图，NoLayout 表示由坐标的个数推断
+func (p *parser) parseGeometry(defaultLayout geom.Layout) (geom.T, error) {
+	if p.tok.kind != tokenWord {
+		return nil, p.unexpected("geometry type")
+	}
+	typeTok := p.tok
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	layout, err := p.parseLayout()
+	if err != nil {
+		return nil, err
+	}
+	if layout == geom.NoLayout {
+		layout = defaultLayout
+	}
+	switch strings.ToUpper(typeTok.text) {
+	case "POINT":
+		return p.parsePoint(layout)
+	case "LINESTRING":
+		return p.parseLineString(layout)
+	case "POLYGON":
+		return p.parsePolygon(layout)
+	case "MULTIPOINT":
+		return p.parseMultiPoint(layout)
+	case "MULTILINESTRING":
+		return p.parseMultiLineString(layout)
+	case "MULTIPOLYGON":
+		return p.parseMultiPolygon(layout)
+	case "GEOMETRYCOLLECTION":
+		return p.parseGeometryCollection(layout)
+	default:
+		return nil, ErrSyntax{Pos: typeTok.pos, Msg: fmt.Sprintf("unknown geometry type %s", typeTok)}
+	}
+}
+
+func (p *parser) parsePoint(layout geom.Layout) (geom.T, error) {
+	if empty, err := p.parseEmpty(); err != nil {
+		return nil, err
+	} else if empty {
+		return geom.NewPointFlat(orXY(layout), nil), nil
+	}
+	if err := p.expect(tokenLParen); err != nil {
+		return nil, err
+	}
+	flatCoords, layout, err := p.parseCoord(nil, layout)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokenRParen); err != nil {
+		return nil, err
+	}
+	return geom.NewPointFlat(layout, flatCoords), nil
+}
+
+func (p *parser) parseLineString(layout geom.Layout) (geom.T, error) {
+	if empty, err := p.parseEmpty(); err != nil {
+		return nil, err
+	} else if empty {
+		return geom.NewLineString(orXY(layout)), nil
+	}
+	flatCoords, layout, err := p.parseFlatCoords1(nil, layout)
+	if err != nil {
+		return nil, err
+	}
+	return geom.NewLineStringFlat(layout, flatCoords), nil
+}
+
+func (p *parser) parsePolygon(layout geom.Layout) (geom.T, error) {
+	if empty, err := p.parseEmpty(); err != nil {
+		return nil, err
+	} else if empty {
+		return geom.NewPolygon(orXY(layout)), nil
+	}
+	flatCoords, ends, layout, err := p.parseFlatCoords2(nil, nil, layout, false)
+	if err != nil {
+		return nil, err
+	}
+	return geom.NewPolygonFlat(orXY(layout), flatCoords, ends), nil
+}
+
+// parseMultiPoint 函数同时支持 MULTIPOINT (1 2, 3 4) 和 MULTIPOINT ((1 2), (3 4)) 两种写法。
+// MultiPoint 不能表示空的点，因此 EMPTY 成员被忽略
+func (p *parser) parseMultiPoint(layout geom.Layout) (geom.T, error) {
+	if empty, err := p.parseEmpty(); err != nil {
+		return nil, err
+	} else if empty {
+		return geom.NewMultiPoint(orXY(layout)), nil
+	}
+	if err := p.expect(tokenLParen); err != nil {
+		return nil, err
+	}
+	var flatCoords []float64
+	for {
+		empty, err := p.parseEmpty()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case empty:
+		case p.tok.kind == tokenLParen:
+			if err = p.advance(); err != nil {
+				return nil, err
+			}
+			if flatCoords, layout, err = p.parseCoord(flatCoords, layout); err != nil {
+				return nil, err
+			}
+			if err = p.expect(tokenRParen); err != nil {
+				return nil, err
+			}
+		default:
+			if flatCoords, layout, err = p.parseCoord(flatCoords, layout); err != nil {
+				return nil, err
+			}
+		}
+		if p.tok.kind != tokenComma {
+			break
+		}
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(tokenRParen); err != nil {
+		return nil, err
+	}
+	return geom.NewMultiPointFlat(orXY(layout), flatCoords), nil
+}
+
+func (p *parser) parseMultiLineString(layout geom.Layout) (geom.T, error) {
+	if empty, err := p.parseEmpty(); err != nil {
+		return nil, err
+	} else if empty {
+		return geom.NewMultiLineString(orXY(layout)), nil
+	}
+	flatCoords, ends, layout, err := p.parseFlatCoords2(nil, nil, layout, true)
+	if err != nil {
+		return nil, err
+	}
+	return geom.NewMultiLineStringFlat(orXY(layout), flatCoords, ends), nil
+}
+
+// parseMultiPolygon 函数解析多多边形，MultiPolygon 不能表示空的多边形，因此 EMPTY 成员被忽略
+func (p *parser) parseMultiPolygon(layout geom.Layout) (geom.T, error) {
+	if empty, err := p.parseEmpty(); err != nil {
+		return nil, err
+	} else if empty {
+		return geom.NewMultiPolygon(orXY(layout)), nil
+	}
+	if err := p.expect(tokenLParen); err != nil {
+		return nil, err
+	}
+	var flatCoords []float64
+	var endss [][]int
+	for {
+		empty, err := p.parseEmpty()
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			var ends []int
+			if flatCoords, ends, layout, err = p.parseFlatCoords2(flatCoords, nil, layout, false); err != nil {
+				return nil, err
+			}
+			endss = append(endss, ends)
+		}
+		if p.tok.kind != tokenComma {
+			break
+		}
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(tokenRParen); err != nil {
+		return nil, err
+	}
+	return geom.NewMultiPolygonFlat(orXY(layout), flatCoords, endss), nil
+}
+
+// parseGeometryCollection 函数解析几何图形集合，集合的维度关键字作为其中没有维度关键字的几何图形的默认视图
+func (p *parser) parseGeometryCollection(layout geom.Layout) (geom.T, error) {
+	gc := geom.NewGeometryCollection()
+	if empty, err := p.parseEmpty(); err != nil {
+		return nil, err
+	} else if empty {
+		return gc, nil
+	}
+	if err := p.expect(tokenLParen); err != nil {
+		return nil, err
+	}
+	for {
+		g, err := p.parseGeometry(layout)
+		if err != nil {
+			return nil, err
+		}
+		if err = gc.Push(g); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenComma {
+			break
+		}
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(tokenRParen); err != nil {
+		return nil, err
+	}
+	return gc, nil
+}
+
+// parseCoord 函数解析一个坐标并追加到 flatCoords。如果 layout 为 NoLayout，则由坐标的个数推断视图
+func (p *parser) parseCoord(flatCoords []float64, layout geom.Layout) ([]float64, geom.Layout, error) {
+	start := p.tok.pos
+	n := 0
+	for p.tok.kind == tokenNumber || p.tok.kind == tokenWord {
+		x, err := strconv.ParseFloat(p.tok.text, 64)
+		if err != nil {
+			return nil, layout, ErrSyntax{Pos: p.tok.pos, Msg: fmt.Sprintf("invalid number %s", p.tok)}
+		}
+		flatCoords = append(flatCoords, x)
+		n++
+		if err := p.advance(); err != nil {
+			return nil, layout, err
+		}
+	}
+	if n == 0 {
+		return nil, layout, p.unexpected(tokenNumber.String())
+	}
+	if layout == geom.NoLayout {
+		switch n {
+		case 1:
+			return nil, layout, ErrSyntax{Pos: start, Msg: "coordinate has only one ordinate"}
+		case 2:
+			layout = geom.XY
+		case 3:
+			layout = geom.XYZ
+		case 4:
+			layout = geom.XYZM
+		default:
+			return nil, layout, ErrSyntax{Pos: start, Msg: fmt.Sprintf("coordinate has %d ordinates, want at most 4", n)}
+		}
+	} else if n != layout.Stride() {
+		return nil, layout, ErrSyntax{Pos: start, Msg: fmt.Sprintf("got %d ordinates, want %d for layout %s", n, layout.Stride(), layout)}
+	}
+	return flatCoords, layout, nil
+}
+
+// parseFlatCoords1 函数解析一个用括号包围、逗号分隔的坐标序列
+func (p *parser) parseFlatCoords1(flatCoords []float64, layout geom.Layout) ([]float64, geom.Layout, error) {
+	if err := p.expect(tokenLParen); err != nil {
+		return nil, layout, err
+	}
+	for {
+		var err error
+		if flatCoords, layout, err = p.parseCoord(flatCoords, layout); err != nil {
+			return nil, layout, err
+		}
+		if p.tok.kind != tokenComma {
+			break
+		}
+		if err = p.advance(); err != nil {
+			return nil, layout, err
+		}
+	}
+	return flatCoords, layout, p.expect(tokenRParen)
+}
+
+// parseFlatCoords2 函数解析一个用括号包围、逗号分隔的坐标序列的序列。allowEmpty 为 true 时 EMPTY 成员为没有坐标的序列，
+// 否则返回 ErrSyntax：多边形的线环不能是空的
+func (p *parser) parseFlatCoords2(flatCoords []float64, ends []int, layout geom.Layout, allowEmpty bool) ([]float64, []int, geom.Layout, error) {
+	if err := p.expect(tokenLParen); err != nil {
+		return nil, nil, layout, err
+	}
+	for {
+		pos := p.tok.pos
+		empty, err := p.parseEmpty()
+		if err != nil {
+			return nil, nil, layout, err
+		}
+		if empty && !allowEmpty {
+			return nil, nil, layout, ErrSyntax{Pos: pos, Msg: "polygon ring cannot be EMPTY"}
+		}
+		if !empty {
+			if flatCoords, layout, err = p.parseFlatCoords1(flatCoords, layout); err != nil {
+				return nil, nil, layout, err
+			}
+		}
+		ends = append(ends, len(flatCoords))
+		if p.tok.kind != tokenComma {
+			break
+		}
+		if err = p.advance(); err != nil {
+			return nil, nil, layout, err
+		}
+	}
+	return flatCoords, ends, layout, p.expect(tokenRParen)
+}
+
+// orXY 函数在 layout 为 NoLayout 时返回 XY，用于没有坐标可以推断视图的空几何图形
+func orXY(layout geom.Layout) geom.Layout {
+	if layout == geom.NoLayout {
+		return geom.XY
+	}
+	return layout
+}
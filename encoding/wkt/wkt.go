@@ -1,3 +1,4 @@
+// Package wkt 实现了著名文本(WKT)格式的编码和解码.
 package wkt
 
 import (
@@ -58,10 +59,19 @@ func write(b *bytes.Buffer, g geom.T) error {
 	}
 	switch g := g.(type) {
 	case *geom.Point:
+		if len(g.FlatCoords()) == 0 {
+			return writeEMPTY(b)
+		}
 		return writeFlatCoords0(b, g.FlatCoords(), layout.Stride())
 	case *geom.LineString:
+		if len(g.FlatCoords()) == 0 {
+			return writeEMPTY(b)
+		}
 		return writeFlatCoords1(b, g.FlatCoords(), layout.Stride())
 	case *geom.Polygon:
+		if len(g.Ends()) == 0 {
+			return writeEMPTY(b)
+		}
 		return writeFlatCoords2(b, g.FlatCoords(), 0, g.Ends(), layout.Stride())
 	case *geom.MultiPoint:
 		if g.Empty() {
@@ -159,6 +169,12 @@ func writeFlatCoords2(b *bytes.Buffer, flatCoords []float64, start int, ends []i
 				return err
 			}
 		}
+		if start == end {
+			if err := writeEMPTY(b); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := writeFlatCoords1(b, flatCoords[start:end], stride); err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package wkt
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/chengxiaoer/geomGo"
@@ -87,6 +88,10 @@ func TestMarshal(t *testing.T) {
 			g: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{{{{1, 2}, {3, 4}, {5, 6}}}, {{{7, 8}, {9, 10}, {11, 12}}}}),
 			s: "MULTIPOLYGON (((1 2, 3 4, 5 6)), ((7 8, 9 10, 11 12)))",
 		},
+		{
+			g: geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4}, []int{0, 4, 4}),
+			s: "MULTILINESTRING (EMPTY, (1 2, 3 4), EMPTY)",
+		},
 		{
 			g: geom.NewGeometryCollection(),
 			s: "GEOMETRYCOLLECTION EMPTY",
@@ -104,3 +109,185 @@ func TestMarshal(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshal(t *testing.T) {
+	for _, tc := range []struct {
+		s string
+		g geom.T
+	}{
+		{
+			s: "POINT (1 2)",
+			g: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}),
+		},
+		{
+			s: "point(1 2)",
+			g: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}),
+		},
+		{
+			s: "POINT (1 2 3)",
+			g: geom.NewPoint(geom.XYZ).MustSetCoords(geom.Coord{1, 2, 3}),
+		},
+		{
+			s: "POINT Z (1 2 3)",
+			g: geom.NewPoint(geom.XYZ).MustSetCoords(geom.Coord{1, 2, 3}),
+		},
+		{
+			s: "POINT M (1 2 3)",
+			g: geom.NewPoint(geom.XYM).MustSetCoords(geom.Coord{1, 2, 3}),
+		},
+		{
+			s: "POINT ZM (1 2 3 4)",
+			g: geom.NewPoint(geom.XYZM).MustSetCoords(geom.Coord{1, 2, 3, 4}),
+		},
+		{
+			s: "POINT (-1.5 2e3)",
+			g: geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{-1.5, 2000}),
+		},
+		{
+			s: "POINT EMPTY",
+			g: geom.NewPointFlat(geom.XY, nil),
+		},
+		{
+			s: "\tLINESTRING ( 1 2 ,3 4 )\n",
+			g: geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{1, 2}, {3, 4}}),
+		},
+		{
+			s: "LINESTRING EMPTY",
+			g: geom.NewLineString(geom.XY),
+		},
+		{
+			s: "LINESTRING ZM (1 2 3 4, 5 6 7 8)",
+			g: geom.NewLineString(geom.XYZM).MustSetCoords([]geom.Coord{{1, 2, 3, 4}, {5, 6, 7, 8}}),
+		},
+		{
+			s: "POLYGON ((1 2, 3 4, 5 6), (7 8, 9 10, 11 12))",
+			g: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{1, 2}, {3, 4}, {5, 6}}, {{7, 8}, {9, 10}, {11, 12}}}),
+		},
+		{
+			s: "POLYGON M EMPTY",
+			g: geom.NewPolygon(geom.XYM),
+		},
+		{
+			s: "MULTIPOINT (1 2, 3 4)",
+			g: geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{1, 2}, {3, 4}}),
+		},
+		{
+			s: "MULTIPOINT ((1 2), (3 4))",
+			g: geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{1, 2}, {3, 4}}),
+		},
+		{
+			s: "MULTIPOINT EMPTY",
+			g: geom.NewMultiPoint(geom.XY),
+		},
+		{
+			s: "MULTIPOINT (EMPTY, (1 2), EMPTY, 3 4)",
+			g: geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{1, 2}, {3, 4}}),
+		},
+		{
+			s: "MULTIPOINT (EMPTY)",
+			g: geom.NewMultiPoint(geom.XY),
+		},
+		{
+			s: "MULTILINESTRING (EMPTY, (1 2, 3 4))",
+			g: geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4}, []int{0, 4}),
+		},
+		{
+			s: "MULTILINESTRING ((1 2, 3 4), (5 6, 7 8))",
+			g: geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}}),
+		},
+		{
+			s: "MULTIPOLYGON Z (((1 2 3, 4 5 6, 7 8 9)), ((10 11 12, 13 14 15, 16 17 18)))",
+			g: geom.NewMultiPolygon(geom.XYZ).MustSetCoords([][][]geom.Coord{{{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}}, {{{10, 11, 12}, {13, 14, 15}, {16, 17, 18}}}}),
+		},
+		{
+			s: "MULTIPOLYGON (EMPTY, ((1 2, 3 4, 5 6)), EMPTY)",
+			g: geom.NewMultiPolygonFlat(geom.XY, []float64{1, 2, 3, 4, 5, 6}, [][]int{{6}}),
+		},
+		{
+			s: "MULTIPOLYGON M (EMPTY)",
+			g: geom.NewMultiPolygon(geom.XYM),
+		},
+		{
+			s: "GEOMETRYCOLLECTION EMPTY",
+			g: geom.NewGeometryCollection(),
+		},
+		{
+			s: "GEOMETRYCOLLECTION (POINT (1 2), GEOMETRYCOLLECTION (LINESTRING (3 4, 5 6)))",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{1, 2}),
+				geom.NewGeometryCollection().MustPush(
+					geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{3, 4}, {5, 6}}),
+				),
+			),
+		},
+		{
+			s: "GEOMETRYCOLLECTION M (POINT (1 2 3), LINESTRING M (4 5 6, 7 8 9))",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPoint(geom.XYM).MustSetCoords(geom.Coord{1, 2, 3}),
+				geom.NewLineString(geom.XYM).MustSetCoords([]geom.Coord{{4, 5, 6}, {7, 8, 9}}),
+			),
+		},
+	} {
+		if got, err := Unmarshal(tc.s); err != nil || !reflect.DeepEqual(got, tc.g) {
+			t.Errorf("Unmarshal(%q) == %#v, %v, want %#v, nil", tc.s, got, err, tc.g)
+		}
+	}
+}
+
+func TestUnmarshalRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"POINT (1 2)",
+		"POINT EMPTY",
+		"POINT ZM (1 2 3 4)",
+		"LINESTRING EMPTY",
+		"LINESTRING M (1 2 3, 4 5 6)",
+		"POLYGON EMPTY",
+		"POLYGON ((0 0, 1 0, 1 1, 0 0))",
+		"MULTIPOINT Z (1 2 3, 4 5 6)",
+		"MULTILINESTRING EMPTY",
+		"MULTILINESTRING ((1 2, 3 4), (5 6, 7 8))",
+		"MULTILINESTRING (EMPTY, (1 2, 3 4), EMPTY)",
+		"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0), (0.25 0.5, 0.5 0.5, 0.5 0.75, 0.25 0.5)), ((2 2, 3 2, 3 3, 2 2)))",
+		"GEOMETRYCOLLECTION EMPTY",
+		"GEOMETRYCOLLECTION Z (POINT Z (1 2 3), GEOMETRYCOLLECTION Z (LINESTRING Z (1 2 3, 4 5 6)))",
+	} {
+		g, err := Unmarshal(s)
+		if err != nil {
+			t.Errorf("Unmarshal(%q) == _, %v, want _, nil", s, err)
+			continue
+		}
+		if got, err := Marshal(g); err != nil || got != s {
+			t.Errorf("Marshal(Unmarshal(%q)) == %q, %v, want %q, nil", s, got, err, s)
+		}
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		pos int
+	}{
+		{s: "", pos: 0},
+		{s: "CIRCLE (1 2)", pos: 0},
+		{s: "POINT", pos: 5},
+		{s: "POINT (1)", pos: 7},
+		{s: "POINT (1 2", pos: 10},
+		{s: "POINT (1 x)", pos: 9},
+		{s: "POINT (1 2 3 4 5)", pos: 7},
+		{s: "MULTIPOINT (1 2 3 4 5)", pos: 12},
+		{s: "POINT Z (1 2)", pos: 9},
+		{s: "POINT (1 2) POINT (3 4)", pos: 12},
+		{s: "LINESTRING (1 2, 3 4 5)", pos: 17},
+		{s: "LINESTRING (1 2; 3 4)", pos: 15},
+		{s: "POLYGON (1 2, 3 4)", pos: 9},
+		{s: "POLYGON (EMPTY)", pos: 9},
+		{s: "POLYGON ((0 0, 1 0, 1 1, 0 0), EMPTY)", pos: 31},
+		{s: "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0), EMPTY))", pos: 37},
+		{s: "GEOMETRYCOLLECTION (POINT (1 2),)", pos: 32},
+	} {
+		_, err := Unmarshal(tc.s)
+		if e, ok := err.(ErrSyntax); !ok || e.Pos != tc.pos {
+			t.Errorf("Unmarshal(%q) == _, %v, want _, ErrSyntax{Pos: %d, ...}", tc.s, err, tc.pos)
+		}
+	}
+}
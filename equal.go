@@ -0,0 +1,103 @@
+package geom
+
+import "math"
+
+// Equal函数 检测两个几何图形在结构上是否相同，即类型、视图、SRID、ends、endss 和所有的坐标都相同。
+// 与 Coord.Equal 一样，两个 NaN 被视为相等。GeometryCollection 中的几何图形按照顺序逐个比较
+func Equal(g1, g2 T) bool {
+	return EqualWithTolerance(g1, g2, 0)
+}
+
+// EqualWithTolerance函数 与 Equal 相同，但是两个坐标值之差的绝对值不超过 tol 时即视为相等
+func EqualWithTolerance(g1, g2 T, tol float64) bool {
+	if g1 == nil || g2 == nil {
+		return g1 == nil && g2 == nil
+	}
+	switch g1 := g1.(type) {
+	case *Point:
+		g2, ok := g2.(*Point)
+		return ok && equalGeom(g1, g2, tol)
+	case *LineString:
+		g2, ok := g2.(*LineString)
+		return ok && equalGeom(g1, g2, tol)
+	case *LinearRing:
+		g2, ok := g2.(*LinearRing)
+		return ok && equalGeom(g1, g2, tol)
+	case *Polygon:
+		g2, ok := g2.(*Polygon)
+		return ok && equalGeom(g1, g2, tol)
+	case *MultiPoint:
+		g2, ok := g2.(*MultiPoint)
+		return ok && equalGeom(g1, g2, tol)
+	case *MultiLineString:
+		g2, ok := g2.(*MultiLineString)
+		return ok && equalGeom(g1, g2, tol)
+	case *MultiPolygon:
+		g2, ok := g2.(*MultiPolygon)
+		return ok && equalGeom(g1, g2, tol)
+	case *GeometryCollection:
+		g2, ok := g2.(*GeometryCollection)
+		if !ok || g1.srid != g2.srid || len(g1.geoms) != len(g2.geoms) {
+			return false
+		}
+		for i := range g1.geoms {
+			if !EqualWithTolerance(g1.geoms[i], g2.geoms[i], tol) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func equalGeom(g1, g2 T, tol float64) bool {
+	if g1.Layout() != g2.Layout() || g1.SRID() != g2.SRID() {
+		return false
+	}
+	if !equalEnds(g1.Ends(), g2.Ends()) {
+		return false
+	}
+	endss1, endss2 := g1.Endss(), g2.Endss()
+	if len(endss1) != len(endss2) {
+		return false
+	}
+	for i := range endss1 {
+		if !equalEnds(endss1[i], endss2[i]) {
+			return false
+		}
+	}
+	return equalFlatCoords(g1.FlatCoords(), g2.FlatCoords(), tol)
+}
+
+func equalEnds(ends1, ends2 []int) bool {
+	if len(ends1) != len(ends2) {
+		return false
+	}
+	for i := range ends1 {
+		if ends1[i] != ends2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalFlatCoords(flatCoords1, flatCoords2 []float64, tol float64) bool {
+	if len(flatCoords1) != len(flatCoords2) {
+		return false
+	}
+	for i := range flatCoords1 {
+		if !equalFloat(flatCoords1[i], flatCoords2[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalFloat 函数检测两个值之差的绝对值是否不超过 tol，两个 NaN 被视为相等
+func equalFloat(x, y, tol float64) bool {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.IsNaN(x) && math.IsNaN(y)
+	}
+	return x == y || math.Abs(x-y) <= tol
+}
@@ -0,0 +1,115 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	nan := math.NaN()
+	for i, tc := range []struct {
+		g1, g2 T
+		tol    float64
+		want   bool
+	}{
+		{
+			g1:   NewPointFlat(XY, []float64{1, 2}),
+			g2:   NewPointFlat(XY, []float64{1, 2}),
+			want: true,
+		},
+		{
+			g1:   NewPointFlat(XY, []float64{1, nan}),
+			g2:   NewPointFlat(XY, []float64{1, nan}),
+			want: true,
+		},
+		{
+			g1:   NewPointFlat(XY, []float64{1, nan}),
+			g2:   NewPointFlat(XY, []float64{1, 2}),
+			want: false,
+		},
+		{
+			g1:   NewPointFlat(XY, []float64{1, 2}).SetSRID(4326),
+			g2:   NewPointFlat(XY, []float64{1, 2}),
+			want: false,
+		},
+		{
+			g1:   NewPointFlat(XYM, []float64{1, 2, 3}),
+			g2:   NewPointFlat(XYZ, []float64{1, 2, 3}),
+			want: false,
+		},
+		{
+			g1:   NewPointFlat(XY, []float64{1, 2}),
+			g2:   NewMultiPointFlat(XY, []float64{1, 2}),
+			want: false,
+		},
+		{
+			g1:   NewLineStringFlat(XY, []float64{0, 0, 1, 1}),
+			g2:   NewLineStringFlat(XY, []float64{0, 0, 1, 1.0001}),
+			want: false,
+		},
+		{
+			g1:   NewLineStringFlat(XY, []float64{0, 0, 1, 1}),
+			g2:   NewLineStringFlat(XY, []float64{0, 0, 1, 1.0001}),
+			tol:  1e-3,
+			want: true,
+		},
+		{
+			g1:   NewLineStringFlat(XY, []float64{0, 0, 1, math.Inf(1)}),
+			g2:   NewLineStringFlat(XY, []float64{0, 0, 1, math.Inf(1)}),
+			tol:  1e-3,
+			want: true,
+		},
+		{
+			g1:   NewMultiLineStringFlat(XY, []float64{0, 0, 1, 1, 2, 2}, []int{4, 6}),
+			g2:   NewMultiLineStringFlat(XY, []float64{0, 0, 1, 1, 2, 2}, []int{2, 6}),
+			want: false,
+		},
+		{
+			g1:   NewMultiPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}, {}}),
+			g2:   NewMultiPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}, {}}),
+			want: true,
+		},
+		{
+			g1:   NewMultiPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}, {}}),
+			g2:   NewMultiPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}}),
+			want: false,
+		},
+		{
+			g1:   NewGeometryCollection().MustPush(NewPointFlat(XY, []float64{1, 2}), NewLineStringFlat(XY, []float64{0, 0, 1, 1})),
+			g2:   NewGeometryCollection().MustPush(NewPointFlat(XY, []float64{1, 2}), NewLineStringFlat(XY, []float64{0, 0, 1, 1})),
+			want: true,
+		},
+		{
+			g1:   NewGeometryCollection().MustPush(NewPointFlat(XY, []float64{1, 2}), NewLineStringFlat(XY, []float64{0, 0, 1, 1})),
+			g2:   NewGeometryCollection().MustPush(NewLineStringFlat(XY, []float64{0, 0, 1, 1}), NewPointFlat(XY, []float64{1, 2})),
+			want: false,
+		},
+		{
+			g1:   NewGeometryCollection().SetSRID(4326),
+			g2:   NewGeometryCollection(),
+			want: false,
+		},
+		{
+			g1:   nil,
+			g2:   nil,
+			want: true,
+		},
+		{
+			g1:   NewPoint(XY),
+			g2:   nil,
+			want: false,
+		},
+	} {
+		if got := EqualWithTolerance(tc.g1, tc.g2, tc.tol); got != tc.want {
+			t.Errorf("%d: EqualWithTolerance(%v, %v, %v) == %v, want %v", i, tc.g1, tc.g2, tc.tol, got, tc.want)
+		}
+		if got := EqualWithTolerance(tc.g2, tc.g1, tc.tol); got != tc.want {
+			t.Errorf("%d: EqualWithTolerance(%v, %v, %v) == %v, want %v", i, tc.g2, tc.g1, tc.tol, got, tc.want)
+		}
+		if tc.tol == 0 {
+			if got := Equal(tc.g1, tc.g2); got != tc.want {
+				t.Errorf("%d: Equal(%v, %v) == %v, want %v", i, tc.g1, tc.g2, got, tc.want)
+			}
+		}
+	}
+}
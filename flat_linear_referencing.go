@@ -0,0 +1,103 @@
+package geom
+
+import (
+	"math"
+)
+
+// segmentLength 函数返回 flatCoords 中第 i 个坐标与下一个坐标之间的线段在 x、y 平面上的长度
+func segmentLength(flatCoords []float64, i, stride int) float64 {
+	dx := flatCoords[i+stride] - flatCoords[i]
+	dy := flatCoords[i+stride+1] - flatCoords[i+1]
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// interpolateCoord 函数在 flatCoords 中第 i 个坐标与下一个坐标之间按照比例 t 插值，所有的维度（包括 z、m）都参与插值
+func interpolateCoord(flatCoords []float64, i, stride int, t float64) Coord {
+	c := make(Coord, stride)
+	for j := 0; j < stride; j++ {
+		c[j] = flatCoords[i+j] + t*(flatCoords[i+stride+j]-flatCoords[i+j])
+	}
+	return c
+}
+
+// pointAtDistance1 函数返回线上距离起点 distance 的点，distance 被限制在0与线的长度之间
+func pointAtDistance1(flatCoords []float64, offset, end, stride int, distance float64) Coord {
+	if offset == end {
+		return nil
+	}
+	if distance > 0 {
+		var sum float64
+		for i := offset; i < end-stride; i += stride {
+			l := segmentLength(flatCoords, i, stride)
+			if l > 0 && sum+l >= distance {
+				return interpolateCoord(flatCoords, i, stride, (distance-sum)/l)
+			}
+			sum += l
+		}
+		return Coord(flatCoords[end-stride : end]).Clone()
+	}
+	return Coord(flatCoords[offset : offset+stride]).Clone()
+}
+
+// project1 函数返回线上距离点 c 最近的点到线的起点的距离，以及点 c 与该点之间距离的平方
+func project1(flatCoords []float64, offset, end, stride int, c Coord) (float64, float64) {
+	if end-offset == stride {
+		dx, dy := c[0]-flatCoords[offset], c[1]-flatCoords[offset+1]
+		return 0, dx*dx + dy*dy
+	}
+	var sum, best, bestDistance2 float64
+	bestDistance2 = math.Inf(1)
+	for i := offset; i < end-stride; i += stride {
+		x0, y0 := flatCoords[i], flatCoords[i+1]
+		dx, dy := flatCoords[i+stride]-x0, flatCoords[i+stride+1]-y0
+		len2 := dx*dx + dy*dy
+		var t float64
+		if len2 > 0 {
+			t = math.Max(0, math.Min(1, ((c[0]-x0)*dx+(c[1]-y0)*dy)/len2))
+		}
+		px, py := x0+t*dx-c[0], y0+t*dy-c[1]
+		if d2 := px*px + py*py; d2 < bestDistance2 {
+			best, bestDistance2 = sum+t*math.Sqrt(len2), d2
+		}
+		sum += math.Sqrt(len2)
+	}
+	return best, bestDistance2
+}
+
+// substring1 函数将线上距离起点从 start 到 stop 的部分追加到 dst，要求 start <= stop。
+// 结果包含起点、终点以及两者之间的所有顶点，相邻的相同坐标只保留一个，start 与 stop 相等时结果为两个相同的点
+func substring1(dst []float64, flatCoords []float64, offset, end, stride int, start, stop float64) []float64 {
+	n := len(dst)
+	dst = append(dst, pointAtDistance1(flatCoords, offset, end, stride, start)...)
+	var sum float64
+	for i := offset; i < end; i += stride {
+		if i > offset {
+			sum += segmentLength(flatCoords, i-stride, stride)
+		}
+		if sum > stop {
+			break
+		}
+		if sum >= start && !equalFlatCoords(dst[len(dst)-stride:], flatCoords[i:i+stride], 0) {
+			dst = append(dst, flatCoords[i:i+stride]...)
+		}
+	}
+	last := pointAtDistance1(flatCoords, offset, end, stride, stop)
+	if len(dst)-n == stride || !equalFlatCoords(dst[len(dst)-stride:], last, 0) {
+		dst = append(dst, last...)
+	}
+	return dst
+}
+
+// reverseCoords 函数原地反转 flatCoords 中坐标的顺序
+func reverseCoords(flatCoords []float64, stride int) {
+	for i, j := 0, len(flatCoords)-stride; i < j; i, j = i+stride, j-stride {
+		for k := 0; k < stride; k++ {
+			flatCoords[i+k], flatCoords[j+k] = flatCoords[j+k], flatCoords[i+k]
+		}
+	}
+}
+
+// clampDistance 函数将 distance 限制在0与 length 之间
+func clampDistance(distance, length float64) float64 {
+	return math.Max(0, math.Min(length, distance))
+}
@@ -0,0 +1,203 @@
+package geodesic
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// maxSigmaStep 是计算面积时辅助球面上求积区间的最大长度（弧度），更长的边被分为多个区间分别求积
+const maxSigmaStep = math.Pi / 16
+
+// gaussNodes 和 gaussWeights 是8点 Gauss-Legendre 求积公式在 [-1, 1] 上的正节点和对应的权重，负节点与正节点对称
+var (
+	gaussNodes   = [...]float64{0.1834346424956498, 0.5255324099163290, 0.7966664774136267, 0.9602898564975363}
+	gaussWeights = [...]float64{0.3626837833783620, 0.3137066458778873, 0.2223810344533745, 0.1012285362903763}
+)
+
+// Area方法 返回几何图形的椭球面面积，单位为平方米。
+// 线环的面积与方向无关，多边形的面积为外边界的面积减去洞的面积，点和线的面积为0。
+// 线环所围成的区域不能超过椭球面积的一半，包围极点的线环也是允许的。
+//
+// 每条边与赤道之间的面积沿测地线积分得到（见 Karney, "Algorithms for geodesics"），不需要加密边，
+// 结果与 GeographicLib 的差别远小于1平方米，对从赤道到两极的地块都是准确的
+func (e Ellipsoid) Area(g geom.T) (float64, error) {
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint, *geom.LineString, *geom.MultiLineString:
+		return 0, nil
+	case *geom.LinearRing:
+		return e.ringArea(g.FlatCoords(), 0, len(g.FlatCoords()), g.Stride()), nil
+	case *geom.Polygon:
+		return e.polygonArea(g.FlatCoords(), 0, g.Ends(), g.Stride()), nil
+	case *geom.MultiPolygon:
+		var area float64
+		offset := 0
+		for _, ends := range g.Endss() {
+			area += e.polygonArea(g.FlatCoords(), offset, ends, g.Stride())
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+		return area, nil
+	case *geom.GeometryCollection:
+		var area float64
+		for _, child := range g.Geoms() {
+			a, err := e.Area(child)
+			if err != nil {
+				return 0, err
+			}
+			area += a
+		}
+		return area, nil
+	default:
+		return 0, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// Perimeter方法 返回线环、多边形和多多边形所有线环的测地线长度之和，单位为米，点和线的周长为0
+func (e Ellipsoid) Perimeter(g geom.T) (float64, error) {
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint, *geom.LineString, *geom.MultiLineString:
+		return 0, nil
+	case *geom.GeometryCollection:
+		var perimeter float64
+		for _, child := range g.Geoms() {
+			p, err := e.Perimeter(child)
+			if err != nil {
+				return 0, err
+			}
+			perimeter += p
+		}
+		return perimeter, nil
+	default:
+		return e.Length(g)
+	}
+}
+
+func (e Ellipsoid) polygonArea(flatCoords []float64, offset int, ends []int, stride int) float64 {
+	var area float64
+	for i, end := range ends {
+		ringArea := e.ringArea(flatCoords, offset, end, stride)
+		if i == 0 {
+			area = ringArea
+		} else {
+			area -= ringArea
+		}
+		offset = end
+	}
+	return area
+}
+
+// ringArea 方法返回线环的面积。每条边与赤道以及两端的经线围成的区域的有向面积之和为线环的有向面积，
+// 包围极点的线环经度差之和为 ±2pi，此时需要加上半球的面积
+func (e Ellipsoid) ringArea(flatCoords []float64, offset, end, stride int) float64 {
+	auth := e.authalicSphere()
+	var area, lambdaSum float64
+	for i := offset + stride; i < end; i += stride {
+		p1, p2 := geom.Coord(flatCoords[i-stride:i-stride+2]), geom.Coord(flatCoords[i:i+2])
+		area += e.edgeArea(auth, p1, p2)
+		lambdaSum += toRadians(normalizeAngle(p2[0] - p1[0]))
+	}
+	hemisphere := 2 * math.Pi * auth.r2
+	if math.Abs(lambdaSum) > math.Pi {
+		area = math.Copysign(hemisphere, lambdaSum) - area
+	}
+	area = math.Mod(math.Abs(area), 2*hemisphere)
+	if area > hemisphere {
+		area = 2*hemisphere - area
+	}
+	return area
+}
+
+// edgeArea 方法返回从 p1 到 p2 的测地线与赤道以及两端的经线围成的区域的有向面积，即 F(φ)dλ 沿测地线的积分，
+// F(φ) 为赤道与纬度 φ 之间单位经度的面积。
+//
+// F(φ)dλ 被分解为 c²dα 与一个光滑的余项，c² 为等面积球（authalic sphere）半径的平方，α 为方位角。
+// 前者的积分为 c²(α2-α1)，后者以辅助球面上从赤道开始的角距离 σ 为自变量，使用 Gauss-Legendre 公式求积
+func (e Ellipsoid) edgeArea(auth authalicSphere, p1, p2 geom.Coord) float64 {
+	phi1, phi2 := toRadians(p1[1]), toRadians(p2[1])
+	l := toRadians(normalizeAngle(p2[0] - p1[0]))
+	sinU1, cosU1 := e.reducedLatitude(phi1)
+	sinU2, cosU2 := e.reducedLatitude(phi2)
+	_, alpha1, alpha2, sigma12, omega12, ok := e.inverseVincenty(phi1, phi2, l)
+	var alpha12 float64
+	if ok && math.Abs(omega12) < 0.75*math.Pi && math.Abs(sinU2-sinU1) < 1.75 {
+		// 短边的 α2-α1 是两个相近的数相减，舍入误差乘以 c² 后可达数平方米，
+		// 因此改用辅助球面上梯形（两条经线、赤道和该边）的球面角超计算
+		dU1, dU2 := 1+cosU1, 1+cosU2
+		alpha12 = 2 * math.Atan2(math.Sin(omega12)*(sinU1*dU2+sinU2*dU1), (1+math.Cos(omega12))*(sinU1*sinU2+dU1*dU2))
+	} else {
+		if !ok {
+			_, alpha1, alpha2 = e.inverseBisection(phi1, phi2, l)
+			sigma12 = math.Remainder(math.Atan2(sinU2, cosU2*math.Cos(alpha2))-math.Atan2(sinU1, cosU1*math.Cos(alpha1)), 2*math.Pi)
+			if sigma12 < -math.Pi/2 {
+				sigma12 += 2 * math.Pi
+			}
+		}
+		alpha12 = math.Remainder(alpha2-alpha1, 2*math.Pi)
+		if math.Pi-math.Abs(alpha12) < 1e-11 {
+			// 经过或到达极点的经线的方位角之差为 ±180°，符号与沿极点经过的经度差（经过南极时相反）一致
+			alpha12 = math.Copysign(alpha12, l*(phi1+phi2))
+		}
+	}
+	area := auth.r2 * alpha12
+	sinAlpha1, cosAlpha1 := math.Sincos(alpha1)
+	// 测地线在赤道上的方位角为 alpha0，经线的 sin(alpha0) 为0，余项也为0
+	sinAlpha0 := cosU1 * sinAlpha1
+	cosAlpha0 := math.Hypot(cosAlpha1, sinAlpha1*sinU1)
+	if auth.e == 0 || sinAlpha0 == 0 {
+		return area
+	}
+	sigma1 := math.Atan2(sinU1, cosU1*cosAlpha1)
+	n := math.Ceil(math.Abs(sigma12) / maxSigmaStep)
+	if n == 0 {
+		return area
+	}
+	h := sigma12 / n / 2
+	var sum float64
+	for i := 0.0; i < n; i++ {
+		mid := sigma1 + (2*i+1)*h
+		for j, x := range gaussNodes {
+			sum += gaussWeights[j] * (auth.areaIntegrand(cosAlpha0*math.Sin(mid-h*x)) + auth.areaIntegrand(cosAlpha0*math.Sin(mid+h*x)))
+		}
+	}
+	return area + sinAlpha0*e.A*e.A/2*sum*h
+}
+
+// authalicSphere 是与椭球面积相等的球，r2 为半径的平方
+type authalicSphere struct {
+	e, e2, qp, r2 float64
+}
+
+func (e Ellipsoid) authalicSphere() authalicSphere {
+	e2 := e.F * (2 - e.F)
+	auth := authalicSphere{e: math.Sqrt(e2), e2: e2}
+	auth.qp = auth.q(1)
+	auth.r2 = e.A * e.A * auth.qp / 2
+	return auth
+}
+
+func (auth authalicSphere) q(sinPhi float64) float64 {
+	if auth.e == 0 {
+		return 2 * sinPhi
+	}
+	return (1 - auth.e2) * (sinPhi/(1-auth.e2*sinPhi*sinPhi) + math.Atanh(auth.e*sinPhi)/auth.e)
+}
+
+// areaIntegrand 方法返回面积的余项对 σ 的导数除以 a²sin(alpha0)/2，w 为归化纬度的正弦。
+// 导数中 F(φ)dλ 与 c²dα 的差以 1-w² 为因子，这里先约去该因子，避免在测地线的最高点附近两个相近的数相减
+func (auth authalicSphere) areaIntegrand(w float64) float64 {
+	sign := 1.0
+	if w < 0 {
+		sign, w = -1, -w
+	}
+	e, e2 := auth.e, auth.e2
+	d := math.Sqrt(1 - e2*(1-w*w))
+	k := e * (1 - e2) / ((d + w) * (d - e2*w))
+	// atanh(z)/z，z 趋于0时为1
+	z, ratio := -k*(1-w*w), 1.0
+	if z != 0 {
+		ratio = math.Atanh(z) / z
+	}
+	return sign * (-e2*w + (1-e2)/e*((1-e2)*math.Atanh(e*w/d)/(d+w)-w*ratio*k))
+}
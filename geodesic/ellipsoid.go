@@ -0,0 +1,109 @@
+package geodesic
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// Ellipsoid 是旋转椭球
+type Ellipsoid struct {
+	// A 是长半轴，单位为米
+	A float64
+	// F 是扁率，(A-B)/A
+	F float64
+}
+
+// 常用的参考椭球
+var (
+	WGS84 = Ellipsoid{A: 6378137, F: 1 / 298.257223563}
+	GRS80 = Ellipsoid{A: 6378137, F: 1 / 298.257222101}
+)
+
+// NewEllipsoid函数 使用长半轴和扁率倒数创建椭球，invF 为0时表示球体
+func NewEllipsoid(a, invF float64) Ellipsoid {
+	if invF == 0 {
+		return Ellipsoid{A: a}
+	}
+	return Ellipsoid{A: a, F: 1 / invF}
+}
+
+// B方法 返回短半轴
+func (e Ellipsoid) B() float64 {
+	return e.A * (1 - e.F)
+}
+
+// Distance方法 返回两点之间的测地线距离，单位为米
+func (e Ellipsoid) Distance(p1, p2 geom.Coord) float64 {
+	s, _, _ := e.Inverse(p1, p2)
+	return s
+}
+
+// Length方法 返回几何图形的测地线长度，单位为米。
+// 多边形返回所有线环的周长之和，点和多点的长度为0
+func (e Ellipsoid) Length(g geom.T) (float64, error) {
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		return 0, nil
+	case *geom.LineString, *geom.LinearRing:
+		return e.length1(g.FlatCoords(), 0, len(g.FlatCoords()), g.Stride()), nil
+	case *geom.MultiLineString, *geom.Polygon:
+		return e.length2(g.FlatCoords(), 0, g.Ends(), g.Stride()), nil
+	case *geom.MultiPolygon:
+		var length float64
+		offset := 0
+		for _, ends := range g.Endss() {
+			length += e.length2(g.FlatCoords(), offset, ends, g.Stride())
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+		return length, nil
+	case *geom.GeometryCollection:
+		var length float64
+		for _, child := range g.Geoms() {
+			l, err := e.Length(child)
+			if err != nil {
+				return 0, err
+			}
+			length += l
+		}
+		return length, nil
+	default:
+		return 0, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+func (e Ellipsoid) length1(flatCoords []float64, offset, end, stride int) float64 {
+	var length float64
+	for i := offset + stride; i < end; i += stride {
+		length += e.Distance(flatCoords[i-stride:i-stride+2], flatCoords[i:i+2])
+	}
+	return length
+}
+
+func (e Ellipsoid) length2(flatCoords []float64, offset int, ends []int, stride int) float64 {
+	var length float64
+	for _, end := range ends {
+		length += e.length1(flatCoords, offset, end, stride)
+		offset = end
+	}
+	return length
+}
+
+// normalizeAngle 函数将角度（度）规范到 (-180, 180] 之间
+func normalizeAngle(x float64) float64 {
+	x = math.Remainder(x, 360)
+	if x == -180 {
+		return 180
+	}
+	return x
+}
+
+func toRadians(x float64) float64 {
+	return x * math.Pi / 180
+}
+
+func toDegrees(x float64) float64 {
+	return x * 180 / math.Pi
+}
@@ -0,0 +1,58 @@
+// Package geodesic 包含了旋转椭球面上的测地线计算，例如两点之间的距离、方位角和目的地点。
+//
+// 坐标的第一个和第二个值分别为经度和纬度，单位为度（例如 SRID 4326），其他的值将被忽略。
+// 距离的单位为米，方位角从北方向顺时针计算，单位为度。
+// 包级别的函数使用 WGS84 椭球，其他椭球请使用 Ellipsoid 的方法
+package geodesic
+
+import (
+	"github.com/chengxiaoer/geomGo"
+)
+
+// Inverse函数 返回 WGS84 椭球上从 p1 到 p2 的测地线距离、起始方位角和终止方位角，见 Ellipsoid.Inverse
+func Inverse(p1, p2 geom.Coord) (distance, azimuth1, azimuth2 float64) {
+	return WGS84.Inverse(p1, p2)
+}
+
+// Direct函数 返回 WGS84 椭球上从 p 出发沿方位角 azimuth 行进 distance 米到达的点及该点的方位角，见 Ellipsoid.Direct
+func Direct(p geom.Coord, azimuth, distance float64) (geom.Coord, float64) {
+	return WGS84.Direct(p, azimuth, distance)
+}
+
+// Distance函数 返回 WGS84 椭球上两点之间的测地线距离
+func Distance(p1, p2 geom.Coord) float64 {
+	return WGS84.Distance(p1, p2)
+}
+
+// InitialAzimuth函数 返回 WGS84 椭球上从 p1 到 p2 的测地线在 p1 处的方位角
+func InitialAzimuth(p1, p2 geom.Coord) float64 {
+	_, azimuth, _ := WGS84.Inverse(p1, p2)
+	return azimuth
+}
+
+// FinalAzimuth函数 返回 WGS84 椭球上从 p1 到 p2 的测地线在 p2 处的方位角
+func FinalAzimuth(p1, p2 geom.Coord) float64 {
+	_, _, azimuth := WGS84.Inverse(p1, p2)
+	return azimuth
+}
+
+// Destination函数 返回 WGS84 椭球上从 p 出发沿方位角 azimuth 行进 distance 米到达的点
+func Destination(p geom.Coord, azimuth, distance float64) geom.Coord {
+	dest, _ := WGS84.Direct(p, azimuth, distance)
+	return dest
+}
+
+// Length函数 返回几何图形在 WGS84 椭球上的测地线长度，见 Ellipsoid.Length
+func Length(g geom.T) (float64, error) {
+	return WGS84.Length(g)
+}
+
+// Area函数 返回几何图形在 WGS84 椭球上的面积，见 Ellipsoid.Area
+func Area(g geom.T) (float64, error) {
+	return WGS84.Area(g)
+}
+
+// Perimeter函数 返回几何图形在 WGS84 椭球上的周长，见 Ellipsoid.Perimeter
+func Perimeter(g geom.T) (float64, error) {
+	return WGS84.Perimeter(g)
+}
@@ -0,0 +1,37 @@
+package geodesic_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/geodesic"
+)
+
+func ExampleInverse() {
+	beijing := geom.Coord{116.4074, 39.9042}
+	shanghai := geom.Coord{121.4737, 31.2304}
+	distance, azimuth1, azimuth2 := geodesic.Inverse(beijing, shanghai)
+	fmt.Printf("%.0f m, %.2f°, %.2f°\n", distance, azimuth1, azimuth2)
+	// Output: 1065846 m, 152.97°, 155.93°
+}
+
+func ExampleDestination() {
+	dest := geodesic.Destination(geom.Coord{0, 0}, 90, 111319.491)
+	fmt.Printf("%.6f %.6f\n", dest[0], dest[1])
+	// Output: 1.000000 0.000000
+}
+
+func ExampleLength() {
+	ls := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1})
+	length, _ := geodesic.Length(ls)
+	fmt.Printf("%.3f\n", length)
+	// Output: 221893.879
+}
+
+func ExampleArea() {
+	// 赤道上 0.01° × 0.01° 的地块
+	parcel := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 0.01, 0, 0.01, 0.01, 0, 0.01, 0, 0}, []int{10})
+	area, _ := geodesic.Area(parcel)
+	fmt.Printf("%.2f ha\n", area/10000)
+	// Output: 123.09 ha
+}
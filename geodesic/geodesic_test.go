@@ -0,0 +1,276 @@
+package geodesic_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/geodesic"
+)
+
+// dms 函数将度、分、秒转换为度
+func dms(d, m, s float64) float64 {
+	return math.Copysign(math.Abs(d)+m/60+s/3600, d)
+}
+
+// angleDiff 函数返回两个方位角之差的绝对值
+func angleDiff(a, b float64) float64 {
+	return math.Abs(math.Remainder(a-b, 360))
+}
+
+func TestInverse(t *testing.T) {
+	for i, tc := range []struct {
+		desc               string
+		ellipsoid          geodesic.Ellipsoid
+		p1, p2             geom.Coord
+		distance           float64
+		azimuth1, azimuth2 float64
+	}{
+		{
+			// Vincenty (1975) 中的测试数据
+			desc:      "Flinders Peak to Buninyong",
+			ellipsoid: geodesic.WGS84,
+			p1:        geom.Coord{dms(144, 25, 29.52440), dms(-37, 57, 3.72030)},
+			p2:        geom.Coord{dms(143, 55, 35.38390), dms(-37, 39, 10.15610)},
+			distance:  54972.271,
+			azimuth1:  dms(306, 52, 5.37),
+			azimuth2:  dms(307, 10, 25.07),
+		},
+		{
+			desc:      "equator",
+			ellipsoid: geodesic.WGS84,
+			p1:        geom.Coord{0, 0},
+			p2:        geom.Coord{1, 0},
+			distance:  111319.491,
+			azimuth1:  90,
+			azimuth2:  90,
+		},
+		{
+			desc:      "meridian to pole",
+			ellipsoid: geodesic.WGS84,
+			p1:        geom.Coord{30, 0},
+			p2:        geom.Coord{30, 90},
+			distance:  10001965.729,
+			azimuth1:  0,
+			azimuth2:  0,
+		},
+		{
+			desc:      "across the antimeridian",
+			ellipsoid: geodesic.WGS84,
+			p1:        geom.Coord{179.5, 0},
+			p2:        geom.Coord{-179.5, 0},
+			distance:  111319.491,
+			azimuth1:  90,
+			azimuth2:  90,
+		},
+		{
+			desc:      "antipodal on the equator",
+			ellipsoid: geodesic.WGS84,
+			p1:        geom.Coord{0, 0},
+			p2:        geom.Coord{180, 0},
+			// 经过两极的测地线都是最短的，选择经过南极的一条
+			distance: 20003931.459,
+			azimuth1: 180,
+			azimuth2: 0,
+		},
+		{
+			desc:      "nearly antipodal",
+			ellipsoid: geodesic.WGS84,
+			p1:        geom.Coord{0, 0},
+			p2:        geom.Coord{179.5, 0.5},
+			distance:  19936288.579,
+			azimuth1:  25.671873,
+			azimuth2:  154.327085,
+		},
+		{
+			desc:      "coincident",
+			ellipsoid: geodesic.WGS84,
+			p1:        geom.Coord{10, 20},
+			p2:        geom.Coord{10, 20},
+			distance:  0,
+			azimuth1:  0,
+			azimuth2:  0,
+		},
+		{
+			desc:      "sphere",
+			ellipsoid: geodesic.NewEllipsoid(6371000, 0),
+			p1:        geom.Coord{0, 0},
+			p2:        geom.Coord{90, 0},
+			distance:  6371000 * math.Pi / 2,
+			azimuth1:  90,
+			azimuth2:  90,
+		},
+	} {
+		distance, azimuth1, azimuth2 := tc.ellipsoid.Inverse(tc.p1, tc.p2)
+		if math.Abs(distance-tc.distance) > 1e-3 || angleDiff(azimuth1, tc.azimuth1) > 1e-6 || angleDiff(azimuth2, tc.azimuth2) > 1e-6 {
+			t.Errorf("%d: %s: Inverse(%v, %v) == %v, %v, %v, want %v, %v, %v", i, tc.desc, tc.p1, tc.p2, distance, azimuth1, azimuth2, tc.distance, tc.azimuth1, tc.azimuth2)
+		}
+		if tc.distance == 0 {
+			continue
+		}
+		dest, azimuth := tc.ellipsoid.Direct(tc.p1, azimuth1, distance)
+		if d := tc.ellipsoid.Distance(dest, tc.p2); d > 1e-3 || angleDiff(azimuth, azimuth2) > 1e-6 {
+			t.Errorf("%d: %s: Direct(%v, %v, %v) == %v, %v, want %v, %v", i, tc.desc, tc.p1, azimuth1, distance, dest, azimuth, tc.p2, azimuth2)
+		}
+	}
+}
+
+func TestInverseSymmetric(t *testing.T) {
+	for _, lat := range []float64{-80, -45, -10, 0, 0.3, 30, 60, 89} {
+		for _, dlon := range []float64{0.1, 10, 90, 170, 179, 179.8, 180} {
+			p1 := geom.Coord{20, lat}
+			p2 := geom.Coord{20 + dlon, -lat + 0.2}
+			s12, azimuth1, azimuth2 := geodesic.Inverse(p1, p2)
+			s21, _, _ := geodesic.Inverse(p2, p1)
+			if math.Abs(s12-s21) > 1e-6 {
+				t.Errorf("Inverse(%v, %v) distance %v != reverse distance %v", p1, p2, s12, s21)
+			}
+			dest, azimuth := geodesic.Direct(p1, azimuth1, s12)
+			if d := geodesic.Distance(dest, p2); d > 1e-3 || angleDiff(azimuth, azimuth2) > 1e-5 {
+				t.Errorf("Direct(%v, %v, %v) == %v, %v, want %v, %v", p1, azimuth1, s12, dest, azimuth, p2, azimuth2)
+			}
+		}
+	}
+}
+
+func TestDestination(t *testing.T) {
+	p := geom.Coord{116.4, 39.9, 50, 7}
+	dest := geodesic.Destination(p, 45, 100000)
+	if len(dest) != 4 || dest[2] != 50 || dest[3] != 7 {
+		t.Errorf("Destination(%v, 45, 100000) == %v, want other ordinates preserved", p, dest)
+	}
+	if d := geodesic.Distance(p, dest); math.Abs(d-100000) > 1e-3 {
+		t.Errorf("Distance(%v, %v) == %v, want 100000", p, dest, d)
+	}
+	if azimuth := geodesic.InitialAzimuth(p, dest); angleDiff(azimuth, 45) > 1e-6 {
+		t.Errorf("InitialAzimuth(%v, %v) == %v, want 45", p, dest, azimuth)
+	}
+	back := geodesic.Destination(dest, geodesic.FinalAzimuth(p, dest)+180, 100000)
+	if d := geodesic.Distance(p, back); d > 1e-3 {
+		t.Errorf("Destination back to %v == %v", p, back)
+	}
+}
+
+func TestLength(t *testing.T) {
+	for i, tc := range []struct {
+		wkt      string
+		expected float64
+	}{
+		{wkt: "POINT (1 2)", expected: 0},
+		{wkt: "LINESTRING (0 0, 1 0, 2 0)", expected: 2 * 111319.491},
+		{wkt: "LINESTRING Z (0 0 100, 1 0 200)", expected: 111319.491},
+		{wkt: "MULTILINESTRING ((0 0, 1 0), (30 0, 30 90))", expected: 111319.491 + 10001965.729},
+		{wkt: "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", expected: 443770.917},
+		{wkt: "GEOMETRYCOLLECTION (POINT (0 0), LINESTRING (0 0, 1 0), LINESTRING (0 0, 1 0))", expected: 2 * 111319.491},
+	} {
+		g, err := wkt.Unmarshal(tc.wkt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		length, err := geodesic.Length(g)
+		if err != nil {
+			t.Errorf("%d: Length(%s) returned error %v", i, tc.wkt, err)
+		} else if math.Abs(length-tc.expected) > 1e-3 {
+			t.Errorf("%d: Length(%s) == %v, want %v", i, tc.wkt, length, tc.expected)
+		}
+	}
+}
+
+func TestArea(t *testing.T) {
+	// WGS84 椭球的总面积
+	const total = 510065621724088.5
+	// GeographicLib（以及 PostGIS 的 ST_Area）给出的赤道上 1° × 1° 的正方形的面积，保留了两位小数
+	const square = 12308778361.47
+	// 赤道上 0.001° × 0.001° 的正方形的面积，按两条经线和两条纬线围成的区域精确计算，
+	// 北边的测地线与纬线之间的面积小于1e-6平方米
+	const smallSquare = 12309.0720787
+	for i, tc := range []struct {
+		wkt      string
+		expected float64
+		epsilon  float64
+	}{
+		{wkt: "POINT (1 2)", expected: 0},
+		{wkt: "LINESTRING (0 0, 1 0, 1 1)", expected: 0},
+		{wkt: "POLYGON ((0 0, 90 0, 0 90, 0 0))", expected: total / 8, epsilon: 1},
+		{wkt: "POLYGON ((0 0, 0 90, 90 0, 0 0))", expected: total / 8, epsilon: 1},
+		{wkt: "POLYGON ((0 0, -90 0, 0 -90, 0 0))", expected: total / 8, epsilon: 1},
+		{wkt: "POLYGON ((0 0, 90 0, 180 0, -90 0, 0 0))", expected: total / 2, epsilon: 1},
+		// 以极点为顶点、经度差为1°的三角形是椭球面积的1/720
+		{wkt: "POLYGON ((0 0, 1 0, 0 90, 0 0))", expected: total / 720, epsilon: 1e-2},
+		{wkt: "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", expected: square, epsilon: 1e-2},
+		{wkt: "POLYGON ((0 0, 0 1, 1 1, 1 0, 0 0))", expected: square, epsilon: 1e-2},
+		{wkt: "POLYGON ((0 0, 1 0, 1 -1, 0 -1, 0 0))", expected: square, epsilon: 1e-2},
+		{wkt: "POLYGON ((0 0, 0.001 0, 0.001 0.001, 0 0.001, 0 0))", expected: smallSquare, epsilon: 1e-3},
+		{wkt: "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0), (0 0, 1 0, 1 1, 0 1, 0 0))", expected: 0, epsilon: 1e-3},
+		{wkt: "MULTIPOLYGON (((0 0, 90 0, 0 90, 0 0)), ((0 0, -90 0, 0 -90, 0 0)))", expected: total / 4, epsilon: 1},
+		{wkt: "GEOMETRYCOLLECTION (POINT (0 0), POLYGON ((0 0, 90 0, 0 90, 0 0)))", expected: total / 8, epsilon: 1},
+	} {
+		g, err := wkt.Unmarshal(tc.wkt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		area, err := geodesic.Area(g)
+		if err != nil {
+			t.Errorf("%d: Area(%s) returned error %v", i, tc.wkt, err)
+		} else if math.Abs(area-tc.expected) > tc.epsilon {
+			t.Errorf("%d: Area(%s) == %v, want %v", i, tc.wkt, area, tc.expected)
+		}
+	}
+}
+
+func TestLinearRing(t *testing.T) {
+	lr := geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 1, 0, 0})
+	if area, err := geodesic.Area(lr); err != nil || math.Abs(area-12308778361.47) > 1e-2 {
+		t.Errorf("Area(%v) == %v, %v, want 12308778361.47, <nil>", lr.FlatCoords(), area, err)
+	}
+	if perimeter, err := geodesic.Perimeter(lr); err != nil || math.Abs(perimeter-443770.917) > 1e-2 {
+		t.Errorf("Perimeter(%v) == %v, %v, want 443770.917, <nil>", lr.FlatCoords(), perimeter, err)
+	}
+}
+
+func TestAreaAroundPole(t *testing.T) {
+	for _, lat := range []float64{45, -45, 89.9} {
+		// 包围极点的线环的面积等于以极点为顶点的四个三角形的面积之和
+		ring := geom.NewPolygonFlat(geom.XY, []float64{0, lat, 90, lat, 180, lat, -90, lat, 0, lat}, []int{10})
+		area, err := geodesic.Area(ring)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pole := math.Copysign(90, lat)
+		var sum float64
+		for k := 0.0; k < 4; k++ {
+			triangle := geom.NewPolygonFlat(geom.XY, []float64{90 * k, lat, 90 * (k + 1), lat, 0, pole, 90 * k, lat}, []int{8})
+			a, err := geodesic.Area(triangle)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sum += a
+		}
+		if math.Abs(area-sum) > 1e-6*sum {
+			t.Errorf("Area(%v) == %v, want %v", ring.FlatCoords(), area, sum)
+		}
+	}
+}
+
+func TestPerimeter(t *testing.T) {
+	for i, tc := range []struct {
+		wkt      string
+		expected float64
+	}{
+		{wkt: "LINESTRING (0 0, 1 0)", expected: 0},
+		{wkt: "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0), (0 0, 1 0, 1 1, 0 1, 0 0))", expected: 2 * 443770.917},
+		{wkt: "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((30 0, 30 90, 31 0, 30 0)))", expected: 443770.917 + 2*10001965.729 + 111319.491},
+	} {
+		g, err := wkt.Unmarshal(tc.wkt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		perimeter, err := geodesic.Perimeter(g)
+		if err != nil {
+			t.Errorf("%d: Perimeter(%s) returned error %v", i, tc.wkt, err)
+		} else if math.Abs(perimeter-tc.expected) > 1e-2 {
+			t.Errorf("%d: Perimeter(%s) == %v, want %v", i, tc.wkt, perimeter, tc.expected)
+		}
+	}
+}
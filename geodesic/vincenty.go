@@ -0,0 +1,195 @@
+package geodesic
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// maxIterations 是迭代计算的最大次数
+const maxIterations = 200
+
+// Inverse方法 求解测地线反算问题：返回从 p1 到 p2 的测地线距离（米）、在 p1 处的起始方位角和在 p2 处的终止方位角（度）。
+// 方位角从北方向顺时针计算，范围为 (-180, 180]。p1 与 p2 重合时方位角为0。
+//
+// 使用 Vincenty 公式迭代求解，对于接近对跖的两点 Vincenty 迭代不收敛，此时使用二分法求解起始方位角
+func (e Ellipsoid) Inverse(p1, p2 geom.Coord) (distance, azimuth1, azimuth2 float64) {
+	phi1, phi2 := toRadians(p1[1]), toRadians(p2[1])
+	l := toRadians(normalizeAngle(p2[0] - p1[0]))
+	s, alpha1, alpha2, _, _, ok := e.inverseVincenty(phi1, phi2, l)
+	if !ok {
+		s, alpha1, alpha2 = e.inverseBisection(phi1, phi2, l)
+	}
+	return s, normalizeAngle(toDegrees(alpha1)), normalizeAngle(toDegrees(alpha2))
+}
+
+// Direct方法 求解测地线正算问题：返回从 p 出发沿方位角 azimuth（度）行进 distance 米到达的点，以及在该点的方位角。
+// 返回的坐标是 p 的拷贝，只替换了经度和纬度
+func (e Ellipsoid) Direct(p geom.Coord, azimuth, distance float64) (geom.Coord, float64) {
+	b := e.B()
+	sinU1, cosU1 := e.reducedLatitude(toRadians(p[1]))
+	sinAlpha1, cosAlpha1 := math.Sincos(toRadians(azimuth))
+	sigma1 := math.Atan2(sinU1, cosU1*cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cos2Alpha := 1 - sinAlpha*sinAlpha
+	a, bb := e.seriesCoefficients(cos2Alpha)
+
+	sigma := distance / (b * a)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	for i := 0; i < maxIterations; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		next := distance/(b*a) + deltaSigma(bb, sinSigma, cosSigma, cos2SigmaM)
+		if math.Abs(next-sigma) < 1e-12 {
+			sigma = next
+			break
+		}
+		sigma = next
+	}
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+	sinSigma, cosSigma = math.Sincos(sigma)
+
+	tmp := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	phi2 := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-e.F)*math.Hypot(sinAlpha, tmp))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	l := lambda - e.lambdaCorrection(sinAlpha, cos2Alpha, sigma, sinSigma, cosSigma, cos2SigmaM)
+
+	dest := append(geom.Coord(nil), p...)
+	dest[0] = normalizeAngle(p[0] + toDegrees(l))
+	dest[1] = toDegrees(phi2)
+	return dest, normalizeAngle(toDegrees(math.Atan2(sinAlpha, -tmp)))
+}
+
+// inverseVincenty 方法使用 Vincenty 公式求解反算问题，不收敛时 ok 为 false。
+// sigma 和 omega 为辅助球面上的角距离和经度差，角度单位均为弧度
+func (e Ellipsoid) inverseVincenty(phi1, phi2, l float64) (s, alpha1, alpha2, sigma, omega float64, ok bool) {
+	sinU1, cosU1 := e.reducedLatitude(phi1)
+	sinU2, cosU2 := e.reducedLatitude(phi2)
+	lambda := l
+	for i := 0; i < maxIterations; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		if sinSigma == 0 {
+			// 两点重合，或者位于赤道上的对跖点
+			return 0, 0, 0, 0, 0, cosSigma > 0
+		}
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+		cos2SigmaM := 0.0
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		prev := lambda
+		lambda = l + e.lambdaCorrection(sinAlpha, cos2Alpha, sigma, sinSigma, cosSigma, cos2SigmaM)
+		if math.Abs(lambda) > math.Pi {
+			return 0, 0, 0, 0, 0, false
+		}
+		// 收敛条件与经度差成比例，面积使用的 lambda 的误差乘以 c² 后需要远小于1平方米
+		if math.Abs(lambda-prev) <= 1e-15*math.Abs(l) {
+			sinLambda, cosLambda = math.Sincos(lambda)
+			s = e.distance(cos2Alpha, sigma, sinSigma, cosSigma, cos2SigmaM)
+			alpha1 = math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+			alpha2 = math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda)
+			return s, alpha1, alpha2, sigma, lambda, true
+		}
+	}
+	return 0, 0, 0, 0, 0, false
+}
+
+// inverseBisection 方法使用二分法求解反算问题，适用于包括接近对跖点在内的所有情况。
+//
+// 首先将问题变换为标准形式：phi1 <= 0，|phi2| <= |phi1|，0 <= l <= pi。此时经度差是起始方位角
+// 在 [0, pi] 上的单调函数（见 Karney, "Algorithms for geodesics"），可以使用二分法求解起始方位角
+func (e Ellipsoid) inverseBisection(phi1, phi2, l float64) (s, alpha1, alpha2 float64) {
+	swapped := math.Abs(phi1) < math.Abs(phi2)
+	if swapped {
+		phi1, phi2, l = phi2, phi1, -l
+	}
+	latSign := 1.0
+	if phi1 > 0 {
+		latSign, phi1, phi2 = -1, -phi1, -phi2
+	}
+	lonSign := 1.0
+	if l < 0 {
+		lonSign, l = -1, -l
+	}
+
+	sinU1, cosU1 := e.reducedLatitude(phi1)
+	sinU2, cosU2 := e.reducedLatitude(phi2)
+	// 赤道上的点使用 -0，使得 sigma1 位于 [-pi, 0]
+	sinU1 = -math.Abs(sinU1)
+
+	var sigma12, sinAlpha0, cos2Alpha0, cosAlpha2CosU2, cos2SigmaM float64
+	solve := func(alpha float64) float64 {
+		sinAlpha, cosAlpha := math.Sincos(alpha)
+		sinAlpha0 = sinAlpha * cosU1
+		cos2Alpha0 = 1 - sinAlpha0*sinAlpha0
+		sigma1 := math.Atan2(sinU1, cosAlpha*cosU1)
+		// 测地线向北到达点2所在的纬度
+		cosAlpha2CosU2 = math.Sqrt(math.Max(0, cosAlpha*cosAlpha*cosU1*cosU1+(cosU2*cosU2-cosU1*cosU1)))
+		sigma2 := math.Atan2(sinU2, cosAlpha2CosU2)
+		omega1 := math.Atan2(sinAlpha0*math.Sin(sigma1), math.Cos(sigma1))
+		omega2 := math.Atan2(sinAlpha0*math.Sin(sigma2), math.Cos(sigma2))
+		sigma12 = sigma2 - sigma1
+		cos2SigmaM = math.Cos(sigma1 + sigma2)
+		sinSigma12, cosSigma12 := math.Sincos(sigma12)
+		return omega2 - omega1 - e.lambdaCorrection(sinAlpha0, cos2Alpha0, sigma12, sinSigma12, cosSigma12, cos2SigmaM)
+	}
+	lo, hi := 0.0, math.Pi
+	for i := 0; i < maxIterations && hi-lo > 1e-15; i++ {
+		mid := (lo + hi) / 2
+		if solve(mid) < l {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	alpha1 = (lo + hi) / 2
+	solve(alpha1)
+	sinSigma12, cosSigma12 := math.Sincos(sigma12)
+	s = e.distance(cos2Alpha0, sigma12, sinSigma12, cosSigma12, cos2SigmaM)
+	alpha2 = math.Atan2(sinAlpha0, cosAlpha2CosU2)
+
+	alpha1, alpha2 = lonSign*alpha1, lonSign*alpha2
+	if latSign < 0 {
+		alpha1, alpha2 = math.Pi-alpha1, math.Pi-alpha2
+	}
+	if swapped {
+		alpha1, alpha2 = alpha2+math.Pi, alpha1+math.Pi
+	}
+	return s, alpha1, alpha2
+}
+
+// reducedLatitude 方法返回归化纬度的正弦和余弦
+func (e Ellipsoid) reducedLatitude(phi float64) (float64, float64) {
+	sinPhi, cosPhi := math.Sincos(phi)
+	return math.Sincos(math.Atan2((1-e.F)*sinPhi, cosPhi))
+}
+
+// seriesCoefficients 方法返回 Vincenty 公式中的 A 和 B 系数
+func (e Ellipsoid) seriesCoefficients(cos2Alpha float64) (float64, float64) {
+	b := e.B()
+	u2 := cos2Alpha * (e.A*e.A - b*b) / (b * b)
+	a := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+	bb := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+	return a, bb
+}
+
+// distance 方法返回辅助球面上角距离 sigma 对应的椭球面上的测地线距离
+func (e Ellipsoid) distance(cos2Alpha, sigma, sinSigma, cosSigma, cos2SigmaM float64) float64 {
+	a, bb := e.seriesCoefficients(cos2Alpha)
+	return e.B() * a * (sigma - deltaSigma(bb, sinSigma, cosSigma, cos2SigmaM))
+}
+
+// lambdaCorrection 方法返回辅助球面上的经度差与椭球面上的经度差之差
+func (e Ellipsoid) lambdaCorrection(sinAlpha, cos2Alpha, sigma, sinSigma, cosSigma, cos2SigmaM float64) float64 {
+	c := e.F / 16 * cos2Alpha * (4 + e.F*(4-3*cos2Alpha))
+	return (1 - c) * e.F * sinAlpha * (sigma + c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+}
+
+func deltaSigma(b, sinSigma, cosSigma, cos2SigmaM float64) float64 {
+	return b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+}
@@ -0,0 +1,405 @@
+// Package strtree 实现了使用 Sort-Tile-Recursive (STR) 算法批量装载的 R 树空间索引。
+//
+// 每个条目都以一个 *geom.Bounds 作为键，只使用边界的 x、y 维度。条目首先通过 Insert 添加，
+// 然后通过 Build 一次性构建成树（第一次查询时也会自动构建），构建之后不能再添加新的条目，但可以移除条目。
+// 构建之后，Tree 的所有方法都可以被多个 goroutine 并发调用。
+//
+// See Leutenegger, Lopez and Edgington, "STR: A Simple and Efficient Algorithm for R-Tree Packing".
+package strtree
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// DefaultNodeCapacity 是每个节点默认的最大子节点数目
+const DefaultNodeCapacity = 10
+
+// ErrBuilt 将会被返回，当树已经构建之后还试图添加新的条目时
+var ErrBuilt = errors.New("strtree: cannot insert into a built tree")
+
+// Visitor 是访问条目的函数，返回 false 时停止访问
+type Visitor func(bounds *geom.Bounds, item interface{}) bool
+
+// ItemDistance 计算查询边界与条目之间的距离，用于最近邻查询。
+// 返回的距离不能小于两者边界之间的距离
+type ItemDistance func(bounds *geom.Bounds, item interface{}) float64
+
+// Tree 是一个 STR 树
+type Tree struct {
+	mu           sync.RWMutex
+	nodeCapacity int
+	entries      []*entry
+	root         *node
+	built        bool
+	size         int
+}
+
+// envelope 是二维的边界框
+type envelope struct {
+	minX, minY, maxX, maxY float64
+}
+
+// entry 是树中的一个条目
+type entry struct {
+	envelope
+	bounds *geom.Bounds
+	item   interface{}
+}
+
+// node 是树中的一个节点，叶子节点包含条目，其他节点包含子节点
+type node struct {
+	envelope
+	children []*node
+	entries  []*entry
+}
+
+// NewTree函数 创建一个空的 STR 树，nodeCapacity 是每个节点的最大子节点数目，小于2时使用 DefaultNodeCapacity
+func NewTree(nodeCapacity int) *Tree {
+	if nodeCapacity < 2 {
+		nodeCapacity = DefaultNodeCapacity
+	}
+	return &Tree{nodeCapacity: nodeCapacity}
+}
+
+/**
+*------------------------------
+*				Tree（STR 树）相关的方法
+*---------------------------------
+ */
+
+// Insert方法 添加一个以 bounds 为键的条目。空的边界将被忽略。树构建之后调用将返回 ErrBuilt
+func (t *Tree) Insert(bounds *geom.Bounds, item interface{}) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.built {
+		return ErrBuilt
+	}
+	if bounds == nil || bounds.IsEmpty() {
+		return nil
+	}
+	t.entries = append(t.entries, &entry{envelope: newEnvelope(bounds), bounds: bounds, item: item})
+	return nil
+}
+
+// Build方法 使用已添加的条目构建树。重复调用没有影响
+func (t *Tree) Build() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.build()
+}
+
+// Len方法 返回树中条目的数目
+func (t *Tree) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if !t.built {
+		return len(t.entries)
+	}
+	return t.size
+}
+
+// Query方法 返回边界与 bounds 重叠的所有条目
+func (t *Tree) Query(bounds *geom.Bounds) []interface{} {
+	var items []interface{}
+	t.Visit(bounds, func(_ *geom.Bounds, item interface{}) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// Visit方法 对边界与 bounds 重叠的每个条目调用 visitor，直到 visitor 返回 false。
+// visitor 中不能调用 Remove
+func (t *Tree) Visit(bounds *geom.Bounds, visitor Visitor) {
+	t.rlockBuilt()
+	defer t.mu.RUnlock()
+	if t.root == nil || bounds == nil || bounds.IsEmpty() {
+		return
+	}
+	t.root.visit(newEnvelope(bounds), visitor)
+}
+
+// Nearest方法 返回距离 bounds 最近的至多 k 个条目，按距离从近到远排序。
+// 如果 distance 为 nil，使用边界之间的距离；否则使用 distance 计算的距离
+func (t *Tree) Nearest(bounds *geom.Bounds, k int, distance ItemDistance) []interface{} {
+	t.rlockBuilt()
+	defer t.mu.RUnlock()
+	if t.root == nil || bounds == nil || bounds.IsEmpty() || k <= 0 {
+		return nil
+	}
+	query := newEnvelope(bounds)
+	queue := &nearestQueue{{node: t.root, distance: query.distance(t.root.envelope)}}
+	var items []interface{}
+	for queue.Len() > 0 && len(items) < k {
+		c := heap.Pop(queue).(nearestCandidate)
+		switch {
+		case c.node != nil:
+			for _, child := range c.node.children {
+				heap.Push(queue, nearestCandidate{node: child, distance: query.distance(child.envelope)})
+			}
+			for _, e := range c.node.entries {
+				heap.Push(queue, nearestCandidate{entry: e, distance: query.distance(e.envelope), exact: distance == nil})
+			}
+		case !c.exact:
+			// 边界之间的距离是下界，计算准确的距离之后重新加入队列
+			heap.Push(queue, nearestCandidate{entry: c.entry, distance: distance(bounds, c.entry.item), exact: true})
+		default:
+			items = append(items, c.entry.item)
+		}
+	}
+	return items
+}
+
+// Remove方法 移除以 bounds 为键的条目 item，条目之间使用 == 比较，因此 item 必须是可比较的类型，
+// 否则比较时将抛出 panic。切片、map 等不可比较的条目使用 RemoveFunc 移除。
+// 如果条目存在并被移除返回 true
+func (t *Tree) Remove(bounds *geom.Bounds, item interface{}) bool {
+	return t.RemoveFunc(bounds, func(other interface{}) bool { return other == item })
+}
+
+// RemoveFunc方法 移除以 bounds 为键、使 match 返回 true 的第一个条目，match 中不能调用 Tree 的方法。
+// 如果条目存在并被移除返回 true
+func (t *Tree) RemoveFunc(bounds *geom.Bounds, match func(item interface{}) bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if bounds == nil || bounds.IsEmpty() {
+		return false
+	}
+	env := newEnvelope(bounds)
+	if !t.built {
+		for i, e := range t.entries {
+			if e.envelope == env && match(e.item) {
+				t.entries = append(t.entries[:i], t.entries[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+	if t.root != nil && t.root.remove(env, match) {
+		t.size--
+		return true
+	}
+	return false
+}
+
+// rlockBuilt 方法在需要时构建树，并获取读锁
+func (t *Tree) rlockBuilt() {
+	t.mu.RLock()
+	if t.built {
+		return
+	}
+	t.mu.RUnlock()
+	t.mu.Lock()
+	t.build()
+	t.mu.Unlock()
+	t.mu.RLock()
+}
+
+func (t *Tree) build() {
+	if t.built {
+		return
+	}
+	t.built = true
+	t.size = len(t.entries)
+	if len(t.entries) == 0 {
+		return
+	}
+	level := t.packLeaves(t.entries)
+	for len(level) > 1 {
+		level = t.packNodes(level)
+	}
+	t.root = level[0]
+	t.entries = nil
+}
+
+// packLeaves 方法使用 STR 算法将条目装入叶子节点
+func (t *Tree) packLeaves(entries []*entry) []*node {
+	envelopes := make([]envelope, len(entries))
+	for i, e := range entries {
+		envelopes[i] = e.envelope
+	}
+	var nodes []*node
+	strPack(envelopes, t.nodeCapacity, func(i, j int) {
+		envelopes[i], envelopes[j] = envelopes[j], envelopes[i]
+		entries[i], entries[j] = entries[j], entries[i]
+	}, func(start, end int) {
+		n := &node{entries: append([]*entry(nil), entries[start:end]...)}
+		n.updateEnvelope()
+		nodes = append(nodes, n)
+	})
+	return nodes
+}
+
+// packNodes 方法使用 STR 算法将一层节点装入上一层节点
+func (t *Tree) packNodes(children []*node) []*node {
+	envelopes := make([]envelope, len(children))
+	for i, c := range children {
+		envelopes[i] = c.envelope
+	}
+	var nodes []*node
+	strPack(envelopes, t.nodeCapacity, func(i, j int) {
+		envelopes[i], envelopes[j] = envelopes[j], envelopes[i]
+		children[i], children[j] = children[j], children[i]
+	}, func(start, end int) {
+		n := &node{children: append([]*node(nil), children[start:end]...)}
+		n.updateEnvelope()
+		nodes = append(nodes, n)
+	})
+	return nodes
+}
+
+// strPack 函数将边界按中心的 x 坐标排序并分成垂直的条带，每个条带内再按中心的 y 坐标排序，
+// 然后每 capacity 个分为一组，对每一组调用 group
+func strPack(envelopes []envelope, capacity int, swap func(i, j int), group func(start, end int)) {
+	n := len(envelopes)
+	numGroups := (n + capacity - 1) / capacity
+	numSlices := int(math.Ceil(math.Sqrt(float64(numGroups))))
+	sliceCapacity := ((n+numSlices-1)/numSlices + capacity - 1) / capacity * capacity
+
+	sort.Sort(envelopeSorting{envelopes: envelopes, swap: swap, dim: 0})
+	for sliceStart := 0; sliceStart < n; sliceStart += sliceCapacity {
+		sliceEnd := sliceStart + sliceCapacity
+		if sliceEnd > n {
+			sliceEnd = n
+		}
+		sort.Sort(envelopeSorting{envelopes: envelopes[sliceStart:sliceEnd], swap: func(i, j int) { swap(sliceStart+i, sliceStart+j) }, dim: 1})
+		for start := sliceStart; start < sliceEnd; start += capacity {
+			end := start + capacity
+			if end > sliceEnd {
+				end = sliceEnd
+			}
+			group(start, end)
+		}
+	}
+}
+
+// envelopeSorting 按边界中心在 dim 维度上的坐标排序，swap 同时交换关联的数据
+type envelopeSorting struct {
+	envelopes []envelope
+	swap      func(i, j int)
+	dim       int
+}
+
+func (s envelopeSorting) Len() int { return len(s.envelopes) }
+
+func (s envelopeSorting) Less(i, j int) bool {
+	return s.envelopes[i].centre(s.dim) < s.envelopes[j].centre(s.dim)
+}
+
+func (s envelopeSorting) Swap(i, j int) { s.swap(i, j) }
+
+func newEnvelope(b *geom.Bounds) envelope {
+	return envelope{minX: b.Min(0), minY: b.Min(1), maxX: b.Max(0), maxY: b.Max(1)}
+}
+
+func (e envelope) centre(dim int) float64 {
+	if dim == 0 {
+		return (e.minX + e.maxX) / 2
+	}
+	return (e.minY + e.maxY) / 2
+}
+
+func (e envelope) overlaps(other envelope) bool {
+	return e.minX <= other.maxX && other.minX <= e.maxX && e.minY <= other.maxY && other.minY <= e.maxY
+}
+
+func (e envelope) expand(other envelope) envelope {
+	return envelope{
+		minX: math.Min(e.minX, other.minX),
+		minY: math.Min(e.minY, other.minY),
+		maxX: math.Max(e.maxX, other.maxX),
+		maxY: math.Max(e.maxY, other.maxY),
+	}
+}
+
+// distance 方法返回两个边界框之间的距离，重叠时为0
+func (e envelope) distance(other envelope) float64 {
+	dx := math.Max(0, math.Max(e.minX-other.maxX, other.minX-e.maxX))
+	dy := math.Max(0, math.Max(e.minY-other.maxY, other.minY-e.maxY))
+	return math.Hypot(dx, dy)
+}
+
+func (n *node) updateEnvelope() {
+	env := envelope{minX: math.Inf(1), minY: math.Inf(1), maxX: math.Inf(-1), maxY: math.Inf(-1)}
+	for _, c := range n.children {
+		env = env.expand(c.envelope)
+	}
+	for _, e := range n.entries {
+		env = env.expand(e.envelope)
+	}
+	n.envelope = env
+}
+
+// visit 方法访问与 query 重叠的条目，visitor 返回 false 时返回 false
+func (n *node) visit(query envelope, visitor Visitor) bool {
+	if !n.overlaps(query) {
+		return true
+	}
+	for _, c := range n.children {
+		if !c.visit(query, visitor) {
+			return false
+		}
+	}
+	for _, e := range n.entries {
+		if e.overlaps(query) && !visitor(e.bounds, e.item) {
+			return false
+		}
+	}
+	return true
+}
+
+// remove 方法从子树中移除条目，并更新节点的边界
+func (n *node) remove(env envelope, match func(item interface{}) bool) bool {
+	if !n.overlaps(env) {
+		return false
+	}
+	for i, e := range n.entries {
+		if e.envelope == env && match(e.item) {
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			n.updateEnvelope()
+			return true
+		}
+	}
+	for i, c := range n.children {
+		if c.remove(env, match) {
+			if len(c.children) == 0 && len(c.entries) == 0 {
+				n.children = append(n.children[:i], n.children[i+1:]...)
+			}
+			n.updateEnvelope()
+			return true
+		}
+	}
+	return false
+}
+
+// nearestCandidate 是最近邻查询中的候选节点或条目，exact 表示 distance 是否为准确的距离
+type nearestCandidate struct {
+	node     *node
+	entry    *entry
+	distance float64
+	exact    bool
+}
+
+// nearestQueue 是按距离排序的最小堆
+type nearestQueue []nearestCandidate
+
+func (q nearestQueue) Len() int { return len(q) }
+
+func (q nearestQueue) Less(i, j int) bool { return q[i].distance < q[j].distance }
+
+func (q nearestQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *nearestQueue) Push(x interface{}) { *q = append(*q, x.(nearestCandidate)) }
+
+func (q *nearestQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,232 @@
+package strtree_test
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/index/strtree"
+)
+
+// grid 函数创建一个 n*n 的点网格树，条目为点的序号 y*n+x
+func grid(t *testing.T, n, capacity int) *strtree.Tree {
+	tree := strtree.NewTree(capacity)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			b := geom.NewBounds(geom.XY).Set(float64(x), float64(y), float64(x), float64(y))
+			if err := tree.Insert(b, y*n+x); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return tree
+}
+
+func sorted(items []interface{}) []int {
+	ints := make([]int, len(items))
+	for i, item := range items {
+		ints[i] = item.(int)
+	}
+	sort.Ints(ints)
+	return ints
+}
+
+func TestQuery(t *testing.T) {
+	for _, capacity := range []int{2, 3, 10} {
+		tree := grid(t, 20, capacity)
+		for i, tc := range []struct {
+			bounds   *geom.Bounds
+			expected []int
+		}{
+			{bounds: geom.NewBounds(geom.XY).Set(0, 0, 1, 1), expected: []int{0, 1, 20, 21}},
+			{bounds: geom.NewBounds(geom.XY).Set(18.5, 18.5, 30, 30), expected: []int{399}},
+			{bounds: geom.NewBounds(geom.XY).Set(4.5, 7, 6.5, 7), expected: []int{145, 146}},
+			{bounds: geom.NewBounds(geom.XY).Set(-2, -2, -1, -1), expected: []int{}},
+			{bounds: geom.NewBounds(geom.XY), expected: []int{}},
+		} {
+			if got := sorted(tree.Query(tc.bounds)); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("capacity %d, test %d: Query(%v) == %v, want %v", capacity, i, tc.bounds, got, tc.expected)
+			}
+		}
+		if got := len(tree.Query(geom.NewBounds(geom.XY).Set(-1, -1, 20, 20))); got != 400 {
+			t.Errorf("capacity %d: len(Query(all)) == %d, want 400", capacity, got)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	tree := strtree.NewTree(0)
+	b := geom.NewBounds(geom.XY).Set(0, 0, 1, 1)
+	if got := tree.Query(b); got != nil {
+		t.Errorf("Query(%v) == %v, want nil", b, got)
+	}
+	if got := tree.Nearest(b, 3, nil); got != nil {
+		t.Errorf("Nearest(%v, 3, nil) == %v, want nil", b, got)
+	}
+	if tree.Remove(b, 1) {
+		t.Errorf("Remove(%v, 1) == true, want false", b)
+	}
+	if got := tree.Len(); got != 0 {
+		t.Errorf("Len() == %d, want 0", got)
+	}
+}
+
+func TestInsertAfterBuild(t *testing.T) {
+	tree := grid(t, 2, 0)
+	tree.Build()
+	if err := tree.Insert(geom.NewBounds(geom.XY).Set(0, 0, 1, 1), 5); err != strtree.ErrBuilt {
+		t.Errorf("Insert after Build returned %v, want %v", err, strtree.ErrBuilt)
+	}
+}
+
+func TestVisit(t *testing.T) {
+	tree := grid(t, 10, 4)
+	n := 0
+	tree.Visit(geom.NewBounds(geom.XY).Set(0, 0, 9, 9), func(b *geom.Bounds, item interface{}) bool {
+		if b.Min(0) != float64(item.(int)%10) || b.Min(1) != float64(item.(int)/10) {
+			t.Errorf("item %v visited with bounds %v", item, b)
+		}
+		n++
+		return n < 7
+	})
+	if n != 7 {
+		t.Errorf("visited %d items, want 7", n)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	tree := grid(t, 20, 4)
+	for i, tc := range []struct {
+		bounds   *geom.Bounds
+		k        int
+		expected []int
+	}{
+		{bounds: geom.NewBounds(geom.XY).Set(5.1, 5.2, 5.1, 5.2), k: 1, expected: []int{105}},
+		{bounds: geom.NewBounds(geom.XY).Set(5.1, 5.2, 5.1, 5.2), k: 3, expected: []int{105, 125, 106}},
+		{bounds: geom.NewBounds(geom.XY).Set(-3, -4, -3, -4), k: 2, expected: []int{0, 1}},
+		{bounds: geom.NewBounds(geom.XY).Set(30, 0.1, 30, 0.1), k: 2, expected: []int{19, 39}},
+		{bounds: geom.NewBounds(geom.XY).Set(0, 0, 0, 0), k: 0, expected: nil},
+	} {
+		var got []int
+		for _, item := range tree.Nearest(tc.bounds, tc.k, nil) {
+			got = append(got, item.(int))
+		}
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%d: Nearest(%v, %d, nil) == %v, want %v", i, tc.bounds, tc.k, got, tc.expected)
+		}
+	}
+	if got := len(tree.Nearest(geom.NewBounds(geom.XY).Set(0, 0, 0, 0), 1000, nil)); got != 400 {
+		t.Errorf("len(Nearest(k=1000)) == %d, want 400", got)
+	}
+}
+
+func TestNearestItemDistance(t *testing.T) {
+	// 边界相同的线段，按照点到线段的实际距离排序
+	tree := strtree.NewTree(2)
+	segments := [][4]float64{
+		{0, 0, 10, 10},
+		{0, 10, 10, 0},
+		{0, 0, 10, 0},
+		{0, 10, 10, 10},
+	}
+	for i := range segments {
+		if err := tree.Insert(geom.NewBounds(geom.XY).Set(0, 0, 10, 10), i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	distance := func(b *geom.Bounds, item interface{}) float64 {
+		s := segments[item.(int)]
+		px, py := b.Min(0), b.Min(1)
+		dx, dy := s[2]-s[0], s[3]-s[1]
+		r := math.Max(0, math.Min(1, ((px-s[0])*dx+(py-s[1])*dy)/(dx*dx+dy*dy)))
+		return math.Hypot(px-s[0]-r*dx, py-s[1]-r*dy)
+	}
+	b := geom.NewBounds(geom.XY).Set(2, 9, 2, 9)
+	var got []int
+	for _, item := range tree.Nearest(b, 4, distance) {
+		got = append(got, item.(int))
+	}
+	if expected := []int{1, 3, 0, 2}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Nearest(%v, 4, distance) == %v, want %v", b, got, expected)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	tree := grid(t, 10, 3)
+	all := geom.NewBounds(geom.XY).Set(0, 0, 9, 9)
+	if !tree.Remove(geom.NewBounds(geom.XY).Set(3, 4, 3, 4), 43) {
+		t.Errorf("Remove(43) == false, want true")
+	}
+	if tree.Remove(geom.NewBounds(geom.XY).Set(3, 4, 3, 4), 43) {
+		t.Errorf("second Remove(43) == true, want false")
+	}
+	if tree.Remove(geom.NewBounds(geom.XY).Set(5, 5, 5, 5), 43) {
+		t.Errorf("Remove(43) with other bounds == true, want false")
+	}
+	if got := tree.Len(); got != 99 {
+		t.Errorf("Len() == %d, want 99", got)
+	}
+	for _, item := range tree.Query(all) {
+		if item == 43 {
+			t.Errorf("Query returned removed item 43")
+		}
+	}
+	for i := 0; i < 100; i++ {
+		tree.Remove(geom.NewBounds(geom.XY).Set(float64(i%10), float64(i/10), float64(i%10), float64(i/10)), i)
+	}
+	if got := tree.Query(all); got != nil {
+		t.Errorf("Query after removing all items == %v, want nil", got)
+	}
+	if got := tree.Nearest(all, 1, nil); got != nil {
+		t.Errorf("Nearest after removing all items == %v, want nil", got)
+	}
+}
+
+func TestRemoveFunc(t *testing.T) {
+	// 切片是不可比较的类型，不能使用 Remove
+	for _, build := range []bool{false, true} {
+		tree := strtree.NewTree(4)
+		for i := 0; i < 20; i++ {
+			b := geom.NewBounds(geom.XY).Set(float64(i), 0, float64(i), 0)
+			if err := tree.Insert(b, []int{i}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if build {
+			tree.Build()
+		}
+		b := geom.NewBounds(geom.XY).Set(7, 0, 7, 0)
+		match := func(item interface{}) bool { return item.([]int)[0] == 7 }
+		if !tree.RemoveFunc(b, match) {
+			t.Errorf("build %v: RemoveFunc(7) == false, want true", build)
+		}
+		if tree.RemoveFunc(b, match) {
+			t.Errorf("build %v: second RemoveFunc(7) == true, want false", build)
+		}
+		if got := tree.Len(); got != 19 {
+			t.Errorf("build %v: Len() == %d, want 19", build, got)
+		}
+	}
+}
+
+func TestConcurrentReaders(t *testing.T) {
+	tree := grid(t, 30, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b := geom.NewBounds(geom.XY).Set(float64(i), float64(i), float64(i+1), float64(i+1))
+			if got := len(tree.Query(b)); got != 4 {
+				t.Errorf("len(Query(%v)) == %d, want 4", b, got)
+			}
+			if got := tree.Nearest(b, 1, nil); len(got) != 1 {
+				t.Errorf("Nearest(%v, 1, nil) == %v, want 1 item", b, got)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,109 @@
+package geom
+
+// SetLayout函数 返回一个视图为 layout 的新几何图形，g 本身不会被修改。
+// 两个视图都有的维度保持不变，layout 中没有的维度被删除，g 中没有的维度依次使用 fill 中的值填充，
+// fill 中没有对应的值时使用0。例如将 XY 转换为 XYZM 时 fill 的第一个值用于 z，第二个值用于 m。
+// 空的几何图形转换后仍然是空的。支持所有的几何图形类型，GeometryCollection 中的每个几何图形都被转换。
+// layout 为 NoLayout 时返回 ErrUnsupportedLayout
+func SetLayout(g T, layout Layout, fill ...float64) (T, error) {
+	if layout == NoLayout {
+		return nil, ErrUnsupportedLayout(layout)
+	}
+	switch g := g.(type) {
+	case *Point:
+		if len(g.flatCoords) == 0 {
+			return NewPointFlat(layout, nil).SetSRID(g.srid), nil
+		}
+		return NewPointFlat(layout, convertLayout(g.flatCoords, g.layout, layout, fill)).SetSRID(g.srid), nil
+	case *LineString:
+		return NewLineStringFlat(layout, convertLayout(g.flatCoords, g.layout, layout, fill)).SetSRID(g.srid), nil
+	case *LinearRing:
+		return NewLinearRingFlat(layout, convertLayout(g.flatCoords, g.layout, layout, fill)).SetSRID(g.srid), nil
+	case *Polygon:
+		return NewPolygonFlat(layout, convertLayout(g.flatCoords, g.layout, layout, fill), convertEnds(g.ends, g.stride, layout.Stride())).SetSRID(g.srid), nil
+	case *MultiPoint:
+		return NewMultiPointFlat(layout, convertLayout(g.flatCoords, g.layout, layout, fill)).SetSRID(g.srid), nil
+	case *MultiLineString:
+		return NewMultiLineStringFlat(layout, convertLayout(g.flatCoords, g.layout, layout, fill), convertEnds(g.ends, g.stride, layout.Stride())).SetSRID(g.srid), nil
+	case *MultiPolygon:
+		endss := make([][]int, len(g.endss))
+		for i, ends := range g.endss {
+			endss[i] = convertEnds(ends, g.stride, layout.Stride())
+		}
+		return NewMultiPolygonFlat(layout, convertLayout(g.flatCoords, g.layout, layout, fill), endss).SetSRID(g.srid), nil
+	case *GeometryCollection:
+		gc := NewGeometryCollection().SetSRID(g.srid)
+		for _, child := range g.geoms {
+			c, err := SetLayout(child, layout, fill...)
+			if err != nil {
+				return nil, err
+			}
+			gc.geoms = append(gc.geoms, c)
+		}
+		return gc, nil
+	default:
+		return nil, ErrUnsupportedType{Value: g}
+	}
+}
+
+// layoutMapping 函数返回新视图的每个维度在原视图中的索引，原视图中不存在的维度为-1
+func layoutMapping(from, to Layout) []int {
+	mapping := make([]int, to.Stride())
+	for i := range mapping {
+		switch {
+		case i < 2:
+			mapping[i] = i
+		case i == to.ZIndex():
+			mapping[i] = from.ZIndex()
+		case i == to.MIndex():
+			mapping[i] = from.MIndex()
+		case i < from.Stride():
+			mapping[i] = i
+		default:
+			mapping[i] = -1
+		}
+	}
+	return mapping
+}
+
+// convertLayout 函数将视图为 from 的坐标转换为视图为 to 的坐标，原视图中不存在的维度依次使用 fill 中的值填充
+func convertLayout(flatCoords []float64, from, to Layout, fill []float64) []float64 {
+	mapping := layoutMapping(from, to)
+	values := make([]float64, len(mapping))
+	k := 0
+	for i, j := range mapping {
+		if j < 0 && k < len(fill) {
+			values[i] = fill[k]
+			k++
+		}
+	}
+	stride := from.Stride()
+	if stride == 0 {
+		return nil
+	}
+	result := make([]float64, 0, len(flatCoords)/stride*len(mapping))
+	for i := 0; i < len(flatCoords); i += stride {
+		for k, j := range mapping {
+			if j < 0 {
+				result = append(result, values[k])
+			} else {
+				result = append(result, flatCoords[i+j])
+			}
+		}
+	}
+	return result
+}
+
+// convertEnds 函数将维数为 from 的坐标的结束位置转换为维数为 to 的坐标的结束位置
+func convertEnds(ends []int, from, to int) []int {
+	if ends == nil {
+		return nil
+	}
+	result := make([]int, len(ends))
+	for i, end := range ends {
+		if from != 0 {
+			result[i] = end / from * to
+		}
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+package geom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetLayout(t *testing.T) {
+	for i, tc := range []struct {
+		g      T
+		layout Layout
+		fill   []float64
+		want   T
+	}{
+		{
+			g:      NewPointFlat(XYZM, []float64{1, 2, 3, 4}).SetSRID(4326),
+			layout: XY,
+			want:   NewPointFlat(XY, []float64{1, 2}).SetSRID(4326),
+		},
+		{
+			g:      NewPointFlat(XY, []float64{1, 2}),
+			layout: XYZM,
+			fill:   []float64{5, 6},
+			want:   NewPointFlat(XYZM, []float64{1, 2, 5, 6}),
+		},
+		{
+			g:      NewPointFlat(XY, []float64{1, 2}),
+			layout: XYM,
+			fill:   []float64{7},
+			want:   NewPointFlat(XYM, []float64{1, 2, 7}),
+		},
+		{
+			g:      NewPointFlat(XYZ, []float64{1, 2, 3}),
+			layout: XYZM,
+			want:   NewPointFlat(XYZM, []float64{1, 2, 3, 0}),
+		},
+		{
+			g:      NewPointFlat(XYM, []float64{1, 2, 4}),
+			layout: XYZM,
+			fill:   []float64{3},
+			want:   NewPointFlat(XYZM, []float64{1, 2, 3, 4}),
+		},
+		{
+			g:      NewPointFlat(XYZ, []float64{1, 2, 3}),
+			layout: XYM,
+			want:   NewPointFlat(XYM, []float64{1, 2, 0}),
+		},
+		{
+			// 空的点仍然是空的，不会被填充为原点
+			g:      NewPointFlat(XY, nil).SetSRID(4326),
+			layout: XYZ,
+			fill:   []float64{1},
+			want:   NewPointFlat(XYZ, nil).SetSRID(4326),
+		},
+		{
+			g:      NewLineStringFlat(XYZ, []float64{1, 2, 3, 4, 5, 6}),
+			layout: XY,
+			want:   NewLineStringFlat(XY, []float64{1, 2, 4, 5}),
+		},
+		{
+			g:      NewLinearRingFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}),
+			layout: XYZ,
+			fill:   []float64{9},
+			want:   NewLinearRingFlat(XYZ, []float64{0, 0, 9, 1, 0, 9, 1, 1, 9, 0, 0, 9}),
+		},
+		{
+			g:      NewPolygonFlat(XYM, []float64{0, 0, 1, 1, 0, 2, 1, 1, 3, 0, 0, 1}, []int{12}),
+			layout: XY,
+			want:   NewPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}),
+		},
+		{
+			g:      NewMultiPointFlat(XY, []float64{1, 2, 3, 4}),
+			layout: XYZ,
+			want:   NewMultiPointFlat(XYZ, []float64{1, 2, 0, 3, 4, 0}),
+		},
+		{
+			g:      NewMultiLineStringFlat(XYZM, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, []int{8, 8, 12}),
+			layout: XYZ,
+			want:   NewMultiLineStringFlat(XYZ, []float64{1, 2, 3, 5, 6, 7, 9, 10, 11}, []int{6, 6, 9}),
+		},
+		{
+			g:      NewMultiPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}, {}}),
+			layout: XYM,
+			want:   NewMultiPolygonFlat(XYM, []float64{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 0}, [][]int{{12}, {}}),
+		},
+		{
+			g:      NewMultiLineString(XYZ),
+			layout: XY,
+			want:   NewMultiLineStringFlat(XY, []float64{}, nil),
+		},
+		{
+			g: NewGeometryCollection().MustPush(
+				NewPointFlat(XY, []float64{1, 2}),
+				NewLineStringFlat(XYZ, []float64{1, 2, 3, 4, 5, 6}),
+			).SetSRID(4326),
+			layout: XYZ,
+			fill:   []float64{-1},
+			want: NewGeometryCollection().MustPush(
+				NewPointFlat(XYZ, []float64{1, 2, -1}),
+				NewLineStringFlat(XYZ, []float64{1, 2, 3, 4, 5, 6}),
+			).SetSRID(4326),
+		},
+		{
+			g: NewGeometryCollection().MustPush(
+				NewPointFlat(XYZ, nil),
+				NewPointFlat(XYZ, []float64{1, 2, 3}),
+			),
+			layout: XY,
+			want: NewGeometryCollection().MustPush(
+				NewPointFlat(XY, nil),
+				NewPointFlat(XY, []float64{1, 2}),
+			),
+		},
+	} {
+		got, err := SetLayout(tc.g, tc.layout, tc.fill...)
+		if err != nil {
+			t.Errorf("%d: SetLayout(%v, %v, %v) == _, %v, want _, <nil>", i, tc.g, tc.layout, tc.fill, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%d: SetLayout(%v, %v, %v) == %#v, want %#v", i, tc.g, tc.layout, tc.fill, got, tc.want)
+		}
+	}
+}
+
+func TestSetLayoutErrors(t *testing.T) {
+	if _, err := SetLayout(NewPoint(XY), NoLayout); err != ErrUnsupportedLayout(NoLayout) {
+		t.Errorf("SetLayout(_, NoLayout) == _, %v, want _, %v", err, ErrUnsupportedLayout(NoLayout))
+	}
+}
+
+func TestSetLayoutPush(t *testing.T) {
+	mls := NewMultiLineString(XY)
+	ls := NewLineStringFlat(XYZ, []float64{1, 2, 3, 4, 5, 6})
+	if err := mls.Push(ls); err == nil {
+		t.Fatal("mls.Push(ls) == <nil>, want ErrLayoutMismatch")
+	}
+	g, err := SetLayout(ls, XY)
+	if err != nil {
+		t.Fatalf("SetLayout(ls, XY) == _, %v", err)
+	}
+	if err := mls.Push(g.(*LineString)); err != nil {
+		t.Errorf("mls.Push(g) == %v, want <nil>", err)
+	}
+	if ls.Layout() != XYZ || len(ls.FlatCoords()) != 6 {
+		t.Errorf("SetLayout modified its argument: %v", ls)
+	}
+}
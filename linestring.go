@@ -82,6 +82,26 @@ func (ls *LineString) MustSetCoords(coords []Coord) *LineString {
 	return ls
 }
 
+// PointAtDistance方法 返回线上沿线距离起点 distance 的点，所有的维度（包括 z、m）都按照线性插值计算。
+// distance 被限制在0与线的长度之间，长度只在 x、y 平面上计算。Linestring 为空时返回 nil
+func (ls *LineString) PointAtDistance(distance float64) Coord {
+	return pointAtDistance1(ls.flatCoords, 0, len(ls.flatCoords), ls.stride, distance)
+}
+
+// PointAtFraction方法 返回线上位于长度的 fraction 比例处的点，fraction 为0时返回起点，为1时返回终点
+func (ls *LineString) PointAtFraction(fraction float64) Coord {
+	return ls.PointAtDistance(fraction * ls.Length())
+}
+
+// Project方法 返回线上距离 c 最近的点到起点的沿线距离，即 c 在线上的投影位置。Linestring 为空时返回0
+func (ls *LineString) Project(c Coord) float64 {
+	if len(ls.flatCoords) == 0 {
+		return 0
+	}
+	distance, _ := project1(ls.flatCoords, 0, len(ls.flatCoords), ls.stride, c)
+	return distance
+}
+
 // SetCoords方法 为Linestring 设置控制点
 func (ls *LineString) SetCoords(coords []Coord) (*LineString, error) {
 	if err := ls.setCoords(coords); err != nil {
@@ -101,6 +121,25 @@ func (ls *LineString) SubLineString(start, stop int) *LineString {
 	return NewLineStringFlat(ls.layout, ls.flatCoords[start*ls.stride:stop*ls.stride])
 }
 
+// Substring方法 返回线上从长度的 start 比例处到 end 比例处的部分，start 和 end 被限制在0与1之间。
+// start 大于 end 时返回的 Linestring 方向相反，两者相等时返回由两个相同的点组成的 Linestring
+func (ls *LineString) Substring(start, end float64) *LineString {
+	if len(ls.flatCoords) == 0 {
+		return NewLineString(ls.layout)
+	}
+	length := ls.Length()
+	startDistance, endDistance := clampDistance(start*length, length), clampDistance(end*length, length)
+	reversed := startDistance > endDistance
+	if reversed {
+		startDistance, endDistance = endDistance, startDistance
+	}
+	flatCoords := substring1(nil, ls.flatCoords, 0, len(ls.flatCoords), ls.stride, startDistance, endDistance)
+	if reversed {
+		reverseCoords(flatCoords, ls.stride)
+	}
+	return NewLineStringFlat(ls.layout, flatCoords)
+}
+
 // Swap方法 与参数传入的 Linestring 互换
 func (ls *LineString) Swap(ls2 *LineString) {
 	*ls, *ls2 = *ls2, *ls
@@ -177,3 +177,61 @@ func TestLineStringStrideMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestLineStringLinearReferencing(t *testing.T) {
+	ls := NewLineString(XYZM).MustSetCoords([]Coord{{0, 0, 0, 0}, {10, 0, 10, 1}, {10, 0, 20, 2}, {10, 10, 30, 3}})
+	for _, c := range []struct {
+		fraction float64
+		want     Coord
+	}{
+		{fraction: -1, want: Coord{0, 0, 0, 0}},
+		{fraction: 0, want: Coord{0, 0, 0, 0}},
+		{fraction: 0.25, want: Coord{5, 0, 5, 0.5}},
+		{fraction: 0.5, want: Coord{10, 0, 10, 1}},
+		{fraction: 0.75, want: Coord{10, 5, 25, 2.5}},
+		{fraction: 1, want: Coord{10, 10, 30, 3}},
+		{fraction: 2, want: Coord{10, 10, 30, 3}},
+	} {
+		if got := ls.PointAtFraction(c.fraction); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ls.PointAtFraction(%v) == %v, want %v", c.fraction, got, c.want)
+		}
+		if got := ls.PointAtDistance(c.fraction * 20); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ls.PointAtDistance(%v) == %v, want %v", c.fraction*20, got, c.want)
+		}
+	}
+	for _, c := range []struct {
+		c    Coord
+		want float64
+	}{
+		{c: Coord{-5, -5}, want: 0},
+		{c: Coord{4, 3}, want: 4},
+		{c: Coord{12, 6}, want: 16},
+		{c: Coord{20, 20}, want: 20},
+	} {
+		if got := ls.Project(c.c); got != c.want {
+			t.Errorf("ls.Project(%v) == %v, want %v", c.c, got, c.want)
+		}
+	}
+	for _, c := range []struct {
+		start, end float64
+		want       []Coord
+	}{
+		{start: 0, end: 1, want: ls.Coords()},
+		{start: 0.25, end: 0.75, want: []Coord{{5, 0, 5, 0.5}, {10, 0, 10, 1}, {10, 0, 20, 2}, {10, 5, 25, 2.5}}},
+		{start: 0.5, end: 1, want: []Coord{{10, 0, 10, 1}, {10, 0, 20, 2}, {10, 10, 30, 3}}},
+		{start: 0.75, end: 0.25, want: []Coord{{10, 5, 25, 2.5}, {10, 0, 20, 2}, {10, 0, 10, 1}, {5, 0, 5, 0.5}}},
+		{start: 0.25, end: 0.25, want: []Coord{{5, 0, 5, 0.5}, {5, 0, 5, 0.5}}},
+		{start: -1, end: 0.1, want: []Coord{{0, 0, 0, 0}, {2, 0, 2, 0.2}}},
+	} {
+		if got := ls.Substring(c.start, c.end).Coords(); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ls.Substring(%v, %v) == %v, want %v", c.start, c.end, got, c.want)
+		}
+	}
+	empty := NewLineString(XY)
+	if got := empty.PointAtFraction(0.5); got != nil {
+		t.Errorf("empty.PointAtFraction(0.5) == %v, want nil", got)
+	}
+	if got := empty.Substring(0, 1); len(got.FlatCoords()) != 0 {
+		t.Errorf("empty.Substring(0, 1) == %v, want empty", got.Coords())
+	}
+}
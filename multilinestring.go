@@ -1,5 +1,7 @@
 package geom
 
+import "math"
+
 //MultiLineString 是 LineStrings的集合.
 type MultiLineString struct {
 	geom2
@@ -76,6 +78,48 @@ func (mls *MultiLineString) Push(ls *LineString) error {
 	return nil
 }
 
+// PointAtDistance方法 返回沿集合中的 Linestring 依次计算距离 distance 处的点，所有的维度（包括 z、m）都按照线性插值计算。
+// distance 被限制在0与总长度之间。集合中没有坐标时返回 nil
+func (mls *MultiLineString) PointAtDistance(distance float64) Coord {
+	var last Coord
+	offset := 0
+	for _, end := range mls.ends {
+		if end > offset {
+			length := length1(mls.flatCoords, offset, end, mls.stride)
+			if distance <= length {
+				return pointAtDistance1(mls.flatCoords, offset, end, mls.stride, distance)
+			}
+			distance -= length
+			last = Coord(mls.flatCoords[end-mls.stride : end])
+		}
+		offset = end
+	}
+	return last.Clone()
+}
+
+// PointAtFraction方法 返回位于集合总长度的 fraction 比例处的点
+func (mls *MultiLineString) PointAtFraction(fraction float64) Coord {
+	return mls.PointAtDistance(fraction * mls.Length())
+}
+
+// Project方法 返回集合中距离 c 最近的点的沿线距离，之前的 Linestring 的长度都计算在内。集合中没有坐标时返回0
+func (mls *MultiLineString) Project(c Coord) float64 {
+	var sum, result float64
+	bestDistance2 := math.Inf(1)
+	offset := 0
+	for _, end := range mls.ends {
+		if end > offset {
+			distance, distance2 := project1(mls.flatCoords, offset, end, mls.stride, c)
+			if distance2 < bestDistance2 {
+				result, bestDistance2 = sum+distance, distance2
+			}
+			sum += length1(mls.flatCoords, offset, end, mls.stride)
+		}
+		offset = end
+	}
+	return result
+}
+
 // SetCoords方法 设置坐标
 func (mls *MultiLineString) SetCoords(coords [][]Coord) (*MultiLineString, error) {
 	if err := mls.setCoords(coords); err != nil {
@@ -90,6 +134,46 @@ func (mls *MultiLineString) SetSRID(srid int) *MultiLineString {
 	return mls
 }
 
+// Substring方法 返回集合中从总长度的 start 比例处到 end 比例处的部分，每个与该部分相交的 Linestring 都对应结果中的一个 Linestring。
+// start 和 end 被限制在0与1之间，start 大于 end 时结果中 Linestring 的顺序和方向都相反
+func (mls *MultiLineString) Substring(start, end float64) *MultiLineString {
+	length := mls.Length()
+	startDistance, endDistance := clampDistance(start*length, length), clampDistance(end*length, length)
+	reversed := startDistance > endDistance
+	if reversed {
+		startDistance, endDistance = endDistance, startDistance
+	}
+	var flatCoords []float64
+	var ends []int
+	var sum float64
+	offset := 0
+	for _, partEnd := range mls.ends {
+		if partEnd == offset {
+			continue
+		}
+		lower := sum
+		sum += length1(mls.flatCoords, offset, partEnd, mls.stride)
+		if sum >= startDistance && lower <= endDistance &&
+			(startDistance == endDistance || sum > startDistance && lower < endDistance) {
+			flatCoords = substring1(flatCoords, mls.flatCoords, offset, partEnd, mls.stride, startDistance-lower, endDistance-lower)
+			ends = append(ends, len(flatCoords))
+			if startDistance == endDistance {
+				break
+			}
+		}
+		offset = partEnd
+	}
+	if reversed && len(ends) > 0 {
+		reverseCoords(flatCoords, mls.stride)
+		reversedEnds := make([]int, 0, len(ends))
+		for i := len(ends) - 2; i >= 0; i-- {
+			reversedEnds = append(reversedEnds, len(flatCoords)-ends[i])
+		}
+		ends = append(reversedEnds, len(flatCoords))
+	}
+	return NewMultiLineStringFlat(mls.layout, flatCoords, ends)
+}
+
 // Swap方法 将本集合与传入的集合互相交换
 func (mls *MultiLineString) Swap(mls2 *MultiLineString) {
 	*mls, *mls2 = *mls2, *mls
@@ -118,3 +118,47 @@ func TestMultiLineStringStrideMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiLineStringLinearReferencing(t *testing.T) {
+	mls := NewMultiLineString(XYM).MustSetCoords([][]Coord{{{0, 0, 0}, {10, 0, 10}}, {}, {{20, 0, 10}, {20, 10, 20}}})
+	for _, c := range []struct {
+		fraction float64
+		want     Coord
+	}{
+		{fraction: -1, want: Coord{0, 0, 0}},
+		{fraction: 0.25, want: Coord{5, 0, 5}},
+		{fraction: 0.5, want: Coord{10, 0, 10}},
+		{fraction: 0.75, want: Coord{20, 5, 15}},
+		{fraction: 2, want: Coord{20, 10, 20}},
+	} {
+		if got := mls.PointAtFraction(c.fraction); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("mls.PointAtFraction(%v) == %v, want %v", c.fraction, got, c.want)
+		}
+	}
+	for _, c := range []struct {
+		c    Coord
+		want float64
+	}{
+		{c: Coord{3, 1}, want: 3},
+		{c: Coord{22, 4}, want: 14},
+		{c: Coord{16, 0}, want: 10},
+	} {
+		if got := mls.Project(c.c); got != c.want {
+			t.Errorf("mls.Project(%v) == %v, want %v", c.c, got, c.want)
+		}
+	}
+	for _, c := range []struct {
+		start, end float64
+		want       [][]Coord
+	}{
+		{start: 0, end: 1, want: [][]Coord{{{0, 0, 0}, {10, 0, 10}}, {{20, 0, 10}, {20, 10, 20}}}},
+		{start: 0.25, end: 0.5, want: [][]Coord{{{5, 0, 5}, {10, 0, 10}}}},
+		{start: 0.25, end: 0.75, want: [][]Coord{{{5, 0, 5}, {10, 0, 10}}, {{20, 0, 10}, {20, 5, 15}}}},
+		{start: 0.75, end: 0.25, want: [][]Coord{{{20, 5, 15}, {20, 0, 10}}, {{10, 0, 10}, {5, 0, 5}}}},
+		{start: 0.5, end: 0.5, want: [][]Coord{{{10, 0, 10}, {10, 0, 10}}}},
+	} {
+		if got := mls.Substring(c.start, c.end).Coords(); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("mls.Substring(%v, %v) == %v, want %v", c.start, c.end, got, c.want)
+		}
+	}
+}
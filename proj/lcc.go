@@ -0,0 +1,85 @@
+package proj
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo/geodesic"
+)
+
+// LambertConformalConic 是椭球面上的兰伯特等角圆锥投影（两条标准纬线）。
+// See Snyder, "Map Projections: A Working Manual", p. 107
+type LambertConformalConic struct {
+	lonOrigin     float64
+	falseEasting  float64
+	falseNorthing float64
+	a, e          float64
+	n, f, rho0    float64
+}
+
+// NewLambertConformalConic函数 创建兰伯特等角圆锥投影，参数依次为椭球、中央经线、原点纬度、两条标准纬线、东偏移和北偏移。
+// 两条标准纬线相同时为单标准纬线的投影
+func NewLambertConformalConic(ellipsoid geodesic.Ellipsoid, lonOrigin, latOrigin, lat1, lat2, falseEasting, falseNorthing float64) *LambertConformalConic {
+	p := &LambertConformalConic{
+		lonOrigin:     lonOrigin,
+		falseEasting:  falseEasting,
+		falseNorthing: falseNorthing,
+		a:             ellipsoid.A,
+		e:             math.Sqrt(ellipsoid.F * (2 - ellipsoid.F)),
+	}
+	m1, t1 := p.m(lat1), p.t(lat1)
+	if lat1 == lat2 {
+		p.n = math.Sin(toRadians(lat1))
+	} else {
+		p.n = (math.Log(m1) - math.Log(p.m(lat2))) / (math.Log(t1) - math.Log(p.t(lat2)))
+	}
+	p.f = m1 / (p.n * math.Pow(t1, p.n))
+	p.rho0 = p.rho(latOrigin)
+	return p
+}
+
+// Forward方法 将经纬度转换为投影坐标
+func (p *LambertConformalConic) Forward(lon, lat float64) (float64, float64) {
+	rho := p.rho(lat)
+	sinTheta, cosTheta := math.Sincos(p.n * toRadians(math.Remainder(lon-p.lonOrigin, 360)))
+	return p.falseEasting + rho*sinTheta, p.falseNorthing + p.rho0 - rho*cosTheta
+}
+
+// Inverse方法 将投影坐标转换为经纬度
+func (p *LambertConformalConic) Inverse(x, y float64) (float64, float64) {
+	dx, dy := x-p.falseEasting, p.rho0-(y-p.falseNorthing)
+	if p.n < 0 {
+		dx, dy = -dx, -dy
+	}
+	rho := math.Copysign(math.Hypot(dx, dy), p.n)
+	lon := p.lonOrigin + toDegrees(math.Atan2(dx, dy)/p.n)
+	if rho == 0 {
+		return lon, math.Copysign(90, p.n)
+	}
+	t := math.Pow(rho/(p.a*p.f), 1/p.n)
+	phi := math.Pi/2 - 2*math.Atan(t)
+	for i := 0; i < 20; i++ {
+		esinPhi := p.e * math.Sin(phi)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-esinPhi)/(1+esinPhi), p.e/2))
+		if math.Abs(next-phi) < 1e-14 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+	return lon, toDegrees(phi)
+}
+
+func (p *LambertConformalConic) rho(lat float64) float64 {
+	return p.a * p.f * math.Pow(p.t(lat), p.n)
+}
+
+func (p *LambertConformalConic) m(lat float64) float64 {
+	sinPhi, cosPhi := math.Sincos(toRadians(lat))
+	return cosPhi / math.Sqrt(1-p.e*p.e*sinPhi*sinPhi)
+}
+
+func (p *LambertConformalConic) t(lat float64) float64 {
+	phi := toRadians(lat)
+	esinPhi := p.e * math.Sin(phi)
+	return math.Tan(math.Pi/4-phi/2) / math.Pow((1-esinPhi)/(1+esinPhi), p.e/2)
+}
@@ -0,0 +1,48 @@
+package proj
+
+import (
+	"math"
+)
+
+// earthRadius 是 Web Mercator 投影使用的球体半径，等于 WGS84 椭球的长半轴
+const earthRadius = 6378137
+
+// maxWebMercatorLatitude 是 Web Mercator 投影的最大纬度，此时投影范围为正方形
+const maxWebMercatorLatitude = 85.05112877980659
+
+// 内置的投影
+var (
+	// Geographic 是经纬度坐标，Forward 和 Inverse 不做任何转换
+	Geographic Projection = geographic{}
+	// WebMercator 是 EPSG:3857 使用的球体墨卡托投影，纬度被限制在 ±85.0511° 之内
+	WebMercator Projection = webMercator{}
+)
+
+type geographic struct{}
+
+func (geographic) Forward(lon, lat float64) (float64, float64) {
+	return lon, lat
+}
+
+func (geographic) Inverse(x, y float64) (float64, float64) {
+	return x, y
+}
+
+type webMercator struct{}
+
+func (webMercator) Forward(lon, lat float64) (float64, float64) {
+	lat = math.Max(-maxWebMercatorLatitude, math.Min(maxWebMercatorLatitude, lat))
+	return earthRadius * toRadians(lon), earthRadius * math.Log(math.Tan(math.Pi/4+toRadians(lat)/2))
+}
+
+func (webMercator) Inverse(x, y float64) (float64, float64) {
+	return toDegrees(x / earthRadius), toDegrees(2*math.Atan(math.Exp(y/earthRadius)) - math.Pi/2)
+}
+
+func toRadians(x float64) float64 {
+	return x * math.Pi / 180
+}
+
+func toDegrees(x float64) float64 {
+	return x * 180 / math.Pi
+}
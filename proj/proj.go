@@ -0,0 +1,152 @@
+// Package proj 包含了坐标参考系统之间的坐标转换（投影与反投影）。
+//
+// 坐标参考系统通过 SRID（EPSG 代码）标识。内置支持 EPSG:4326（WGS84 经纬度）、EPSG:3857（Web Mercator）
+// 以及 WGS84 的 UTM 投影带（EPSG:32601-32660 和 EPSG:32701-32760），其他的横轴墨卡托投影和兰伯特等角圆锥投影
+// 可以通过 Register 注册。所有的投影都以经纬度为中间坐标，不进行基准面之间的转换。
+//
+// 转换只修改坐标的 x、y 值，z、m 等其他值将保持不变
+package proj
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/transform"
+)
+
+// Projection 是经纬度与投影坐标之间的转换，经纬度的单位为度
+type Projection interface {
+	// Forward 将经纬度转换为投影坐标
+	Forward(lon, lat float64) (x, y float64)
+	// Inverse 将投影坐标转换为经纬度
+	Inverse(x, y float64) (lon, lat float64)
+}
+
+// 常用的 SRID
+const (
+	EPSG4326 = 4326
+	EPSG3857 = 3857
+)
+
+// ErrUnknownSRID 将会被返回，当 SRID 没有对应的投影时
+type ErrUnknownSRID struct {
+	SRID int
+}
+
+func (e ErrUnknownSRID) Error() string {
+	return fmt.Sprintf("proj: unknown SRID %d", e.SRID)
+}
+
+var (
+	registryMutex sync.RWMutex
+	registry      = map[int]Projection{
+		EPSG4326: Geographic,
+		EPSG3857: WebMercator,
+	}
+)
+
+// Register函数 注册 SRID 对应的投影，已有的投影（包括内置的投影）将被替换
+func Register(srid int, p Projection) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry[srid] = p
+}
+
+// Lookup函数 返回 SRID 对应的投影
+func Lookup(srid int) (Projection, error) {
+	registryMutex.RLock()
+	p, ok := registry[srid]
+	registryMutex.RUnlock()
+	if ok {
+		return p, nil
+	}
+	switch {
+	case 32601 <= srid && srid <= 32660:
+		return UTM(srid-32600, true), nil
+	case 32701 <= srid && srid <= 32760:
+		return UTM(srid-32700, false), nil
+	default:
+		return nil, ErrUnknownSRID{SRID: srid}
+	}
+}
+
+// Transform函数 返回将几何图形从其 SRID 转换到 srid 的拷贝，拷贝的 SRID 为 srid
+func Transform(g geom.T, srid int) (geom.T, error) {
+	from, to, err := lookupPair(g.SRID(), srid)
+	if err != nil {
+		return nil, err
+	}
+	clone, err := transform.Apply(g, project(from, to))
+	if err != nil {
+		return nil, err
+	}
+	setSRID(clone, srid)
+	return clone, nil
+}
+
+// TransformInPlace函数 将几何图形从其 SRID 转换到 srid，直接修改几何图形的坐标和 SRID
+func TransformInPlace(g geom.T, srid int) error {
+	from, to, err := lookupPair(g.SRID(), srid)
+	if err != nil {
+		return err
+	}
+	if err := transform.ApplyInPlace(g, project(from, to)); err != nil {
+		return err
+	}
+	setSRID(g, srid)
+	return nil
+}
+
+// TransformFlat函数 将平面坐标数组从投影 from 转换到投影 to，直接修改 flatCoords
+func TransformFlat(from, to Projection, flatCoords []float64, stride int) {
+	for i := 0; i+1 < len(flatCoords); i += stride {
+		lon, lat := from.Inverse(flatCoords[i], flatCoords[i+1])
+		flatCoords[i], flatCoords[i+1] = to.Forward(lon, lat)
+	}
+}
+
+func lookupPair(fromSRID, toSRID int) (Projection, Projection, error) {
+	from, err := Lookup(fromSRID)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := Lookup(toSRID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// project 函数返回将一个坐标从投影 from 转换到投影 to 的函数
+func project(from, to Projection) func(c geom.Coord) {
+	return func(c geom.Coord) {
+		lon, lat := from.Inverse(c[0], c[1])
+		c[0], c[1] = to.Forward(lon, lat)
+	}
+}
+
+// setSRID 函数设置几何图形的 SRID，GeometryCollection 中的几何图形也被设置
+func setSRID(g geom.T, srid int) {
+	switch g := g.(type) {
+	case *geom.Point:
+		g.SetSRID(srid)
+	case *geom.LineString:
+		g.SetSRID(srid)
+	case *geom.LinearRing:
+		g.SetSRID(srid)
+	case *geom.Polygon:
+		g.SetSRID(srid)
+	case *geom.MultiPoint:
+		g.SetSRID(srid)
+	case *geom.MultiLineString:
+		g.SetSRID(srid)
+	case *geom.MultiPolygon:
+		g.SetSRID(srid)
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			setSRID(child, srid)
+		}
+		g.SetSRID(srid)
+	}
+}
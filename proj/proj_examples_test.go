@@ -0,0 +1,18 @@
+package proj_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/proj"
+)
+
+func ExampleTransform() {
+	p := geom.NewPointFlat(geom.XYZ, []float64{116.3913, 39.9075, 44}).SetSRID(4326)
+	g, err := proj.Transform(p, 32650)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("SRID=%d;%.2f %.2f %.0f\n", g.SRID(), g.FlatCoords()[0], g.FlatCoords()[1], g.FlatCoords()[2])
+	// Output: SRID=32650;447971.33 4417668.02 44
+}
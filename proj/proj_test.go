@@ -0,0 +1,272 @@
+package proj_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/geodesic"
+	"github.com/chengxiaoer/geomGo/proj"
+)
+
+// dms 函数将度、分、秒转换为度
+func dms(d, m, s float64) float64 {
+	return math.Copysign(math.Abs(d)+m/60+s/3600, d)
+}
+
+func TestProjections(t *testing.T) {
+	airy := geodesic.NewEllipsoid(6377563.396, 299.3249646)
+	// 单位为美国测量英尺的 Clarke 1866 椭球
+	clarke := geodesic.NewEllipsoid(20925832.16, 294.97870)
+	for i, tc := range []struct {
+		desc     string
+		p        proj.Projection
+		lon, lat float64
+		x, y     float64
+		epsilon  float64
+	}{
+		{
+			desc: "Web Mercator",
+			p:    proj.WebMercator,
+			lon:  dms(-100, 20, 0), lat: dms(24, 22, 54.433),
+			x: -11169055.58, y: 2800000.00,
+			epsilon: 0.01,
+		},
+		{
+			desc: "Web Mercator origin",
+			p:    proj.WebMercator,
+			x:    0, y: 0,
+			epsilon: 1e-9,
+		},
+		{
+			// EPSG Guidance Note 7-2 中的测试数据，OSGB 1936 / British National Grid
+			desc: "British National Grid",
+			p:    proj.NewTransverseMercator(airy, -2, 49, 0.9996012717, 400000, -100000),
+			lon:  dms(0, 30, 0), lat: dms(50, 30, 0),
+			x: 577274.99, y: 69740.50,
+			epsilon: 0.01,
+		},
+		{
+			desc: "UTM central meridian",
+			p:    proj.UTM(31, true),
+			lon:  3, lat: 0,
+			x: 500000, y: 0,
+			epsilon: 1e-6,
+		},
+		{
+			desc: "UTM south",
+			p:    proj.UTM(31, false),
+			lon:  3, lat: 0,
+			x: 500000, y: 10000000,
+			epsilon: 1e-6,
+		},
+		{
+			// EPSG Guidance Note 7-2 中的测试数据，NAD27 / Texas South Central
+			desc: "Lambert Conformal Conic",
+			p:    proj.NewLambertConformalConic(clarke, -99, dms(27, 50, 0), dms(28, 23, 0), dms(30, 17, 0), 2000000, 0),
+			lon:  -96, lat: 28.5,
+			x: 2963503.91, y: 254759.80,
+			epsilon: 0.01,
+		},
+	} {
+		x, y := tc.p.Forward(tc.lon, tc.lat)
+		if math.Abs(x-tc.x) > tc.epsilon || math.Abs(y-tc.y) > tc.epsilon {
+			t.Errorf("%d: %s: Forward(%v, %v) == %v, %v, want %v, %v", i, tc.desc, tc.lon, tc.lat, x, y, tc.x, tc.y)
+		}
+		// 测试数据的投影坐标只精确到 0.01，反算的经纬度允许 1e-6 度的误差
+		lon, lat := tc.p.Inverse(tc.x, tc.y)
+		if math.Abs(lon-tc.lon) > 1e-6 || math.Abs(lat-tc.lat) > 1e-6 {
+			t.Errorf("%d: %s: Inverse(%v, %v) == %v, %v, want %v, %v", i, tc.desc, tc.x, tc.y, lon, lat, tc.lon, tc.lat)
+		}
+	}
+}
+
+func TestUTMRoundTrip(t *testing.T) {
+	for zone := 1; zone <= 60; zone += 7 {
+		for _, north := range []bool{true, false} {
+			p := proj.UTM(zone, north)
+			for _, dlon := range []float64{-3, -1.5, 0, 2, 3} {
+				for _, lat := range []float64{0, 15, 45, 80} {
+					if !north {
+						lat = -lat
+					}
+					lon := float64(6*zone-183) + dlon
+					x, y := p.Forward(lon, lat)
+					lon2, lat2 := p.Inverse(x, y)
+					if math.Abs(math.Remainder(lon2-lon, 360)) > 1e-9 || math.Abs(lat2-lat) > 1e-9 {
+						t.Errorf("UTM(%d, %v): Inverse(Forward(%v, %v)) == %v, %v", zone, north, lon, lat, lon2, lat2)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestUTMScale(t *testing.T) {
+	// 投影坐标之间的距离与测地线距离之比应接近比例因子
+	p := proj.UTM(50, true)
+	lon, lat := 117.0, 40.0
+	x1, y1 := p.Forward(lon, lat)
+	x2, y2 := p.Forward(lon, lat+0.001)
+	s := geodesic.Distance(geom.Coord{lon, lat}, geom.Coord{lon, lat + 0.001})
+	if k := math.Hypot(x2-x1, y2-y1) / s; math.Abs(k-0.9996) > 1e-6 {
+		t.Errorf("scale on central meridian == %v, want 0.9996", k)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, srid := range []int{4326, 3857, 32601, 32650, 32660, 32701, 32760} {
+		if _, err := proj.Lookup(srid); err != nil {
+			t.Errorf("Lookup(%d) returned error %v", srid, err)
+		}
+	}
+	for _, srid := range []int{0, 32600, 32661, 32700, 32761, 27700} {
+		if _, err := proj.Lookup(srid); err != (proj.ErrUnknownSRID{SRID: srid}) {
+			t.Errorf("Lookup(%d) returned %v, want %v", srid, err, proj.ErrUnknownSRID{SRID: srid})
+		}
+	}
+	bng := proj.NewTransverseMercator(geodesic.NewEllipsoid(6377563.396, 299.3249646), -2, 49, 0.9996012717, 400000, -100000)
+	proj.Register(27700, bng)
+	if p, err := proj.Lookup(27700); err != nil || p != proj.Projection(bng) {
+		t.Errorf("Lookup(27700) == %v, %v, want %v, <nil>", p, err, bng)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	for i, tc := range []struct {
+		wkt      string
+		from, to int
+		expected string
+	}{
+		{
+			wkt:  "POINT (0 0)",
+			from: 4326, to: 3857,
+			expected: "POINT (0 0)",
+		},
+		{
+			wkt:  "POINT ZM (180 0 10 20)",
+			from: 4326, to: 3857,
+			expected: "POINT ZM (20037508.343 0 10 20)",
+		},
+		{
+			wkt:  "LINESTRING M (3 0 1, 3 45 2)",
+			from: 4326, to: 32631,
+			expected: "LINESTRING M (500000 0 1, 500000 4982950.4 2)",
+		},
+		{
+			wkt:  "POLYGON ((500000 0, 500000 4982950.4, 600000 4982950.4, 500000 0))",
+			from: 32631, to: 4326,
+			expected: "POLYGON ((3 0, 3 45, 4.27 44.998, 3 0))",
+		},
+		{
+			wkt:  "GEOMETRYCOLLECTION (POINT (3 0), MULTIPOINT ((3 0), (3 -45)))",
+			from: 4326, to: 32731,
+			expected: "GEOMETRYCOLLECTION (POINT (500000 10000000), MULTIPOINT ((500000 10000000), (500000 5017049.6)))",
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.wkt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		setSRID(g, tc.from)
+		got, err := proj.Transform(g, tc.to)
+		if err != nil {
+			t.Errorf("%d: Transform(%s, %d) returned error %v", i, tc.wkt, tc.to, err)
+			continue
+		}
+		if got.SRID() != tc.to {
+			t.Errorf("%d: Transform(%s, %d).SRID() == %d", i, tc.wkt, tc.to, got.SRID())
+		}
+		expected, err := wkt.Unmarshal(tc.expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(got, expected, 0.01) {
+			s, _ := wkt.Marshal(got)
+			t.Errorf("%d: Transform(%s, %d) == %s, want %s", i, tc.wkt, tc.to, s, tc.expected)
+		}
+		if g.SRID() != tc.from {
+			t.Errorf("%d: Transform modified the SRID of the input", i)
+		}
+		if s, _ := wkt.Marshal(g); s != mustRemarshal(t, tc.wkt) {
+			t.Errorf("%d: Transform modified the input: %s", i, s)
+		}
+	}
+}
+
+func TestTransformInPlace(t *testing.T) {
+	ls := geom.NewLineStringFlat(geom.XYZ, []float64{116, 40, 50, 117, 41, 60}).SetSRID(4326)
+	if err := proj.TransformInPlace(ls, 3857); err != nil {
+		t.Fatal(err)
+	}
+	if ls.SRID() != 3857 || ls.FlatCoords()[2] != 50 || ls.FlatCoords()[5] != 60 {
+		t.Errorf("TransformInPlace did not update SRID or preserve Z: %v %v", ls.SRID(), ls.FlatCoords())
+	}
+	if err := proj.TransformInPlace(ls, 4326); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []float64{116, 40, 50, 117, 41, 60} {
+		if math.Abs(ls.FlatCoords()[i]-want) > 1e-9 {
+			t.Errorf("round trip coordinate %d == %v, want %v", i, ls.FlatCoords()[i], want)
+		}
+	}
+	if err := proj.TransformInPlace(geom.NewPoint(geom.XY).SetSRID(0), 4326); err != (proj.ErrUnknownSRID{}) {
+		t.Errorf("TransformInPlace with SRID 0 returned %v, want %v", err, proj.ErrUnknownSRID{})
+	}
+}
+
+func setSRID(g geom.T, srid int) {
+	switch g := g.(type) {
+	case *geom.Point:
+		g.SetSRID(srid)
+	case *geom.LineString:
+		g.SetSRID(srid)
+	case *geom.Polygon:
+		g.SetSRID(srid)
+	case *geom.GeometryCollection:
+		g.SetSRID(srid)
+		for _, child := range g.Geoms() {
+			setSRID(child, srid)
+		}
+	}
+}
+
+func mustRemarshal(t *testing.T, s string) string {
+	g, err := wkt.Unmarshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err = wkt.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// almostEqual 函数比较两个几何图形的坐标，x、y 允许 epsilon 的误差
+func almostEqual(g1, g2 geom.T, epsilon float64) bool {
+	gc1, ok1 := g1.(*geom.GeometryCollection)
+	gc2, ok2 := g2.(*geom.GeometryCollection)
+	if ok1 || ok2 {
+		if !ok1 || !ok2 || gc1.NumGeoms() != gc2.NumGeoms() {
+			return false
+		}
+		for i := range gc1.Geoms() {
+			if !almostEqual(gc1.Geom(i), gc2.Geom(i), epsilon) {
+				return false
+			}
+		}
+		return true
+	}
+	c1, c2 := g1.FlatCoords(), g2.FlatCoords()
+	if g1.Layout() != g2.Layout() || len(c1) != len(c2) {
+		return false
+	}
+	for i := range c1 {
+		if math.Abs(c1[i]-c2[i]) > epsilon {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package proj
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo/geodesic"
+)
+
+// TransverseMercator 是椭球面上的横轴墨卡托投影，使用 Krüger 级数展开至 n⁴，在中央经线两侧 4000km 之内精度优于1mm。
+// See Karney, "Transverse Mercator with an accuracy of a few nanometers"
+type TransverseMercator struct {
+	lonOrigin     float64
+	falseEasting  float64
+	falseNorthing float64
+	e             float64
+	// radius 为 k0*A，A 是子午线弧长的平均半径（rectifying radius）
+	radius float64
+	// northingOrigin 是赤道到原点纬度的子午线弧长除以 A
+	northingOrigin float64
+	alpha, beta    [4]float64
+}
+
+// NewTransverseMercator函数 创建横轴墨卡托投影，参数依次为椭球、中央经线、原点纬度、中央经线上的比例因子、东偏移和北偏移
+func NewTransverseMercator(ellipsoid geodesic.Ellipsoid, lonOrigin, latOrigin, scaleFactor, falseEasting, falseNorthing float64) *TransverseMercator {
+	f := ellipsoid.F
+	n := f / (2 - f)
+	n2 := n * n
+	n3 := n2 * n
+	n4 := n3 * n
+	p := &TransverseMercator{
+		lonOrigin:     lonOrigin,
+		falseEasting:  falseEasting,
+		falseNorthing: falseNorthing,
+		e:             math.Sqrt(f * (2 - f)),
+		radius:        scaleFactor * ellipsoid.A / (1 + n) * (1 + n2/4 + n4/64),
+		alpha: [4]float64{
+			n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180,
+			13*n2/48 - 3*n3/5 + 557*n4/1440,
+			61*n3/240 - 103*n4/140,
+			49561 * n4 / 161280,
+		},
+		beta: [4]float64{
+			n/2 - 2*n2/3 + 37*n3/96 - n4/360,
+			n2/48 + n3/15 - 437*n4/1440,
+			17*n3/480 - 37*n4/840,
+			4397 * n4 / 161280,
+		},
+	}
+	xi, _ := p.forward(0, latOrigin)
+	p.northingOrigin = xi
+	return p
+}
+
+// UTM函数 返回 WGS84 椭球上的 UTM 投影，zone 为投影带号（1-60），north 表示北半球
+func UTM(zone int, north bool) *TransverseMercator {
+	falseNorthing := 0.0
+	if !north {
+		falseNorthing = 10000000
+	}
+	return NewTransverseMercator(geodesic.WGS84, float64(6*zone-183), 0, 0.9996, 500000, falseNorthing)
+}
+
+// Forward方法 将经纬度转换为投影坐标
+func (p *TransverseMercator) Forward(lon, lat float64) (float64, float64) {
+	xi, eta := p.forward(lon-p.lonOrigin, lat)
+	return p.falseEasting + p.radius*eta, p.falseNorthing + p.radius*(xi-p.northingOrigin)
+}
+
+// Inverse方法 将投影坐标转换为经纬度
+func (p *TransverseMercator) Inverse(x, y float64) (float64, float64) {
+	xi := (y-p.falseNorthing)/p.radius + p.northingOrigin
+	eta := (x - p.falseEasting) / p.radius
+	xi1, eta1 := xi, eta
+	for j, b := range p.beta {
+		k := 2 * float64(j+1)
+		xi1 -= b * math.Sin(k*xi) * math.Cosh(k*eta)
+		eta1 -= b * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+	// 等角纬度的正切
+	taup := math.Sin(xi1) / math.Hypot(math.Sinh(eta1), math.Cos(xi1))
+	lat := toDegrees(math.Atan(p.tauf(taup)))
+	lon := p.lonOrigin + toDegrees(math.Atan2(math.Sinh(eta1), math.Cos(xi1)))
+	return lon, lat
+}
+
+// forward 方法返回经度差 lon 和纬度 lat 对应的 xi 和 eta，即除以 k0*A 之后的北坐标和东坐标
+func (p *TransverseMercator) forward(lon, lat float64) (float64, float64) {
+	sinLambda, cosLambda := math.Sincos(toRadians(math.Remainder(lon, 360)))
+	sinPhi := math.Sin(toRadians(lat))
+	t := math.Sinh(math.Atanh(sinPhi) - p.e*math.Atanh(p.e*sinPhi))
+	xi1 := math.Atan2(t, cosLambda)
+	eta1 := math.Atanh(sinLambda / math.Sqrt(1+t*t))
+	xi, eta := xi1, eta1
+	for j, a := range p.alpha {
+		k := 2 * float64(j+1)
+		xi += a * math.Sin(k*xi1) * math.Cosh(k*eta1)
+		eta += a * math.Cos(k*xi1) * math.Sinh(k*eta1)
+	}
+	return xi, eta
+}
+
+// tauf 方法使用牛顿迭代由等角纬度的正切求纬度的正切
+func (p *TransverseMercator) tauf(taup float64) float64 {
+	if math.IsInf(taup, 0) {
+		return taup
+	}
+	e2m := 1 - p.e*p.e
+	tau := taup / e2m
+	tol := 1e-15 * math.Max(1, math.Abs(taup))
+	for i := 0; i < 10; i++ {
+		taupa := p.taupf(tau)
+		dtau := (taup - taupa) * (1 + e2m*tau*tau) / (e2m * math.Hypot(1, tau) * math.Hypot(1, taupa))
+		tau += dtau
+		if math.Abs(dtau) < tol {
+			break
+		}
+	}
+	return tau
+}
+
+// taupf 方法由纬度的正切求等角纬度的正切
+func (p *TransverseMercator) taupf(tau float64) float64 {
+	tau1 := math.Hypot(1, tau)
+	sig := math.Sinh(p.e * math.Atanh(p.e*tau/tau1))
+	return math.Hypot(1, sig)*tau - sig*tau1
+}
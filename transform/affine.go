@@ -0,0 +1,126 @@
+package transform
+
+import (
+	"errors"
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// ErrNotInvertible 将会被返回，当仿射变换的行列式为0、不存在逆变换时
+var ErrNotInvertible = errors.New("transform: affine transformation is not invertible")
+
+// Affine 是平面上的仿射变换，使用矩阵
+//
+//	| A[0] A[1] A[2] |
+//	| A[3] A[4] A[5] |
+//	|  0    0    1   |
+//
+// 表示，即 x' = A[0]*x + A[1]*y + A[2]，y' = A[3]*x + A[4]*y + A[5]。
+// 仿射变换只修改坐标的 x、y 值，z、m 等其他值将保持不变。
+// Translate、Scale 等方法返回在原有变换之后再进行相应变换的新仿射变换，因此可以链式调用
+type Affine [6]float64
+
+// Identity函数 返回恒等变换
+func Identity() Affine {
+	return Affine{1, 0, 0, 0, 1, 0}
+}
+
+// Compose方法 返回先进行变换 a 再进行变换 b 的仿射变换
+func (a Affine) Compose(b Affine) Affine {
+	return Affine{
+		b[0]*a[0] + b[1]*a[3],
+		b[0]*a[1] + b[1]*a[4],
+		b[0]*a[2] + b[1]*a[5] + b[2],
+		b[3]*a[0] + b[4]*a[3],
+		b[3]*a[1] + b[4]*a[4],
+		b[3]*a[2] + b[4]*a[5] + b[5],
+	}
+}
+
+// Translate方法 返回在 a 之后沿 x 轴平移 dx、沿 y 轴平移 dy 的仿射变换
+func (a Affine) Translate(dx, dy float64) Affine {
+	return a.Compose(Affine{1, 0, dx, 0, 1, dy})
+}
+
+// Scale方法 返回在 a 之后以原点为中心沿 x 轴缩放 sx 倍、沿 y 轴缩放 sy 倍的仿射变换
+func (a Affine) Scale(sx, sy float64) Affine {
+	return a.Compose(Affine{sx, 0, 0, 0, sy, 0})
+}
+
+// Rotate方法 返回在 a 之后绕原点逆时针旋转 theta 弧度的仿射变换
+func (a Affine) Rotate(theta float64) Affine {
+	sin, cos := math.Sincos(theta)
+	return a.Compose(Affine{cos, -sin, 0, sin, cos, 0})
+}
+
+// RotateAround方法 返回在 a 之后绕点 (x, y) 逆时针旋转 theta 弧度的仿射变换
+func (a Affine) RotateAround(theta, x, y float64) Affine {
+	return a.Translate(-x, -y).Rotate(theta).Translate(x, y)
+}
+
+// Shear方法 返回在 a 之后进行错切的仿射变换，即 x' = x + shx*y，y' = shy*x + y
+func (a Affine) Shear(shx, shy float64) Affine {
+	return a.Compose(Affine{1, shx, 0, shy, 1, 0})
+}
+
+// Determinant方法 返回仿射变换的线性部分的行列式
+func (a Affine) Determinant() float64 {
+	return a[0]*a[4] - a[1]*a[3]
+}
+
+// Invert方法 返回仿射变换的逆变换，行列式为0时返回 ErrNotInvertible
+func (a Affine) Invert() (Affine, error) {
+	det := a.Determinant()
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return Affine{}, ErrNotInvertible
+	}
+	return Affine{
+		a[4] / det,
+		-a[1] / det,
+		(a[1]*a[5] - a[4]*a[2]) / det,
+		-a[3] / det,
+		a[0] / det,
+		(a[3]*a[2] - a[0]*a[5]) / det,
+	}, nil
+}
+
+// TransformXY方法 返回点 (x, y) 变换之后的坐标
+func (a Affine) TransformXY(x, y float64) (float64, float64) {
+	return a[0]*x + a[1]*y + a[2], a[3]*x + a[4]*y + a[5]
+}
+
+// TransformCoord方法 直接修改坐标 c 的 x、y 值
+func (a Affine) TransformCoord(c geom.Coord) {
+	c[0], c[1] = a.TransformXY(c[0], c[1])
+}
+
+// TransformGeom方法 返回几何图形变换之后的拷贝，g 本身不会被修改
+func (a Affine) TransformGeom(g geom.T) (geom.T, error) {
+	return Apply(g, a.TransformCoord)
+}
+
+// TransformBounds方法 返回包含边界框变换之后的四个角点的边界框，x、y 以外的维度保持不变。边界框为空时返回其拷贝
+func (a Affine) TransformBounds(b *geom.Bounds) *geom.Bounds {
+	if b.IsEmpty() {
+		return b.Clone()
+	}
+	stride := b.Layout().Stride()
+	min, max := make(geom.Coord, stride), make(geom.Coord, stride)
+	for i := 2; i < stride; i++ {
+		min[i], max[i] = b.Min(i), b.Max(i)
+	}
+	min[0], min[1] = math.Inf(1), math.Inf(1)
+	max[0], max[1] = math.Inf(-1), math.Inf(-1)
+	for _, corner := range [4][2]float64{
+		{b.Min(0), b.Min(1)},
+		{b.Max(0), b.Min(1)},
+		{b.Max(0), b.Max(1)},
+		{b.Min(0), b.Max(1)},
+	} {
+		x, y := a.TransformXY(corner[0], corner[1])
+		min[0], min[1] = math.Min(min[0], x), math.Min(min[1], y)
+		max[0], max[1] = math.Max(max[0], x), math.Max(max[1], y)
+	}
+	return geom.NewBounds(b.Layout()).SetCoords(min, max)
+}
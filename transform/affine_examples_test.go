@@ -0,0 +1,21 @@
+package transform_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/transform"
+)
+
+func ExampleAffine() {
+	square := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}})
+	a := transform.Identity().Translate(-1, -1).Scale(2, 0.5).Shear(1, 0)
+	g, err := a.TransformGeom(square)
+	if err != nil {
+		panic(err)
+	}
+	s, _ := wkt.Marshal(g)
+	fmt.Println(s)
+	// Output: POLYGON ((-2.5 -0.5, 1.5 -0.5, 2.5 0.5, -1.5 0.5, -2.5 -0.5))
+}
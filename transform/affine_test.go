@@ -0,0 +1,101 @@
+package transform
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+func affineAlmostEqual(a, b Affine) bool {
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-12 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAffine(t *testing.T) {
+	for i, tc := range []struct {
+		a            Affine
+		x, y         float64
+		wantX, wantY float64
+	}{
+		{a: Identity(), x: 1, y: 2, wantX: 1, wantY: 2},
+		{a: Identity().Translate(3, -1), x: 1, y: 2, wantX: 4, wantY: 1},
+		{a: Identity().Scale(2, 3), x: 1, y: 2, wantX: 2, wantY: 6},
+		{a: Identity().Rotate(math.Pi / 2), x: 1, y: 0, wantX: 0, wantY: 1},
+		{a: Identity().RotateAround(math.Pi, 1, 1), x: 2, y: 1, wantX: 0, wantY: 1},
+		{a: Identity().Shear(2, 0), x: 1, y: 1, wantX: 3, wantY: 1},
+		{a: Identity().Shear(0, 2), x: 1, y: 1, wantX: 1, wantY: 3},
+		{a: Identity().Translate(1, 0).Scale(2, 2), x: 1, y: 1, wantX: 4, wantY: 2},
+		{a: Identity().Scale(2, 2).Translate(1, 0), x: 1, y: 1, wantX: 3, wantY: 2},
+		{a: Identity().Translate(1, 0).Compose(Identity().Scale(2, 2)), x: 1, y: 1, wantX: 4, wantY: 2},
+	} {
+		x, y := tc.a.TransformXY(tc.x, tc.y)
+		if math.Abs(x-tc.wantX) > 1e-12 || math.Abs(y-tc.wantY) > 1e-12 {
+			t.Errorf("%d: %v.TransformXY(%v, %v) == %v, %v, want %v, %v", i, tc.a, tc.x, tc.y, x, y, tc.wantX, tc.wantY)
+		}
+		inverse, err := tc.a.Invert()
+		if err != nil {
+			t.Errorf("%d: %v.Invert() == _, %v, want _, <nil>", i, tc.a, err)
+			continue
+		}
+		if got := tc.a.Compose(inverse); !affineAlmostEqual(got, Identity()) {
+			t.Errorf("%d: %v.Compose(%v) == %v, want %v", i, tc.a, inverse, got, Identity())
+		}
+	}
+}
+
+func TestAffineInvertSingular(t *testing.T) {
+	if _, err := Identity().Scale(0, 1).Invert(); err != ErrNotInvertible {
+		t.Errorf("Identity().Scale(0, 1).Invert() == _, %v, want _, %v", err, ErrNotInvertible)
+	}
+}
+
+func TestAffineTransformGeom(t *testing.T) {
+	a := Identity().Translate(10, 20)
+	g := geom.NewLineStringFlat(geom.XYZM, []float64{0, 0, 5, 6, 1, 1, 7, 8}).SetSRID(3857)
+	got, err := a.TransformGeom(g)
+	if err != nil {
+		t.Fatalf("a.TransformGeom(g) == _, %v", err)
+	}
+	want := geom.NewLineStringFlat(geom.XYZM, []float64{10, 20, 5, 6, 11, 21, 7, 8}).SetSRID(3857)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("a.TransformGeom(g) == %v, want %v", got, want)
+	}
+}
+
+func TestAffineTransformBounds(t *testing.T) {
+	for i, tc := range []struct {
+		a       Affine
+		b, want *geom.Bounds
+	}{
+		{
+			a:    Identity().Translate(1, 2),
+			b:    geom.NewBounds(geom.XYZ).Set(0, 0, 5, 1, 1, 6),
+			want: geom.NewBounds(geom.XYZ).Set(1, 2, 5, 2, 3, 6),
+		},
+		{
+			a:    Identity().RotateAround(math.Pi/2, 1, 0),
+			b:    geom.NewBounds(geom.XY).Set(0, 0, 2, 1),
+			want: geom.NewBounds(geom.XY).Set(0, -1, 1, 1),
+		},
+		{
+			a:    Identity().Scale(-1, 1),
+			b:    geom.NewBounds(geom.XY),
+			want: geom.NewBounds(geom.XY),
+		},
+	} {
+		got := tc.a.TransformBounds(tc.b)
+		for dim := 0; dim < tc.want.Layout().Stride(); dim++ {
+			if math.Abs(got.Min(dim)-tc.want.Min(dim)) > 1e-12 && got.Min(dim) != tc.want.Min(dim) ||
+				math.Abs(got.Max(dim)-tc.want.Max(dim)) > 1e-12 && got.Max(dim) != tc.want.Max(dim) {
+				t.Errorf("%d: %v.TransformBounds(%v) == %v, want %v", i, tc.a, tc.b, got, tc.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package transform
+
+import "github.com/chengxiaoer/geomGo"
+
+// Apply函数 返回几何图形的拷贝，并对拷贝的每个坐标调用 f，g 本身不会被修改。
+// 传给 f 的坐标是拷贝中坐标的视图，在 f 中修改坐标的值将直接修改拷贝。
+// 支持所有的几何图形类型，包括嵌套的 GeometryCollection
+func Apply(g geom.T, f func(c geom.Coord)) (geom.T, error) {
+	clone, err := cloneGeometry(g)
+	if err != nil {
+		return nil, err
+	}
+	return clone, ApplyInPlace(clone, f)
+}
+
+// ApplyInPlace函数 对几何图形的每个坐标调用 f，在 f 中修改坐标的值将直接修改几何图形
+func ApplyInPlace(g geom.T, f func(c geom.Coord)) error {
+	switch g := g.(type) {
+	case *geom.Point, *geom.LineString, *geom.LinearRing, *geom.Polygon,
+		*geom.MultiPoint, *geom.MultiLineString, *geom.MultiPolygon:
+		applyFlat(g.FlatCoords(), g.Stride(), f)
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if err := ApplyInPlace(child, f); err != nil {
+				return err
+			}
+		}
+	default:
+		return geom.ErrUnsupportedType{Value: g}
+	}
+	return nil
+}
+
+func applyFlat(flatCoords []float64, stride int, f func(c geom.Coord)) {
+	if stride == 0 {
+		return
+	}
+	for i := 0; i < len(flatCoords); i += stride {
+		f(geom.Coord(flatCoords[i : i+stride : i+stride]))
+	}
+}
+
+// cloneGeometry 函数深层拷贝几何图形
+func cloneGeometry(g geom.T) (geom.T, error) {
+	switch g := g.(type) {
+	case *geom.Point:
+		return g.Clone(), nil
+	case *geom.LineString:
+		return g.Clone(), nil
+	case *geom.LinearRing:
+		return g.Clone(), nil
+	case *geom.Polygon:
+		return g.Clone(), nil
+	case *geom.MultiPoint:
+		return g.Clone(), nil
+	case *geom.MultiLineString:
+		return g.Clone(), nil
+	case *geom.MultiPolygon:
+		return g.Clone(), nil
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.SRID())
+		for _, child := range g.Geoms() {
+			clone, err := cloneGeometry(child)
+			if err != nil {
+				return nil, err
+			}
+			if err := gc.Push(clone); err != nil {
+				return nil, err
+			}
+		}
+		return gc, nil
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
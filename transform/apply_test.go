@@ -0,0 +1,74 @@
+package transform
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+func TestApply(t *testing.T) {
+	double := func(c geom.Coord) {
+		for i := range c {
+			c[i] *= 2
+		}
+	}
+	for i, tc := range []struct {
+		g, want geom.T
+	}{
+		{
+			g:    geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+			want: geom.NewPointFlat(geom.XY, []float64{2, 4}).SetSRID(4326),
+		},
+		{
+			g:    geom.NewLineStringFlat(geom.XYZ, []float64{1, 2, 3, 4, 5, 6}),
+			want: geom.NewLineStringFlat(geom.XYZ, []float64{2, 4, 6, 8, 10, 12}),
+		},
+		{
+			g:    geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}),
+			want: geom.NewPolygonFlat(geom.XY, []float64{0, 0, 2, 0, 2, 2, 0, 0}, []int{8}),
+		},
+		{
+			g:    geom.NewMultiPolygonFlat(geom.XYM, []float64{0, 0, 1, 1, 0, 1, 1, 1, 1, 0, 0, 1}, [][]int{{12}}),
+			want: geom.NewMultiPolygonFlat(geom.XYM, []float64{0, 0, 2, 2, 0, 2, 2, 2, 2, 0, 0, 2}, [][]int{{12}}),
+		},
+		{
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewMultiPointFlat(geom.XY, []float64{1, 1, 2, 2}),
+				geom.NewGeometryCollection().MustPush(geom.NewMultiLineStringFlat(geom.XY, []float64{3, 3, 4, 4}, []int{4})),
+			),
+			want: geom.NewGeometryCollection().MustPush(
+				geom.NewMultiPointFlat(geom.XY, []float64{2, 2, 4, 4}),
+				geom.NewGeometryCollection().MustPush(geom.NewMultiLineStringFlat(geom.XY, []float64{6, 6, 8, 8}, []int{4})),
+			),
+		},
+	} {
+		original, err := cloneGeometry(tc.g)
+		if err != nil {
+			t.Fatalf("%d: cloneGeometry(%v) == _, %v", i, tc.g, err)
+		}
+		got, err := Apply(tc.g, double)
+		if err != nil {
+			t.Errorf("%d: Apply(%v, _) == _, %v, want _, <nil>", i, tc.g, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%d: Apply(%v, _) == %v, want %v", i, tc.g, got, tc.want)
+		}
+		if !reflect.DeepEqual(tc.g, original) {
+			t.Errorf("%d: Apply modified its argument: %v", i, tc.g)
+		}
+		if err := ApplyInPlace(tc.g, double); err != nil {
+			t.Errorf("%d: ApplyInPlace(%v, _) == %v, want <nil>", i, tc.g, err)
+		}
+		if !reflect.DeepEqual(tc.g, tc.want) {
+			t.Errorf("%d: ApplyInPlace(_, _) modified g to %v, want %v", i, tc.g, tc.want)
+		}
+	}
+}
+
+func TestApplyUnsupportedType(t *testing.T) {
+	if _, err := Apply(nil, func(geom.Coord) {}); !reflect.DeepEqual(err, geom.ErrUnsupportedType{}) {
+		t.Errorf("Apply(nil, _) == _, %v, want _, %v", err, geom.ErrUnsupportedType{})
+	}
+}
@@ -0,0 +1,244 @@
+// Package buffer 包含了计算平面（XY）几何图形的缓冲区的函数。
+//
+// 缓冲区是与几何图形的距离不超过给定距离的所有点组成的区域。计算时为每条线生成偏移线组成的线环，
+// 在顶点处生成连接，在线的端点处生成端点，然后一次性分割所有的线环，缓冲区是环绕数大于0的区域，
+// 因此耗时接近顶点数的线性函数。圆弧使用正多边形近似，每个四分之一圆使用的线段数目可以配置。
+// 多边形的缓冲距离可以为负数，此时结果是从多边形中去掉与边界距离不超过该距离的部分。
+// 输入的几何图形必须是有效的，结果只包含 x、y 坐标，SRID 与输入的几何图形相同
+package buffer
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/internal/planar"
+	"github.com/chengxiaoer/geomGo/xy/internal/polygonize"
+	"github.com/chengxiaoer/geomGo/xy/overlay"
+)
+
+// CapStyle 是线的端点的样式
+type CapStyle int
+
+const (
+	// CapRound 表示圆形端点
+	CapRound CapStyle = iota
+	// CapFlat 表示平端点，缓冲区在线的端点处截止
+	CapFlat
+	// CapSquare 表示方形端点，缓冲区在线的端点处延长缓冲距离
+	CapSquare
+)
+
+// JoinStyle 是线的顶点处的连接样式
+type JoinStyle int
+
+const (
+	// JoinRound 表示圆形连接
+	JoinRound JoinStyle = iota
+	// JoinMitre 表示尖角连接，尖角的长度与缓冲距离之比超过 MitreLimit 时使用斜角连接
+	JoinMitre
+	// JoinBevel 表示斜角连接
+	JoinBevel
+)
+
+// DefaultQuadrantSegments 是默认的每个四分之一圆使用的线段数目
+const DefaultQuadrantSegments = 8
+
+// DefaultMitreLimit 是默认的尖角长度与缓冲距离之比的上限
+const DefaultMitreLimit = 5.0
+
+type params struct {
+	quadrantSegments int
+	endCap           CapStyle
+	joinStyle        JoinStyle
+	mitreLimit       float64
+}
+
+// Option 是缓冲区的参数
+type Option func(*params)
+
+// QuadrantSegments函数 设置每个四分之一圆使用的线段数目，小于1时使用1
+func QuadrantSegments(n int) Option {
+	return func(p *params) {
+		if n < 1 {
+			n = 1
+		}
+		p.quadrantSegments = n
+	}
+}
+
+// EndCap函数 设置线的端点的样式，默认为 CapRound
+func EndCap(style CapStyle) Option {
+	return func(p *params) {
+		p.endCap = style
+	}
+}
+
+// Join函数 设置线的顶点处的连接样式，默认为 JoinRound
+func Join(style JoinStyle) Option {
+	return func(p *params) {
+		p.joinStyle = style
+	}
+}
+
+// MitreLimit函数 设置尖角连接的尖角长度与缓冲距离之比的上限
+func MitreLimit(limit float64) Option {
+	return func(p *params) {
+		p.mitreLimit = limit
+	}
+}
+
+// Buffer函数 计算几何图形的缓冲区，返回多边形或多多边形，缓冲区为空时返回空的多边形。
+// 点和线的缓冲距离必须为正数，否则缓冲区为空；多边形的缓冲距离为负数时向内收缩，为0时返回多边形本身
+func Buffer(g geom.T, distance float64, options ...Option) (geom.T, error) {
+	p := &params{
+		quadrantSegments: DefaultQuadrantSegments,
+		endCap:           CapRound,
+		joinStyle:        JoinRound,
+		mitreLimit:       DefaultMitreLimit,
+	}
+	for _, o := range options {
+		o(p)
+	}
+	result, err := buffer(g, distance, p)
+	if err != nil {
+		return nil, err
+	}
+	return setSRID(result, g.SRID()), nil
+}
+
+func buffer(g geom.T, distance float64, p *params) (geom.T, error) {
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		if distance <= 0 {
+			return empty(), nil
+		}
+		b := &polygonize.WindingBuilder{}
+		for i := 0; i < len(g.FlatCoords()); i += g.Stride() {
+			for _, ring := range p.pointRings(g.FlatCoords()[i:i+2], distance) {
+				b.AddRing(0, ring)
+			}
+		}
+		return build(b, covered)
+	case *geom.LineString:
+		return bufferLines(g.FlatCoords(), []int{len(g.FlatCoords())}, g.Stride(), distance, p)
+	case *geom.LinearRing:
+		return bufferLines(g.FlatCoords(), []int{len(g.FlatCoords())}, g.Stride(), distance, p)
+	case *geom.MultiLineString:
+		return bufferLines(g.FlatCoords(), g.Ends(), g.Stride(), distance, p)
+	case *geom.Polygon:
+		return bufferPolygonal(g.FlatCoords(), [][]int{g.Ends()}, g.Stride(), distance, p)
+	case *geom.MultiPolygon:
+		return bufferPolygonal(g.FlatCoords(), g.Endss(), g.Stride(), distance, p)
+	case *geom.GeometryCollection:
+		b := &polygonize.WindingBuilder{}
+		for _, child := range g.Geoms() {
+			piece, err := buffer(child, distance, p)
+			if err != nil {
+				return nil, err
+			}
+			switch piece := piece.(type) {
+			case *geom.Polygon:
+				addPolygons(b, 0, piece.FlatCoords(), [][]int{piece.Ends()}, piece.Stride())
+			case *geom.MultiPolygon:
+				addPolygons(b, 0, piece.FlatCoords(), piece.Endss(), piece.Stride())
+			}
+		}
+		return build(b, covered)
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+func bufferLines(flatCoords []float64, ends []int, stride int, distance float64, p *params) (geom.T, error) {
+	if distance <= 0 {
+		return empty(), nil
+	}
+	b := &polygonize.WindingBuilder{}
+	offset := 0
+	for _, end := range ends {
+		for _, ring := range p.lineRings(planar.XYCoords(flatCoords, offset, end, stride), distance) {
+			b.AddRing(0, ring)
+		}
+		offset = end
+	}
+	return build(b, covered)
+}
+
+// bufferPolygonal 函数计算多边形或多多边形的缓冲区：距离为正数时求多边形与线环缓冲区的并集，为负数时求两者的差集。
+// 线环的缓冲区的环绕数在距离为正数时与多边形的相加，为负数时单独计算
+func bufferPolygonal(flatCoords []float64, endss [][]int, stride int, distance float64, p *params) (geom.T, error) {
+	b := &polygonize.WindingBuilder{}
+	addPolygons(b, 0, flatCoords, endss, stride)
+	if distance == 0 {
+		return build(b, covered)
+	}
+	source, selects := 0, covered
+	if distance < 0 {
+		source, selects = 1, eroded
+	}
+	// 线环是闭合的，不需要端点
+	ringParams := *p
+	ringParams.endCap = CapFlat
+	offset := 0
+	for _, ends := range endss {
+		for _, end := range ends {
+			for _, ring := range ringParams.lineRings(planar.XYCoords(flatCoords, offset, end, stride), math.Abs(distance)) {
+				b.AddRing(source, ring)
+			}
+			offset = end
+		}
+	}
+	return build(b, selects)
+}
+
+// addPolygons 函数添加多边形的线环，外边界为逆时针方向，洞为顺时针方向，这样多边形内部的环绕数为1
+func addPolygons(b *polygonize.WindingBuilder, source int, flatCoords []float64, endss [][]int, stride int) {
+	offset := 0
+	for _, ends := range endss {
+		for i, end := range ends {
+			ring := planar.XYCoords(flatCoords, offset, end, stride)
+			offset = end
+			if len(ring) < 8 {
+				continue
+			}
+			if (xy.SignedArea(geom.XY, ring) > 0) != (i > 0) {
+				planar.Reverse(ring)
+			}
+			b.AddRing(source, ring)
+		}
+	}
+}
+
+// covered 函数选择被至少一个部分覆盖的区域
+func covered(w polygonize.Winding) bool {
+	return w[0] > 0
+}
+
+// eroded 函数选择位于多边形内部且不在边界的缓冲区之内的区域
+func eroded(w polygonize.Winding) bool {
+	return w[0] > 0 && w[1] == 0
+}
+
+func build(b *polygonize.WindingBuilder, selects func(polygonize.Winding) bool) (geom.T, error) {
+	result, ok := b.Build(selects, 0)
+	if !ok {
+		return nil, overlay.ErrTopology
+	}
+	return result, nil
+}
+
+func empty() *geom.Polygon {
+	return geom.NewPolygon(geom.XY)
+}
+
+func setSRID(g geom.T, srid int) geom.T {
+	switch g := g.(type) {
+	case *geom.Polygon:
+		return g.SetSRID(srid)
+	case *geom.MultiPolygon:
+		return g.SetSRID(srid)
+	default:
+		return g
+	}
+}
@@ -0,0 +1,20 @@
+package buffer_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy/buffer"
+)
+
+func ExampleBuffer() {
+	route := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10})
+	corridor, err := buffer.Buffer(route, 1, buffer.EndCap(buffer.CapFlat), buffer.Join(buffer.JoinBevel))
+	if err != nil {
+		panic(err)
+	}
+	s, _ := wkt.Marshal(corridor)
+	fmt.Println(s)
+	// Output: POLYGON ((0 -1, 10 -1, 11 0, 11 10, 9 10, 9 1, 0 1, 0 -1))
+}
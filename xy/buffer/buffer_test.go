@@ -0,0 +1,278 @@
+package buffer_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/buffer"
+	"github.com/chengxiaoer/geomGo/xy/valid"
+)
+
+func TestBuffer(t *testing.T) {
+	for i, tc := range []struct {
+		g        string
+		distance float64
+		options  []buffer.Option
+		expected string
+	}{
+		{
+			g:        "POINT (0 0)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapSquare)},
+			expected: "POLYGON ((-1 -1, 1 -1, 1 1, -1 1, -1 -1))",
+		},
+		{
+			g:        "POINT (0 0)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapFlat)},
+			expected: "POLYGON EMPTY",
+		},
+		{
+			g:        "POINT (0 0)",
+			distance: 1,
+			options:  []buffer.Option{buffer.QuadrantSegments(1)},
+			expected: "POLYGON ((1 0, 0 1, -1 0, 0 -1, 1 0))",
+		},
+		{
+			g:        "POINT (0 0)",
+			distance: -1,
+			expected: "POLYGON EMPTY",
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapFlat)},
+			expected: "POLYGON ((0 -1, 10 -1, 10 1, 0 1, 0 -1))",
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapSquare)},
+			expected: "POLYGON ((0 -1, 10 -1, 11 -1, 11 1, 10 1, 0 1, -1 1, -1 -1, 0 -1))",
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0, 0 0)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapSquare), buffer.Join(buffer.JoinBevel)},
+			// 方形端点只在起点和终点处延长，折返处的斜角连接是平的
+			expected: "POLYGON ((0 -1, 10 -1, 10 0, 10 1, 0 1, -1 1, -1 -1, 0 -1))",
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0, 10 10)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapFlat), buffer.Join(buffer.JoinMitre)},
+			expected: "POLYGON ((0 -1, 10 -1, 11 -1, 11 0, 11 10, 9 10, 9 1, 0 1, 0 -1))",
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0, 10 10)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapFlat), buffer.Join(buffer.JoinBevel)},
+			expected: "POLYGON ((0 -1, 10 -1, 11 0, 11 10, 9 10, 9 1, 0 1, 0 -1))",
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0, 10 10)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapFlat), buffer.Join(buffer.JoinMitre), buffer.MitreLimit(1.2)},
+			expected: "POLYGON ((0 -1, 10 -1, 11 0, 11 10, 9 10, 9 1, 0 1, 0 -1))",
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0, 10 10, 0 10, 0 0)",
+			distance: 1,
+			options:  []buffer.Option{buffer.Join(buffer.JoinMitre)},
+			expected: "POLYGON ((0 -1, 10 -1, 11 -1, 11 0, 11 10, 11 11, 10 11, 0 11, -1 11, -1 10, -1 0, -1 -1, 0 -1), (1 1, 1 9, 9 9, 9 1, 1 1))",
+		},
+		{
+			g:        "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 6 4, 6 6, 4 6, 4 4))",
+			distance: -1,
+			options:  []buffer.Option{buffer.Join(buffer.JoinBevel)},
+			expected: "POLYGON ((1 9, 1 1, 9 1, 9 9, 1 9), (6 3, 4 3, 3 4, 3 6, 4 7, 6 7, 7 6, 7 4, 6 3))",
+		},
+		{
+			g:        "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			distance: -5,
+			expected: "POLYGON EMPTY",
+		},
+		{
+			g:        "POLYGON ((0 0, 0 10, 10 10, 10 0, 0 0))",
+			distance: 0,
+			expected: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+		},
+		{
+			g:        "MULTIPOINT ((0 0), (1 0), (5 5))",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapSquare)},
+			expected: "MULTIPOLYGON (((-1 -1, 0 -1, 1 -1, 2 -1, 2 1, 1 1, 0 1, -1 1, -1 -1)), ((4 4, 6 4, 6 6, 4 6, 4 4)))",
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := buffer.Buffer(g, tc.distance, tc.options...)
+		if err != nil {
+			t.Errorf("%d: Buffer(%s, %v) == _, %v, want nil error", i, tc.g, tc.distance, err)
+			continue
+		}
+		if got, err := wkt.Marshal(b); err != nil || got != tc.expected {
+			t.Errorf("%d: Buffer(%s, %v) == %s, %v, want %s", i, tc.g, tc.distance, got, err, tc.expected)
+		}
+	}
+}
+
+// area 函数计算多边形的面积，不依赖线环的方向
+func area(g geom.T) float64 {
+	var endss [][]int
+	switch g := g.(type) {
+	case *geom.Polygon:
+		endss = [][]int{g.Ends()}
+	case *geom.MultiPolygon:
+		endss = g.Endss()
+	}
+	sum, offset := 0.0, 0
+	for _, ends := range endss {
+		for i, end := range ends {
+			a := math.Abs(xy.SignedArea(g.Layout(), g.FlatCoords()[offset:end]))
+			if i > 0 {
+				a = -a
+			}
+			sum += a
+			offset = end
+		}
+	}
+	return sum
+}
+
+func TestBufferArea(t *testing.T) {
+	// 每个四分之一圆使用 n 条线段时，半径为 r 的圆的内接正多边形的面积
+	circle := func(r float64, n int) float64 {
+		return 2 * float64(n) * r * r * math.Sin(math.Pi/2/float64(n))
+	}
+	for i, tc := range []struct {
+		g        string
+		distance float64
+		options  []buffer.Option
+		expected float64
+	}{
+		{
+			g:        "POINT (3 4)",
+			distance: 2,
+			expected: circle(2, buffer.DefaultQuadrantSegments),
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0)",
+			distance: 1,
+			options:  []buffer.Option{buffer.QuadrantSegments(4)},
+			expected: 20 + circle(1, 4),
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0, 10 10, 0 10, 0 0)",
+			distance: 1,
+			expected: 144 - 4 + circle(1, buffer.DefaultQuadrantSegments) - 64,
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0, 0 0)",
+			distance: 1,
+			expected: 20 + circle(1, buffer.DefaultQuadrantSegments),
+		},
+		{
+			g:        "LINESTRING (0 0, 10 0, 10 10)",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapSquare), buffer.Join(buffer.JoinBevel)},
+			expected: 2*22 - 1 + 0.5,
+		},
+		{
+			// 顶点处的圆越过了第二条线段的平端点
+			g:        "LINESTRING (0 0, 10 0, 10 1)",
+			distance: 2,
+			options:  []buffer.Option{buffer.QuadrantSegments(1), buffer.EndCap(buffer.CapFlat)},
+			expected: 40 + 2 + 2 + 0.5,
+		},
+		{
+			// 终点处的圆越过了折返的顶点
+			g:        "LINESTRING (0 0, 10 0, 9 0)",
+			distance: 2,
+			options:  []buffer.Option{buffer.QuadrantSegments(1), buffer.Join(buffer.JoinBevel)},
+			expected: 40 + 4 + 1,
+		},
+		{
+			g:        "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 6 4, 6 6, 4 6, 4 4))",
+			distance: 1,
+			expected: 144 - 4 + circle(1, buffer.DefaultQuadrantSegments),
+		},
+		{
+			g:        "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 6 4, 6 6, 4 6, 4 4))",
+			distance: -1,
+			expected: 64 - (16 - 4 + circle(1, buffer.DefaultQuadrantSegments)),
+		},
+		{
+			g:        "GEOMETRYCOLLECTION (POINT (0 0), LINESTRING (0 0, 10 0))",
+			distance: 1,
+			options:  []buffer.Option{buffer.EndCap(buffer.CapSquare)},
+			expected: 24,
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := buffer.Buffer(g, tc.distance, tc.options...)
+		if err != nil {
+			t.Errorf("%d: Buffer(%s, %v) == _, %v, want nil error", i, tc.g, tc.distance, err)
+			continue
+		}
+		if ok, reason := valid.IsValid(b); !ok {
+			t.Errorf("%d: Buffer(%s, %v) is invalid: %v", i, tc.g, tc.distance, reason)
+		}
+		if got := area(b); math.Abs(got-tc.expected) > 1e-9 {
+			t.Errorf("%d: area of Buffer(%s, %v) == %v, want %v", i, tc.g, tc.distance, got, tc.expected)
+		}
+	}
+}
+
+func TestBufferLargeLine(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large line in short mode")
+	}
+	// 随机游走的路线，每条线段的长度在1到2之间，与相邻线段和附近线段的缓冲区大量重叠
+	r := rand.New(rand.NewSource(1))
+	n := 10000
+	coords := make([]float64, 0, 2*n)
+	x, y, angle := 0.0, 0.0, 0.0
+	for i := 0; i < n; i++ {
+		coords = append(coords, x, y)
+		angle += r.NormFloat64() / 2
+		length := 1 + r.Float64()
+		x, y = x+length*math.Cos(angle), y+length*math.Sin(angle)
+	}
+	start := time.Now()
+	b, err := buffer.Buffer(geom.NewLineStringFlat(geom.XY, coords), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 耗时接近顶点数的线性函数，在普通的机器上约为1.5秒；逐个合并每条线段的缓冲区时超过20秒
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Buffer of %d vertices took %v, want less than 10s", n, elapsed)
+	}
+	if ok, reason := valid.IsValid(b); !ok {
+		t.Errorf("Buffer of %d vertices is invalid: %v", n, reason)
+	}
+	if area(b) <= 0 {
+		t.Errorf("area of Buffer of %d vertices == %v, want positive", n, area(b))
+	}
+}
+
+func TestBufferSRID(t *testing.T) {
+	g := geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 1, 10, 0, 2}).SetSRID(3857)
+	b, err := buffer.Buffer(g, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.SRID() != 3857 || b.Layout() != geom.XY {
+		t.Errorf("got SRID %d and layout %v, want 3857 and XY", b.SRID(), b.Layout())
+	}
+}
@@ -0,0 +1,239 @@
+package buffer
+
+import "math"
+
+// collinearTolerance 是相邻两条线段方向的单位向量叉积的下限，小于该值时认为两条线段共线，不需要斜角和尖角连接
+const collinearTolerance = 1e-12
+
+// arcTolerance 是圆弧的端点与圆的内接正多边形的顶点之间的角度差与顶点间隔之比的下限，小于该值时认为两者重合
+const arcTolerance = 1e-9
+
+// pointRings 方法返回点的缓冲区的线环：圆形端点时为圆，方形端点时为正方形，平端点时没有线环
+func (p *params) pointRings(c []float64, distance float64) [][]float64 {
+	switch p.endCap {
+	case CapRound:
+		return [][]float64{p.circle(c, distance)}
+	case CapSquare:
+		x, y := c[0], c[1]
+		return [][]float64{closeRing([]float64{
+			x - distance, y - distance,
+			x + distance, y - distance,
+			x + distance, y + distance,
+			x - distance, y + distance,
+		})}
+	default:
+		return nil
+	}
+}
+
+// lineRings 方法返回线的缓冲区的线环，线环内部的环绕数大于0的区域就是缓冲区。
+//
+// 不闭合的线只有一个线环：沿着线的右侧偏移线前进，经过终点的端点，再沿着线的左侧偏移线返回，经过起点的端点。
+// 闭合的线有两个线环，分别是右侧和左侧的偏移线。偏移线在转向的外侧使用连接，在内侧经过顶点，
+// 这样线环等于每条线段两侧的矩形、外侧的连接和端点的边界之和，每个点的环绕数就是覆盖它的部分的数目。
+// 至少有4个点的线才可能是闭合的，A-B-A 这样往返的线仍然需要两个端点。
+//
+// 圆形端点和圆形连接的缓冲区包括以顶点为圆心的整个圆。圆内的每个点到线的最近点位于某条线段的内部、
+// 某个顶点的连接或者某个端点处，端点和连接都是圆形时这些点已经被覆盖，因此只在以下情况另外使用一个圆：
+// 折返的顶点；端点不是圆形时，越过端点所在直线的圆形连接；连接不是圆形时，距离在两倍缓冲距离之内有其他顶点的圆形端点
+func (p *params) lineRings(coords []float64, distance float64) [][]float64 {
+	n := len(coords) / 2
+	switch n {
+	case 0:
+		return nil
+	case 1:
+		return p.pointRings(coords, distance)
+	}
+	reversed := make([]float64, len(coords))
+	for i := 0; i < n; i++ {
+		reversed[2*i], reversed[2*i+1] = coords[2*(n-1-i)], coords[2*(n-1-i)+1]
+	}
+	closed := n >= 4 && coords[0] == coords[2*n-2] && coords[1] == coords[2*n-1]
+	var rings [][]float64
+	if closed {
+		rings = append(rings, p.offset(nil, coords, distance, true), p.offset(nil, reversed, distance, true))
+	} else {
+		ring := p.offset(nil, coords, distance, false)
+		ring = p.cap(ring, coords[2*n-4:], distance)
+		ring = p.offset(ring, reversed, distance, false)
+		ring = p.cap(ring, reversed[2*n-4:], distance)
+		rings = append(rings, closeRing(ring))
+	}
+	if p.endCap == CapRound && p.joinStyle != JoinRound && !closed {
+		for _, end := range [...][]float64{coords[:2], coords[2*n-2:]} {
+			if hasVertexWithin(coords[2:2*n-2], end, 2*distance) {
+				rings = append(rings, p.circle(end, distance))
+			}
+		}
+	}
+	if p.joinStyle == JoinRound {
+		for i := 1; i < n-1; i++ {
+			c := coords[2*i : 2*i+2]
+			if isReversal(coords[2*i-2:2*i], c, coords[2*i+2:2*i+4]) ||
+				p.endCap != CapRound && (behind(coords[:2], coords[2:4], c, distance) || behind(reversed[:2], reversed[2:4], c, distance)) {
+				rings = append(rings, p.circle(c, distance))
+			}
+		}
+		if closed && isReversal(coords[2*n-4:2*n-2], coords[:2], coords[2:4]) {
+			rings = append(rings, p.circle(coords[:2], distance))
+		}
+	}
+	return rings
+}
+
+// offset 方法将线 coords 右侧距离为 distance 的偏移线追加到 ring，在顶点处使用连接。
+// closed 为 true 时线是闭合的，在起点处也使用连接，返回闭合的线环
+func (p *params) offset(ring, coords []float64, distance float64, closed bool) []float64 {
+	n := len(coords) / 2
+	for i := 1; i < n; i++ {
+		p0, p1 := coords[2*i-2:2*i], coords[2*i:2*i+2]
+		nx, ny := rightNormal(p0, p1, distance)
+		ring = appendPoint(ring, p0[0]+nx, p0[1]+ny)
+		ring = appendPoint(ring, p1[0]+nx, p1[1]+ny)
+		switch {
+		case i < n-1:
+			ring = p.join(ring, p0, p1, coords[2*i+2:2*i+4], distance)
+		case closed:
+			ring = p.join(ring, p0, p1, coords[2:4], distance)
+		}
+	}
+	if closed {
+		ring = closeRing(ring)
+	}
+	return ring
+}
+
+// join 方法追加线段 p0-p1 与 p1-p2 的右侧偏移线在顶点 p1 处的连接，不包括两条偏移线的端点。
+// 左转时右侧是外侧，使用连接；右转或折返时右侧是内侧，偏移线经过顶点
+func (p *params) join(ring, p0, p1, p2 []float64, distance float64) []float64 {
+	ux1, uy1 := unit(p0, p1)
+	ux2, uy2 := unit(p1, p2)
+	cross := ux1*uy2 - uy1*ux2
+	switch {
+	case math.Abs(cross) < collinearTolerance && ux1*ux2+uy1*uy2 > 0:
+		return ring
+	case cross <= 0 || math.Abs(cross) < collinearTolerance:
+		return appendPoint(ring, p1[0], p1[1])
+	}
+	nx1, ny1 := rightNormal(p0, p1, distance)
+	nx2, ny2 := rightNormal(p1, p2, distance)
+	x, y := p1[0], p1[1]
+	switch p.joinStyle {
+	case JoinRound:
+		return p.arc(ring, p1, nx1, ny1, nx2, ny2, distance)
+	case JoinMitre:
+		// 尖角的顶点是两条偏移线的交点，与顶点的距离为 distance*sqrt(2/(1+cos θ))，θ 为两条线段法向量的夹角
+		cos := ux1*ux2 + uy1*uy2
+		if ratio := math.Sqrt(2 / (1 + cos)); ratio <= p.mitreLimit {
+			f := 1 / (1 + cos)
+			return appendPoint(ring, x+(nx1+nx2)*f, y+(ny1+ny2)*f)
+		}
+	}
+	return ring
+}
+
+// cap 方法追加线在终点处的端点，last 为线的最后两个点，不包括两侧偏移线的端点
+func (p *params) cap(ring, last []float64, distance float64) []float64 {
+	p0, p1 := last[:2], last[2:4]
+	nx, ny := rightNormal(p0, p1, distance)
+	switch p.endCap {
+	case CapRound:
+		return p.arc(ring, p1, nx, ny, -nx, -ny, distance)
+	case CapSquare:
+		ux, uy := unit(p0, p1)
+		ring = appendPoint(ring, p1[0]+nx+ux*distance, p1[1]+ny+uy*distance)
+		return appendPoint(ring, p1[0]-nx+ux*distance, p1[1]-ny+uy*distance)
+	default:
+		return ring
+	}
+}
+
+// arc 方法追加以 c 为圆心、从方向 (dx0, dy0) 逆时针旋转到方向 (dx1, dy1) 的圆弧上的 circle 的顶点，不包括圆弧的端点
+func (p *params) arc(ring, c []float64, dx0, dy0, dx1, dy1, distance float64) []float64 {
+	a0 := math.Atan2(dy0, dx0)
+	a1 := math.Atan2(dy1, dx1)
+	if a1 <= a0 {
+		a1 += 2 * math.Pi
+	}
+	// 圆弧的端点与顶点几乎重合时跳过该顶点，避免产生极短的边
+	step := math.Pi / 2 / float64(p.quadrantSegments)
+	t0, t1 := a0/step, a1/step
+	for k := math.Floor(t0+arcTolerance) + 1; k < t1-arcTolerance; k++ {
+		x, y := p.circleVertex(c, int(k), distance)
+		ring = appendPoint(ring, x, y)
+	}
+	return ring
+}
+
+// circle 方法返回以 c 为圆心、distance 为半径的圆的内接正多边形，方向为逆时针
+func (p *params) circle(c []float64, distance float64) []float64 {
+	n := 4 * p.quadrantSegments
+	coords := make([]float64, 0, 2*n+2)
+	for i := 0; i < n; i++ {
+		x, y := p.circleVertex(c, i, distance)
+		coords = append(coords, x, y)
+	}
+	return closeRing(coords)
+}
+
+// circleVertex 方法返回圆的内接正多边形的第 i 个顶点，第0个顶点在 x 轴正方向上。
+// 只计算第一象限的顶点，其他象限的顶点通过旋转得到，这样坐标轴上的顶点是精确的
+func (p *params) circleVertex(c []float64, i int, distance float64) (float64, float64) {
+	n := p.quadrantSegments
+	i = (i%(4*n) + 4*n) % (4 * n)
+	sin, cos := math.Sincos(math.Pi / 2 * float64(i%n) / float64(n))
+	dx, dy := distance*cos, distance*sin
+	for k := 0; k < i/n; k++ {
+		dx, dy = -dy, dx
+	}
+	return c[0] + dx, c[1] + dy
+}
+
+// isReversal 函数检测线段 p0-p1 与 p1-p2 是否方向相反
+func isReversal(p0, p1, p2 []float64) bool {
+	ux1, uy1 := unit(p0, p1)
+	ux2, uy2 := unit(p1, p2)
+	return math.Abs(ux1*uy2-uy1*ux2) < collinearTolerance && ux1*ux2+uy1*uy2 < 0
+}
+
+// behind 函数检测以 c 为圆心、distance 为半径的圆是否越过端点 p0 处垂直于第一条线段 p0-p1 的直线
+func behind(p0, p1, c []float64, distance float64) bool {
+	ux, uy := unit(p0, p1)
+	return (c[0]-p0[0])*ux+(c[1]-p0[1])*uy < distance
+}
+
+// hasVertexWithin 函数检测 coords 中是否有与 c 的距离小于 distance 的顶点
+func hasVertexWithin(coords, c []float64, distance float64) bool {
+	for i := 0; i < len(coords); i += 2 {
+		if math.Hypot(coords[i]-c[0], coords[i+1]-c[1]) < distance {
+			return true
+		}
+	}
+	return false
+}
+
+// rightNormal 函数返回线段 p0-p1 右侧长度为 distance 的法向量
+func rightNormal(p0, p1 []float64, distance float64) (float64, float64) {
+	ux, uy := unit(p0, p1)
+	return uy * distance, -ux * distance
+}
+
+// unit 函数返回从 p0 指向 p1 的单位向量
+func unit(p0, p1 []float64) (float64, float64) {
+	dx, dy := p1[0]-p0[0], p1[1]-p0[1]
+	l := math.Hypot(dx, dy)
+	return dx / l, dy / l
+}
+
+// appendPoint 函数在点与最后一个点不同时将其追加到 ring
+func appendPoint(ring []float64, x, y float64) []float64 {
+	if n := len(ring); n >= 2 && ring[n-2] == x && ring[n-1] == y {
+		return ring
+	}
+	return append(ring, x, y)
+}
+
+// closeRing 函数在线环的最后一个点与第一个点不同时追加第一个点
+func closeRing(ring []float64) []float64 {
+	return appendPoint(ring, ring[0], ring[1])
+}
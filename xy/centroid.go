@@ -8,6 +8,7 @@ import (
 
 // Centroid函数 计算几何体的质心。、
 //根据几何学的拓扑结构，质心可能在几何之外。
+// GeometryCollection 使用 CentroidCalculator 计算，只有维度最高的组成部分参与计算
 func Centroid(geometry geom.T) (centroid geom.Coord, err error) {
 	switch t := geometry.(type) {
 	case *geom.Point:
@@ -24,9 +25,123 @@ func Centroid(geometry geom.T) (centroid geom.Coord, err error) {
 		centroid = PolygonsCentroid(t)
 	case *geom.MultiPolygon:
 		centroid = MultiPolygonCentroid(t)
+	case *geom.GeometryCollection:
+		calc := NewCentroidCalculator()
+		if err = calc.AddGeometry(t); err == nil {
+			centroid = calc.GetCentroid()
+		}
 	default:
 		err = fmt.Errorf("%v is not a supported type for centroid calculation", t)
 	}
 
 	return centroid, err
 }
+
+// CentroidCalculator结构 组合了点、线和面的质心计算，可以计算不同维度的几何图形混合在一起的质心。
+// 与 PostGIS 一样，只有维度最高的组成部分决定质心：存在面积时使用以面积为权重的面的质心，
+// 否则使用以长度为权重的线的质心（包括面积为0的多边形的边界），否则使用点的平均值。
+// 每个几何图形使用各自的视图读取坐标，因此可以添加视图不同的几何图形。
+// 该结构必须使用 NewCentroidCalculator 函数创建
+type CentroidCalculator struct {
+	areaSum2    float64
+	areaCent3   geom.Coord
+	lineLength  float64
+	lineCentSum geom.Coord
+	points      PointCentroidCalculator
+}
+
+// NewCentroidCalculator函数 创建组合的质心计算器，创建后可以添加任意的几何图形
+func NewCentroidCalculator() *CentroidCalculator {
+	return &CentroidCalculator{
+		areaCent3:   geom.Coord{0, 0},
+		lineCentSum: geom.Coord{0, 0},
+		points:      NewPointCentroidCalculator(),
+	}
+}
+
+// AddGeometry方法 向计算器中添加几何图形，GeometryCollection 中的几何图形（包括嵌套的集合）逐个添加，空的组成部分将被忽略。
+// 遇到不支持的类型时返回错误，此前已添加的几何图形仍然保留在计算器中
+func (calc *CentroidCalculator) AddGeometry(g geom.T) error {
+	switch g := g.(type) {
+	case *geom.Point:
+		if len(g.FlatCoords()) > 0 {
+			calc.points.AddPoint(g)
+		}
+	case *geom.MultiPoint:
+		for i := 0; i < g.NumPoints(); i++ {
+			calc.points.AddCoord(g.Coord(i))
+		}
+	case *geom.LineString:
+		calc.addLine(g.Layout(), g.FlatCoords())
+	case *geom.LinearRing:
+		calc.addLine(g.Layout(), g.FlatCoords())
+	case *geom.MultiLineString:
+		for i := 0; i < g.NumLineStrings(); i++ {
+			calc.addLine(g.Layout(), g.LineString(i).FlatCoords())
+		}
+	case *geom.Polygon:
+		calc.addPolygon(g)
+	case *geom.MultiPolygon:
+		for i := 0; i < g.NumPolygons(); i++ {
+			calc.addPolygon(g.Polygon(i))
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if err := calc.AddGeometry(child); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%v is not a supported type for centroid calculation", g)
+	}
+	return nil
+}
+
+// GetCentroid方法 获取当前的质心，只包含 X 和 Y 坐标。没有添加任何非空的几何图形时返回 nil
+func (calc *CentroidCalculator) GetCentroid() geom.Coord {
+	switch {
+	case calc.areaSum2 != 0:
+		return geom.Coord{calc.areaCent3[0] / 3 / calc.areaSum2, calc.areaCent3[1] / 3 / calc.areaSum2}
+	case calc.lineLength > 0:
+		return geom.Coord{calc.lineCentSum[0] / calc.lineLength, calc.lineCentSum[1] / calc.lineLength}
+	case calc.points.ptCount > 0:
+		return calc.points.GetCentroid()
+	default:
+		return nil
+	}
+}
+
+// addLine 方法添加一条线，长度为0的线作为一个点添加
+func (calc *CentroidCalculator) addLine(layout geom.Layout, flatCoords []float64) {
+	if len(flatCoords) == 0 {
+		return
+	}
+	lineCalc := NewLineCentroidCalculator(layout)
+	lineCalc.addLine(flatCoords, 0, len(flatCoords))
+	if lineCalc.totalLength == 0 {
+		calc.points.AddCoord(geom.Coord(flatCoords))
+		return
+	}
+	calc.lineLength += lineCalc.totalLength
+	calc.lineCentSum[0] += lineCalc.centSum[0]
+	calc.lineCentSum[1] += lineCalc.centSum[1]
+}
+
+// addPolygon 方法添加一个多边形。三角形的面积和质心的加权和与基准点无关，因此每个多边形可以使用独立的 AreaCentroidCalculator 计算之后累加
+func (calc *CentroidCalculator) addPolygon(polygon *geom.Polygon) {
+	if polygon.NumLinearRings() == 0 || len(polygon.FlatCoords()) == 0 {
+		return
+	}
+	areaCalc := NewAreaCentroidCalculator(polygon.Layout())
+	areaCalc.AddPolygon(polygon)
+	calc.areaSum2 += areaCalc.areasum2
+	calc.areaCent3[0] += areaCalc.cg3[0]
+	calc.areaCent3[1] += areaCalc.cg3[1]
+	if areaCalc.totalLength == 0 {
+		calc.points.AddCoord(polygon.Coord(0))
+		return
+	}
+	calc.lineLength += areaCalc.totalLength
+	calc.lineCentSum[0] += areaCalc.centSum[0]
+	calc.lineCentSum[1] += areaCalc.centSum[1]
+}
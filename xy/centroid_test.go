@@ -1,10 +1,12 @@
 package xy_test
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
 	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
 	"github.com/chengxiaoer/geomGo/xy"
 	"github.com/chengxiaoer/geomGo/xy/internal"
 )
@@ -61,3 +63,89 @@ func TestCentroid(t *testing.T) {
 		}
 	}
 }
+
+func TestCentroidGeometryCollection(t *testing.T) {
+	for i, tc := range []struct {
+		desc string
+		g    string
+		want geom.Coord
+	}{
+		{
+			desc: "areas dominate lines and points",
+			g:    "GEOMETRYCOLLECTION (POINT (100 100), LINESTRING (0 0, 50 0), POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0)))",
+			want: geom.Coord{1, 1},
+		},
+		{
+			desc: "weighted by area",
+			g:    "GEOMETRYCOLLECTION (POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0)), MULTIPOLYGON (((4 0, 5 0, 5 1, 4 1, 4 0))))",
+			want: geom.Coord{1.7, 0.9},
+		},
+		{
+			desc: "hole",
+			g:    "GEOMETRYCOLLECTION (POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (0 0, 2 0, 2 2, 0 2, 0 0)))",
+			want: geom.Coord{7.0 / 3, 7.0 / 3},
+		},
+		{
+			desc: "lines dominate points",
+			g:    "GEOMETRYCOLLECTION (POINT (100 100), LINESTRING (0 0, 2 0), MULTILINESTRING ((0 2, 0 4)))",
+			want: geom.Coord{0.5, 1.5},
+		},
+		{
+			desc: "weighted by length",
+			g:    "GEOMETRYCOLLECTION (LINESTRING (0 0, 3 0), LINESTRING (10 0, 10 1))",
+			want: geom.Coord{3.625, 0.125},
+		},
+		{
+			desc: "zero area polygon is linear",
+			g:    "GEOMETRYCOLLECTION (POLYGON ((0 0, 2 0, 1 0, 0 0)), POINT (100 100))",
+			want: geom.Coord{1, 0},
+		},
+		{
+			desc: "points",
+			g:    "GEOMETRYCOLLECTION (POINT (0 0), MULTIPOINT (2 0, 4 6), LINESTRING (1 1, 1 1))",
+			want: geom.Coord{1.75, 1.75},
+		},
+		{
+			desc: "nested and mixed layouts",
+			g:    "GEOMETRYCOLLECTION (GEOMETRYCOLLECTION Z (POLYGON Z ((0 0 1, 2 0 1, 2 2 1, 0 2 1, 0 0 1))), POLYGON ((2 0, 4 0, 4 2, 2 2, 2 0)))",
+			want: geom.Coord{2, 1},
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.g)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.g, err)
+		}
+		got, err := xy.Centroid(g)
+		if err != nil || math.Abs(got[0]-tc.want[0]) > 1e-12 || math.Abs(got[1]-tc.want[1]) > 1e-12 {
+			t.Errorf("%d: %s: Centroid(...) == %v, %v, want %v, nil", i, tc.desc, got, err, tc.want)
+		}
+	}
+}
+
+func TestCentroidCalculator(t *testing.T) {
+	calc := xy.NewCentroidCalculator()
+	if got := calc.GetCentroid(); got != nil {
+		t.Errorf("GetCentroid() == %v, want nil", got)
+	}
+	if err := calc.AddGeometry(geom.NewGeometryCollection()); err != nil {
+		t.Errorf("AddGeometry(empty) == %v, want nil", err)
+	}
+	if got := calc.GetCentroid(); got != nil {
+		t.Errorf("GetCentroid() == %v, want nil", got)
+	}
+	if err := calc.AddGeometry(geom.NewPointFlat(geom.XY, []float64{1, 1})); err != nil {
+		t.Errorf("AddGeometry(point) == %v, want nil", err)
+	}
+	if err := calc.AddGeometry(geom.NewPointFlat(geom.XYZ, []float64{3, 5, 7})); err != nil {
+		t.Errorf("AddGeometry(point) == %v, want nil", err)
+	}
+	if got, want := calc.GetCentroid(), (geom.Coord{2, 3}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetCentroid() == %v, want %v", got, want)
+	}
+	if err := calc.AddGeometry(geom.NewLineStringFlat(geom.XY, []float64{0, 0, 0, 4})); err != nil {
+		t.Errorf("AddGeometry(line) == %v, want nil", err)
+	}
+	if got, want := calc.GetCentroid(), (geom.Coord{0, 2}); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetCentroid() == %v, want %v", got, want)
+	}
+}
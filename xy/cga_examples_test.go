@@ -108,3 +108,27 @@ func ExampleDistance() {
 	fmt.Println(distance)
 	// Output: 20
 }
+
+func ExampleDistanceBetween() {
+	polygon := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, []int{10})
+	line := geom.NewLineStringFlat(geom.XY, []float64{13, 14, 20, 20})
+	distance, err := xy.DistanceBetween(polygon, line)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(distance)
+	c1, c2, err := xy.NearestPoints(polygon, line)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(c1, c2)
+	within, err := xy.IsWithinDistance(polygon, line, 4)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(within)
+	// Output:
+	// 5
+	// [10 10] [13 14]
+	// false
+}
@@ -6,16 +6,152 @@ import (
 	"github.com/chengxiaoer/geomGo"
 	"github.com/chengxiaoer/geomGo/bigxy"
 	"github.com/chengxiaoer/geomGo/sorting"
+	"github.com/chengxiaoer/geomGo/transform"
 	"github.com/chengxiaoer/geomGo/xy/internal"
 	"github.com/chengxiaoer/geomGo/xy/orientation"
 )
 
+// ConvexHull函数 计算几何图形的凸包。凸包是包含几何图形中所有点的最小凸几何图形。
+// 根据输入点的分布，返回 Point、LineString 或 Polygon，几何图形为空时返回空的 GeometryCollection。
+// GeometryCollection 中的所有几何图形一起参与计算，如果它们的视图不同，则只使用 x、y 坐标。
+// 使用 Graham 扫描算法
+func ConvexHull(geometry geom.T) geom.T {
+	layout, flatCoords := convexHullInput(geometry)
+	hull := ConvexHullFlat(layout, flatCoords)
+	switch hull := hull.(type) {
+	case *geom.Point:
+		hull.SetSRID(geometry.SRID())
+	case *geom.LineString:
+		hull.SetSRID(geometry.SRID())
+	case *geom.Polygon:
+		hull.SetSRID(geometry.SRID())
+	case *geom.GeometryCollection:
+		hull.SetSRID(geometry.SRID())
+	}
+	return hull
+}
+
+// ConvexHullFlat函数 计算坐标数组的凸包，坐标数组不会被修改。
+// 根据输入点的分布，返回 Point、LineString 或 Polygon，坐标数组为空时返回空的 GeometryCollection。
+// 使用 Graham 扫描算法
+func ConvexHullFlat(layout geom.Layout, flatCoords []float64) geom.T {
+	calc := &convexHullCalculator{layout: layout, stride: layout.Stride()}
+	return calc.convexHull(flatCoords)
+}
+
+// convexHullInput 函数返回参与凸包计算的视图和坐标数组，GeometryCollection 中的坐标将被展开
+func convexHullInput(geometry geom.T) (geom.Layout, []float64) {
+	gc, ok := geometry.(*geom.GeometryCollection)
+	if !ok {
+		return geometry.Layout(), geometry.FlatCoords()
+	}
+	var leaves []geom.T
+	var collect func(gc *geom.GeometryCollection)
+	collect = func(gc *geom.GeometryCollection) {
+		for _, g := range gc.Geoms() {
+			if child, ok := g.(*geom.GeometryCollection); ok {
+				collect(child)
+			} else {
+				leaves = append(leaves, g)
+			}
+		}
+	}
+	collect(gc)
+	if len(leaves) == 0 {
+		return geom.XY, nil
+	}
+	layout := leaves[0].Layout()
+	for _, g := range leaves[1:] {
+		if g.Layout() != layout {
+			layout = geom.XY
+			break
+		}
+	}
+	var flatCoords []float64
+	for _, g := range leaves {
+		if g.Layout() == layout {
+			flatCoords = append(flatCoords, g.FlatCoords()...)
+			continue
+		}
+		gFlatCoords, stride := g.FlatCoords(), g.Stride()
+		for i := 0; i < len(gFlatCoords); i += stride {
+			flatCoords = append(flatCoords, gFlatCoords[i], gFlatCoords[i+1])
+		}
+	}
+	return layout, flatCoords
+}
+
 type convexHullCalculator struct {
 	layout   geom.Layout
 	stride   int
 	inputPts []float64
 }
 
+func (calc *convexHullCalculator) convexHull(flatCoords []float64) geom.T {
+	// UniqueCoords 返回一个新的数组，因此后续的排序不会修改输入的坐标
+	calc.inputPts = transform.UniqueCoords(calc.layout, comparator{}, flatCoords)
+
+	switch len(calc.inputPts) / calc.stride {
+	case 0:
+		return geom.NewGeometryCollection()
+	case 1:
+		return geom.NewPointFlat(calc.layout, calc.inputPts)
+	case 2:
+		return geom.NewLineStringFlat(calc.layout, calc.inputPts)
+	}
+
+	reducedPts := calc.inputPts
+	// 点较多时使用八边形启发式算法减少参与扫描的点
+	if len(calc.inputPts)/calc.stride > 50 {
+		reducedPts = calc.reduce(calc.inputPts)
+	}
+
+	calc.preSort(reducedPts)
+	return calc.lineOrPolygon(calc.grahamScan(reducedPts))
+}
+
+// reduce 方法移除位于八边形内部的点，这些点不可能是凸包的顶点
+func (calc *convexHullCalculator) reduce(inputPts []float64) []float64 {
+	polyPts := calc.computeOctRing(inputPts)
+	if polyPts == nil {
+		return inputPts
+	}
+
+	// 八边形的顶点必须保留，这样位于八边形边界上的点被丢弃也没有影响
+	reducedSet := transform.NewTreeSet(calc.layout, comparator{})
+	for i := 0; i < len(polyPts); i += calc.stride {
+		reducedSet.Insert(polyPts[i : i+calc.stride])
+	}
+	for i := 0; i < len(inputPts); i += calc.stride {
+		pt := inputPts[i : i+calc.stride]
+		if !calc.isPointInRing(pt, polyPts) {
+			reducedSet.Insert(pt)
+		}
+	}
+
+	reducedPts := reducedSet.ToFlatArray()
+	// 确保至少有3个点 (不必唯一)
+	if len(reducedPts) < 3*calc.stride {
+		return calc.padArray3(reducedPts)
+	}
+	return reducedPts
+}
+
+// isPointInRing 方法使用射线交叉法检测点是否位于线环内部，点位于线环上时结果不确定
+func (calc *convexHullCalculator) isPointInRing(p, ring []float64) bool {
+	crossings := 0
+	for i := calc.stride; i < len(ring); i += calc.stride {
+		x1, y1 := ring[i-calc.stride]-p[0], ring[i-calc.stride+1]-p[1]
+		x2, y2 := ring[i]-p[0], ring[i+1]-p[1]
+		if (y1 > 0 && y2 <= 0) || (y2 > 0 && y1 <= 0) {
+			if (x1*y2-x2*y1)/(y2-y1) > 0 {
+				crossings++
+			}
+		}
+	}
+	return crossings%2 == 1
+}
+
 func (calc *convexHullCalculator) lineOrPolygon(coordinates []float64) geom.T {
 
 	cleanCoords := calc.cleanRing(coordinates)
@@ -129,19 +265,18 @@ func (calc *convexHullCalculator) computeOctRing(inputPts []float64) []float64 {
 	}
 
 	// points must all lie in a line
-	if copyTo < 6 {
+	if copyTo < 2*stride {
 		return nil
 	}
 
 	copyTo += stride
-	octPts = octPts[0 : copyTo+stride]
-
+	// 八个点互不相同时 octPts 没有空间闭合线环，因此使用新的数组
+	ring := make([]float64, copyTo+stride)
+	copy(ring, octPts[:copyTo])
 	// close ring
-	for j := 0; j < stride; j++ {
-		octPts[copyTo+j] = octPts[j]
-	}
+	copy(ring[copyTo:], octPts[:stride])
 
-	return octPts
+	return ring
 }
 
 func (calc *convexHullCalculator) computeOctPts(inputPts []float64) []float64 {
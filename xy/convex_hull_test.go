@@ -1,11 +1,17 @@
 package xy
 
 import (
+	"math"
+	"math/rand"
 	"reflect"
 	"testing"
 
 	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/bigxy"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
 	"github.com/chengxiaoer/geomGo/xy/internal"
+	"github.com/chengxiaoer/geomGo/xy/location"
+	"github.com/chengxiaoer/geomGo/xy/orientation"
 )
 
 func TestPresort(t *testing.T) {
@@ -135,3 +141,163 @@ func TestGrahamScan(t *testing.T) {
 		t.Fatalf("calc.grahamScan(...) mutated the input coords.  Expected \n\t%v\nbut was\n\t%v", internal.RING.FlatCoords(), coords)
 	}
 }
+
+func TestConvexHull(t *testing.T) {
+	for i, tc := range []struct {
+		wkt, expected string
+	}{
+		// 以下测试用例来自 JTS 的 ConvexHullTest
+		{
+			wkt:      "LINESTRING (30 220, 240 220, 240 220)",
+			expected: "LINESTRING (30 220, 240 220)",
+		},
+		{
+			wkt:      "GEOMETRYCOLLECTION (POINT (130 240), POINT (130 240), POINT (130 240), POINT (570 240), POINT (570 240), POINT (570 240), POINT (650 240))",
+			expected: "LINESTRING (130 240, 650 240)",
+		},
+		{
+			wkt:      "MULTIPOINT (0 0, 0 0, 10 0)",
+			expected: "LINESTRING (0 0, 10 0)",
+		},
+		{
+			wkt:      "MULTIPOINT (0 0, 10 0, 10 0)",
+			expected: "LINESTRING (0 0, 10 0)",
+		},
+		{
+			wkt:      "MULTIPOINT (0 0, 5 0, 10 0)",
+			expected: "LINESTRING (0 0, 10 0)",
+		},
+		{
+			wkt:      "MULTIPOINT (0 0, 5 1, 10 0)",
+			expected: "POLYGON ((0 0, 5 1, 10 0, 0 0))",
+		},
+		{
+			wkt:      "MULTIPOINT (0 0, 0 0, 5 0, 5 0, 10 0, 10 0)",
+			expected: "LINESTRING (0 0, 10 0)",
+		},
+		{
+			wkt:      "MULTIPOINT (-0.2 -0.1, 0 -0.1, 0.2 -0.1, 0 -0.1, -0.2 0.1, 0 0.1, 0.2 0.1, 0 0.1)",
+			expected: "POLYGON ((-0.2 -0.1, -0.2 0.1, 0.2 0.1, 0.2 -0.1, -0.2 -0.1))",
+		},
+		{
+			wkt:      "MULTIPOINT (1 1, 1 1, 1 1)",
+			expected: "POINT (1 1)",
+		},
+		{
+			wkt:      "POINT (1 2)",
+			expected: "POINT (1 2)",
+		},
+		{
+			wkt:      "MULTIPOINT EMPTY",
+			expected: "GEOMETRYCOLLECTION EMPTY",
+		},
+		{
+			wkt:      "GEOMETRYCOLLECTION EMPTY",
+			expected: "GEOMETRYCOLLECTION EMPTY",
+		},
+		{
+			wkt:      "POLYGON ((0 0, 10 0, 5 2, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 1))",
+			expected: "POLYGON ((0 0, 0 10, 10 10, 10 0, 0 0))",
+		},
+		{
+			wkt:      "MULTIPOINT Z (0 0 1, 10 0 2, 10 10 3, 0 10 4, 5 5 5)",
+			expected: "POLYGON Z ((0 0 1, 0 10 4, 10 10 3, 10 0 2, 0 0 1))",
+		},
+		{
+			wkt:      "GEOMETRYCOLLECTION (POINT Z (0 0 1), LINESTRING (10 0, 10 10), GEOMETRYCOLLECTION (POINT (0 10)))",
+			expected: "POLYGON ((0 0, 0 10, 10 10, 10 0, 0 0))",
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.wkt)
+		if err != nil {
+			t.Fatalf("%d: wkt.Unmarshal(%q) == _, %v", i, tc.wkt, err)
+		}
+		if got, err := wkt.Marshal(ConvexHull(g)); err != nil || got != tc.expected {
+			t.Errorf("%d: ConvexHull(%s) == %s, %v, want %s, nil", i, tc.wkt, got, err, tc.expected)
+		}
+	}
+}
+
+func TestConvexHullReduce(t *testing.T) {
+	// 超过50个点时会使用八边形启发式算法过滤内部的点
+	coords := []float64{0, 0, 100, 0, 100, 100, 0, 100}
+	for i := 1; i < 10; i++ {
+		for j := 1; j < 10; j++ {
+			coords = append(coords, float64(10*i), float64(10*j))
+		}
+	}
+	input := append([]float64{}, coords...)
+
+	hull := ConvexHullFlat(geom.XY, coords)
+	expected := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 0, 100, 100, 100, 100, 0, 0, 0}, []int{10})
+	if !reflect.DeepEqual(hull, expected) {
+		t.Errorf("ConvexHullFlat(...) == %#v, want %#v", hull, expected)
+	}
+	if !reflect.DeepEqual(coords, input) {
+		t.Errorf("ConvexHullFlat(...) mutated the input coords")
+	}
+}
+
+func TestConvexHullReduceCircle(t *testing.T) {
+	// 圆上的点使八边形的八个顶点互不相同
+	for _, layout := range []geom.Layout{geom.XY, geom.XYZ} {
+		stride := layout.Stride()
+		var coords []float64
+		for i := 0; i < 64; i++ {
+			angle := 2 * math.Pi * float64(i) / 64
+			coords = append(coords, 10*math.Cos(angle), 10*math.Sin(angle))
+			if stride == 3 {
+				coords = append(coords, float64(i))
+			}
+		}
+		hull, ok := ConvexHullFlat(layout, coords).(*geom.Polygon)
+		if !ok {
+			t.Fatalf("ConvexHullFlat(%v, circle) is not a polygon", layout)
+		}
+		if got := hull.NumCoords(); got != 65 {
+			t.Errorf("ConvexHullFlat(%v, circle).NumCoords() == %d, want 65", layout, got)
+		}
+		vertices := map[[2]float64]bool{}
+		for i := 0; i < len(hull.FlatCoords()); i += stride {
+			vertices[[2]float64{hull.FlatCoords()[i], hull.FlatCoords()[i+1]}] = true
+		}
+		for i := 0; i < len(coords); i += stride {
+			if !vertices[[2]float64{coords[i], coords[i+1]}] {
+				t.Errorf("ConvexHullFlat(%v, circle) is missing vertex %v", layout, coords[i:i+stride])
+			}
+		}
+	}
+}
+
+func TestConvexHullReduceRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 100; n++ {
+		coords := make([]float64, 2*100)
+		for i := range coords {
+			coords[i] = r.Float64() * 100
+		}
+		hull, ok := ConvexHullFlat(geom.XY, coords).(*geom.Polygon)
+		if !ok {
+			t.Fatalf("%d: ConvexHullFlat(...) is not a polygon", n)
+		}
+		ring := hull.FlatCoords()
+		// 凸包的顶点按顺时针方向排列，并且所有的点都不在凸包的外部
+		for i := 2; i+2 < len(ring); i += 2 {
+			if o := bigxy.OrientationIndex(geom.Coord(ring[i-2:i]), geom.Coord(ring[i:i+2]), geom.Coord(ring[i+2:i+4])); o != orientation.Clockwise {
+				t.Errorf("%d: ConvexHullFlat(...) turns %v at %v", n, o, ring[i:i+2])
+			}
+		}
+		for i := 0; i < len(coords); i += 2 {
+			if LocatePointInRing(geom.XY, coords[i:i+2], ring) == location.Exterior {
+				t.Errorf("%d: %v is outside ConvexHullFlat(...)", n, coords[i:i+2])
+			}
+		}
+	}
+}
+
+func TestConvexHullSRID(t *testing.T) {
+	mp := geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 0}, {0, 1}}).SetSRID(4326)
+	if got := ConvexHull(mp).SRID(); got != 4326 {
+		t.Errorf("ConvexHull(...).SRID() == %d, want 4326", got)
+	}
+}
@@ -0,0 +1,342 @@
+package xy
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/internal"
+	"github.com/chengxiaoer/geomGo/xy/internal/lineintersector"
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+// DistanceBetween函数 计算两个几何图形之间的最短距离。
+// 支持所有的几何图形类型，包括 GeometryCollection。一个几何图形与多边形的内部相交时距离为0。
+// 任意一个几何图形为空时返回 math.Inf(1)，不支持的类型返回 geom.ErrUnsupportedType
+func DistanceBetween(g1, g2 geom.T) (float64, error) {
+	op, err := newDistanceOp(g1, g2, 0)
+	if err != nil {
+		return 0, err
+	}
+	op.compute()
+	return op.minDistance, nil
+}
+
+// NearestPoints函数 返回两个几何图形上距离最近的两个点，第一个点在 g1 上，第二个点在 g2 上。
+// 两个几何图形相交时两个点相同。任意一个几何图形为空时返回 nil, nil，不支持的类型返回 geom.ErrUnsupportedType
+func NearestPoints(g1, g2 geom.T) (geom.Coord, geom.Coord, error) {
+	op, err := newDistanceOp(g1, g2, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	op.compute()
+	return op.nearest[0], op.nearest[1], nil
+}
+
+// IsWithinDistance函数 检测两个几何图形之间的距离是否不超过 distance。
+// 边界框之间的距离超过 distance 时直接返回 false，找到距离不超过 distance 的点时立即返回 true，
+// 因此比 DistanceBetween 更适合用于邻近查询。不支持的类型返回 geom.ErrUnsupportedType
+func IsWithinDistance(g1, g2 geom.T, distance float64) (bool, error) {
+	op, err := newDistanceOp(g1, g2, distance)
+	if err != nil {
+		return false, err
+	}
+	if distance < 0 || op.facets[0].isEmpty() || op.facets[1].isEmpty() {
+		return false, nil
+	}
+	if boundsDistance(op.facets[0].bounds(), op.facets[1].bounds()) > distance {
+		return false, nil
+	}
+	op.compute()
+	return op.minDistance <= distance, nil
+}
+
+// boundsDistance 函数计算两个边界框之间的距离，边界框为 minX、minY、maxX、maxY
+func boundsDistance(b1, b2 [4]float64) float64 {
+	dx := math.Max(0, math.Max(b1[0]-b2[2], b2[0]-b1[2]))
+	dy := math.Max(0, math.Max(b1[1]-b2[3], b2[1]-b1[3]))
+	return math.Hypot(dx, dy)
+}
+
+// distanceFacets 是几何图形分解之后的组成部分：点、线（包括多边形的线环）和多边形
+type distanceFacets struct {
+	points   []geom.Coord
+	lines    []distanceLine
+	polygons []distancePolygon
+}
+
+type distanceLine struct {
+	flatCoords []float64
+	stride     int
+}
+
+type distancePolygon struct {
+	layout     geom.Layout
+	flatCoords []float64
+	offset     int
+	ends       []int
+}
+
+func (f *distanceFacets) add(g geom.T) error {
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		for i := 0; i < len(g.FlatCoords()); i += g.Stride() {
+			f.points = append(f.points, geom.Coord(g.FlatCoords()[i:i+2]))
+		}
+	case *geom.LineString, *geom.LinearRing:
+		f.addLines(g.FlatCoords(), 0, []int{len(g.FlatCoords())}, g.Stride())
+	case *geom.MultiLineString:
+		f.addLines(g.FlatCoords(), 0, g.Ends(), g.Stride())
+	case *geom.Polygon:
+		f.addPolygon(g.Layout(), g.FlatCoords(), 0, g.Ends())
+	case *geom.MultiPolygon:
+		offset := 0
+		for _, ends := range g.Endss() {
+			f.addPolygon(g.Layout(), g.FlatCoords(), offset, ends)
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if err := f.add(child); err != nil {
+				return err
+			}
+		}
+	default:
+		return geom.ErrUnsupportedType{Value: g}
+	}
+	return nil
+}
+
+func (f *distanceFacets) addLines(flatCoords []float64, offset int, ends []int, stride int) {
+	for _, end := range ends {
+		if end > offset {
+			f.lines = append(f.lines, distanceLine{flatCoords: flatCoords[offset:end], stride: stride})
+		}
+		offset = end
+	}
+}
+
+func (f *distanceFacets) addPolygon(layout geom.Layout, flatCoords []float64, offset int, ends []int) {
+	if len(ends) == 0 {
+		return
+	}
+	f.polygons = append(f.polygons, distancePolygon{layout: layout, flatCoords: flatCoords, offset: offset, ends: ends})
+	f.addLines(flatCoords, offset, ends, layout.Stride())
+}
+
+// anyCoords 方法返回每个组成部分的一个点，用于检测一个几何图形是否位于另一个几何图形的多边形之内
+func (f *distanceFacets) anyCoords() []geom.Coord {
+	coords := append([]geom.Coord(nil), f.points...)
+	for _, l := range f.lines {
+		coords = append(coords, geom.Coord(l.flatCoords[0:2]))
+	}
+	return coords
+}
+
+// bounds 方法返回所有点和线的 x、y 边界框
+func (f *distanceFacets) bounds() [4]float64 {
+	b := [4]float64{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	extend := func(x, y float64) {
+		b[0], b[1] = math.Min(b[0], x), math.Min(b[1], y)
+		b[2], b[3] = math.Max(b[2], x), math.Max(b[3], y)
+	}
+	for _, p := range f.points {
+		extend(p[0], p[1])
+	}
+	for _, l := range f.lines {
+		for i := 0; i < len(l.flatCoords); i += l.stride {
+			extend(l.flatCoords[i], l.flatCoords[i+1])
+		}
+	}
+	return b
+}
+
+func (f *distanceFacets) isEmpty() bool {
+	return len(f.points) == 0 && len(f.lines) == 0
+}
+
+// distanceOp 计算两个几何图形之间的最短距离和最近点，距离不超过 terminate 时提前结束
+type distanceOp struct {
+	facets      [2]distanceFacets
+	terminate   float64
+	minDistance float64
+	nearest     [2]geom.Coord
+}
+
+func newDistanceOp(g1, g2 geom.T, terminate float64) (*distanceOp, error) {
+	op := &distanceOp{terminate: terminate, minDistance: math.Inf(1)}
+	if err := op.facets[0].add(g1); err != nil {
+		return nil, err
+	}
+	if err := op.facets[1].add(g2); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (op *distanceOp) compute() {
+	if op.facets[0].isEmpty() || op.facets[1].isEmpty() {
+		return
+	}
+	op.computeContainment()
+	if op.done() {
+		return
+	}
+	op.computeFacetDistance()
+}
+
+func (op *distanceOp) done() bool {
+	return op.minDistance <= op.terminate
+}
+
+// update 方法在 distance 小于当前的最短距离时记录最近点，swap 为 true 时 c1 在第二个几何图形上
+func (op *distanceOp) update(distance float64, c1, c2 geom.Coord, swap bool) {
+	if distance >= op.minDistance {
+		return
+	}
+	if swap {
+		c1, c2 = c2, c1
+	}
+	op.minDistance = distance
+	op.nearest = [2]geom.Coord{copyXY(c1), copyXY(c2)}
+}
+
+// computeContainment 方法检测一个几何图形是否有点位于另一个几何图形的多边形之内或边界上
+func (op *distanceOp) computeContainment() {
+	for i := 0; i < 2; i++ {
+		polygons, coords := op.facets[i].polygons, op.facets[1-i].anyCoords()
+		for _, p := range polygons {
+			for _, c := range coords {
+				if locatePointInPolygon(p.layout, c, p.flatCoords, p.offset, p.ends) != location.Exterior {
+					op.update(0, c, c, false)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (op *distanceOp) computeFacetDistance() {
+	for i := 0; i < 2 && !op.done(); i++ {
+		swap := i == 1
+		a, b := &op.facets[i], &op.facets[1-i]
+		for _, p := range a.points {
+			for _, l := range b.lines {
+				op.pointLineDistance(p, l, swap)
+				if op.done() {
+					return
+				}
+			}
+			// 点与点之间的距离只计算一次
+			if swap {
+				continue
+			}
+			for _, q := range b.points {
+				op.update(internal.Distance2D(p, q), p, q, false)
+				if op.done() {
+					return
+				}
+			}
+		}
+	}
+	for _, l1 := range op.facets[0].lines {
+		for _, l2 := range op.facets[1].lines {
+			op.lineLineDistance(l1, l2)
+			if op.done() {
+				return
+			}
+		}
+	}
+}
+
+func (op *distanceOp) pointLineDistance(p geom.Coord, l distanceLine, swap bool) {
+	stride := l.stride
+	if len(l.flatCoords) == stride {
+		op.update(internal.Distance2D(p, l.flatCoords[0:2]), p, l.flatCoords[0:2], swap)
+		return
+	}
+	for i := stride; i < len(l.flatCoords); i += stride {
+		c := closestPointOnSegment(p, l.flatCoords[i-stride:i-stride+2], l.flatCoords[i:i+2])
+		op.update(internal.Distance2D(p, c), p, c, swap)
+		if op.done() {
+			return
+		}
+	}
+}
+
+func (op *distanceOp) lineLineDistance(l1, l2 distanceLine) {
+	s1, s2 := l1.stride, l2.stride
+	if len(l1.flatCoords) == s1 {
+		op.pointLineDistance(l1.flatCoords[0:2], l2, false)
+		return
+	}
+	if len(l2.flatCoords) == s2 {
+		op.pointLineDistance(l2.flatCoords[0:2], l1, true)
+		return
+	}
+	for i := s1; i < len(l1.flatCoords); i += s1 {
+		a0, a1 := geom.Coord(l1.flatCoords[i-s1:i-s1+2]), geom.Coord(l1.flatCoords[i:i+2])
+		for j := s2; j < len(l2.flatCoords); j += s2 {
+			b0, b1 := geom.Coord(l2.flatCoords[j-s2:j-s2+2]), geom.Coord(l2.flatCoords[j:j+2])
+			if segmentsBoundsDistance(a0, a1, b0, b1) >= op.minDistance {
+				continue
+			}
+			c1, c2 := closestPointsOnSegments(a0, a1, b0, b1)
+			op.update(internal.Distance2D(c1, c2), c1, c2, false)
+			if op.done() {
+				return
+			}
+		}
+	}
+}
+
+// segmentsBoundsDistance 函数计算两条线段的边界框之间的距离，是两条线段之间距离的下限
+func segmentsBoundsDistance(a0, a1, b0, b1 geom.Coord) float64 {
+	dx := math.Max(0, math.Max(math.Min(a0[0], a1[0])-math.Max(b0[0], b1[0]), math.Min(b0[0], b1[0])-math.Max(a0[0], a1[0])))
+	dy := math.Max(0, math.Max(math.Min(a0[1], a1[1])-math.Max(b0[1], b1[1]), math.Min(b0[1], b1[1])-math.Max(a0[1], a1[1])))
+	return math.Hypot(dx, dy)
+}
+
+// closestPointOnSegment 函数返回线段 p0-p1 上距离点 p 最近的点
+func closestPointOnSegment(p, p0, p1 geom.Coord) geom.Coord {
+	dx, dy := p1[0]-p0[0], p1[1]-p0[1]
+	len2 := dx*dx + dy*dy
+	if len2 == 0 {
+		return p0
+	}
+	r := ((p[0]-p0[0])*dx + (p[1]-p0[1])*dy) / len2
+	switch {
+	case r <= 0:
+		return p0
+	case r >= 1:
+		return p1
+	}
+	return geom.Coord{p0[0] + r*dx, p0[1] + r*dy}
+}
+
+// closestPointsOnSegments 函数返回两条线段上距离最近的两个点，两条线段相交时返回交点
+func closestPointsOnSegments(a0, a1, b0, b1 geom.Coord) (geom.Coord, geom.Coord) {
+	result := lineintersector.LineIntersectsLine(lineintersector.RobustLineIntersector{}, a0, a1, b0, b1)
+	if result.HasIntersection() {
+		c := result.Intersection()[0]
+		return c, c
+	}
+	best := math.Inf(1)
+	var c1, c2 geom.Coord
+	for _, candidate := range [...][2]geom.Coord{
+		{a0, closestPointOnSegment(a0, b0, b1)},
+		{a1, closestPointOnSegment(a1, b0, b1)},
+		{closestPointOnSegment(b0, a0, a1), b0},
+		{closestPointOnSegment(b1, a0, a1), b1},
+	} {
+		if d := internal.Distance2D(candidate[0], candidate[1]); d < best {
+			best, c1, c2 = d, candidate[0], candidate[1]
+		}
+	}
+	return c1, c2
+}
+
+func copyXY(c geom.Coord) geom.Coord {
+	return geom.Coord{c[0], c[1]}
+}
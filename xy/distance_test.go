@@ -0,0 +1,172 @@
+package xy_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+)
+
+func TestDistanceBetween(t *testing.T) {
+	for i, tc := range []struct {
+		desc     string
+		g1, g2   string
+		distance float64
+		nearest  [2]geom.Coord
+	}{
+		{
+			desc:     "point to point",
+			g1:       "POINT (0 0)",
+			g2:       "POINT (3 4)",
+			distance: 5,
+			nearest:  [2]geom.Coord{{0, 0}, {3, 4}},
+		},
+		{
+			desc:     "point to line",
+			g1:       "POINT (5 5)",
+			g2:       "LINESTRING (0 0, 10 0)",
+			distance: 5,
+			nearest:  [2]geom.Coord{{5, 5}, {5, 0}},
+		},
+		{
+			desc:     "line to point",
+			g1:       "LINESTRING (0 0, 10 0)",
+			g2:       "POINT (12 0)",
+			distance: 2,
+			nearest:  [2]geom.Coord{{10, 0}, {12, 0}},
+		},
+		{
+			desc:     "crossing lines",
+			g1:       "LINESTRING (0 0, 10 10)",
+			g2:       "LINESTRING (0 10, 10 0)",
+			distance: 0,
+			nearest:  [2]geom.Coord{{5, 5}, {5, 5}},
+		},
+		{
+			desc:     "parallel lines",
+			g1:       "LINESTRING (0 0, 10 0)",
+			g2:       "LINESTRING (2 3, 8 3)",
+			distance: 3,
+			nearest:  [2]geom.Coord{{2, 0}, {2, 3}},
+		},
+		{
+			desc:     "point inside polygon",
+			g1:       "POINT (5 5)",
+			g2:       "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			distance: 0,
+			nearest:  [2]geom.Coord{{5, 5}, {5, 5}},
+		},
+		{
+			desc:     "point in hole",
+			g1:       "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 8 2, 8 8, 2 8, 2 2))",
+			g2:       "POINT (5 4)",
+			distance: 2,
+			nearest:  [2]geom.Coord{{5, 2}, {5, 4}},
+		},
+		{
+			desc:     "line inside polygon",
+			g1:       "LINESTRING (1 1, 2 2)",
+			g2:       "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			distance: 0,
+			nearest:  [2]geom.Coord{{1, 1}, {1, 1}},
+		},
+		{
+			desc:     "polygon inside polygon",
+			g1:       "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			g2:       "POLYGON ((2 2, 3 2, 3 3, 2 2))",
+			distance: 0,
+			nearest:  [2]geom.Coord{{2, 2}, {2, 2}},
+		},
+		{
+			desc:     "disjoint polygons",
+			g1:       "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			g2:       "POLYGON ((13 14, 20 14, 20 20, 13 14))",
+			distance: 5,
+			nearest:  [2]geom.Coord{{10, 10}, {13, 14}},
+		},
+		{
+			desc:     "multi point to multi line",
+			g1:       "MULTIPOINT ((0 10), (20 1))",
+			g2:       "MULTILINESTRING ((0 0, 5 0), (15 0, 20 0))",
+			distance: 1,
+			nearest:  [2]geom.Coord{{20, 1}, {20, 0}},
+		},
+		{
+			desc:     "multi polygon",
+			g1:       "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((10 0, 20 0, 20 10, 10 0)))",
+			g2:       "POINT (15 2)",
+			distance: 0,
+			nearest:  [2]geom.Coord{{15, 2}, {15, 2}},
+		},
+		{
+			desc:     "geometry collection",
+			g1:       "GEOMETRYCOLLECTION (POINT (100 100), LINESTRING (0 0, 0 10))",
+			g2:       "GEOMETRYCOLLECTION (POINT (50 50), POLYGON ((3 0, 4 0, 4 1, 3 0)))",
+			distance: 3,
+			nearest:  [2]geom.Coord{{0, 0}, {3, 0}},
+		},
+	} {
+		g1, err := wkt.Unmarshal(tc.g1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g2, err := wkt.Unmarshal(tc.g2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := xy.DistanceBetween(g1, g2); err != nil || math.Abs(got-tc.distance) > 1e-9 {
+			t.Errorf("%d: %s: DistanceBetween(%s, %s) == %v, %v, want %v, nil", i, tc.desc, tc.g1, tc.g2, got, err, tc.distance)
+		}
+		if got, err := xy.DistanceBetween(g2, g1); err != nil || math.Abs(got-tc.distance) > 1e-9 {
+			t.Errorf("%d: %s: DistanceBetween(%s, %s) == %v, %v, want %v, nil", i, tc.desc, tc.g2, tc.g1, got, err, tc.distance)
+		}
+		if c1, c2, err := xy.NearestPoints(g1, g2); err != nil || !c1.Equal(geom.XY, tc.nearest[0]) || !c2.Equal(geom.XY, tc.nearest[1]) {
+			t.Errorf("%d: %s: NearestPoints(%s, %s) == %v, %v, %v, want %v, %v, nil", i, tc.desc, tc.g1, tc.g2, c1, c2, err, tc.nearest[0], tc.nearest[1])
+		}
+		for _, d := range []float64{tc.distance, tc.distance + 0.5} {
+			if within, err := xy.IsWithinDistance(g1, g2, d); err != nil || !within {
+				t.Errorf("%d: %s: IsWithinDistance(%s, %s, %v) == %v, %v, want true, nil", i, tc.desc, tc.g1, tc.g2, d, within, err)
+			}
+		}
+		if within, err := xy.IsWithinDistance(g1, g2, tc.distance-0.5); tc.distance > 0 && (err != nil || within) {
+			t.Errorf("%d: %s: IsWithinDistance(%s, %s, %v) == %v, %v, want false, nil", i, tc.desc, tc.g1, tc.g2, tc.distance-0.5, within, err)
+		}
+	}
+}
+
+func TestDistanceBetweenEmpty(t *testing.T) {
+	point := geom.NewPointFlat(geom.XY, []float64{0, 0})
+	for i, g := range []geom.T{
+		geom.NewMultiPoint(geom.XY),
+		geom.NewLineString(geom.XY),
+		geom.NewPolygon(geom.XY),
+		geom.NewGeometryCollection(),
+	} {
+		if got, err := xy.DistanceBetween(point, g); err != nil || !math.IsInf(got, 1) {
+			t.Errorf("%d: DistanceBetween(%v, %v) == %v, %v, want +Inf, nil", i, point, g, got, err)
+		}
+		if c1, c2, err := xy.NearestPoints(g, point); err != nil || c1 != nil || c2 != nil {
+			t.Errorf("%d: NearestPoints(%v, %v) == %v, %v, %v, want nil, nil, nil", i, g, point, c1, c2, err)
+		}
+		if within, err := xy.IsWithinDistance(point, g, 100); err != nil || within {
+			t.Errorf("%d: IsWithinDistance(%v, %v, 100) == %v, %v, want false, nil", i, point, g, within, err)
+		}
+	}
+}
+
+func TestDistanceBetweenUnsupportedType(t *testing.T) {
+	point := geom.NewPointFlat(geom.XY, []float64{0, 0})
+	want := geom.ErrUnsupportedType{Value: nil}
+	if _, err := xy.DistanceBetween(point, nil); !reflect.DeepEqual(err, want) {
+		t.Errorf("DistanceBetween(point, nil) == _, %v, want _, %v", err, want)
+	}
+	if _, _, err := xy.NearestPoints(nil, point); !reflect.DeepEqual(err, want) {
+		t.Errorf("NearestPoints(nil, point) == _, _, %v, want _, _, %v", err, want)
+	}
+	if _, err := xy.IsWithinDistance(point, nil, 1); !reflect.DeepEqual(err, want) {
+		t.Errorf("IsWithinDistance(point, nil, 1) == _, %v, want _, %v", err, want)
+	}
+}
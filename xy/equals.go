@@ -0,0 +1,264 @@
+package xy
+
+import (
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// EqualsTopo函数 检测两个几何图形在规范化之后是否相同。
+// 规范化将忽略线环的起点和方向、线的方向、相邻的重复点、多边形中内环的顺序、集合中组成部分的顺序以及 MultiPoint 中重复的点，
+// 之后使用 geom.Equal 比较类型、视图、SRID 和坐标。不支持的类型返回 false
+func EqualsTopo(g1, g2 geom.T) bool {
+	n1, ok := normalize(g1)
+	if !ok {
+		return false
+	}
+	n2, ok := normalize(g2)
+	if !ok {
+		return false
+	}
+	return geom.Equal(n1, n2)
+}
+
+// normalize 函数返回几何图形规范化之后的拷贝
+func normalize(g geom.T) (geom.T, bool) {
+	switch g := g.(type) {
+	case *geom.Point:
+		return g.Clone(), true
+	case *geom.LineString:
+		return geom.NewLineStringFlat(g.Layout(), normalizeLine(g.FlatCoords(), g.Stride())).SetSRID(g.SRID()), true
+	case *geom.LinearRing:
+		return geom.NewLinearRingFlat(g.Layout(), normalizeRing(g.FlatCoords(), g.Stride())).SetSRID(g.SRID()), true
+	case *geom.Polygon:
+		flatCoords, ends := normalizePolygon(g.FlatCoords(), 0, g.Ends(), g.Stride())
+		return geom.NewPolygonFlat(g.Layout(), flatCoords, ends).SetSRID(g.SRID()), true
+	case *geom.MultiPoint:
+		return geom.NewMultiPointFlat(g.Layout(), normalizePoints(g.FlatCoords(), g.Stride())).SetSRID(g.SRID()), true
+	case *geom.MultiLineString:
+		var lines [][]float64
+		offset := 0
+		for _, end := range g.Ends() {
+			lines = append(lines, normalizeLine(g.FlatCoords()[offset:end], g.Stride()))
+			offset = end
+		}
+		flatCoords, ends := joinParts(nil, lines)
+		return geom.NewMultiLineStringFlat(g.Layout(), flatCoords, ends).SetSRID(g.SRID()), true
+	case *geom.MultiPolygon:
+		var polygons []normalizedPolygon
+		offset := 0
+		for _, ends := range g.Endss() {
+			flatCoords, polygonEnds := normalizePolygon(g.FlatCoords(), offset, ends, g.Stride())
+			polygons = append(polygons, normalizedPolygon{flatCoords: flatCoords, ends: polygonEnds})
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+		sort.SliceStable(polygons, func(i, j int) bool {
+			return compareFlatCoords(polygons[i].flatCoords, polygons[j].flatCoords) < 0
+		})
+		var flatCoords []float64
+		var endss [][]int
+		for _, p := range polygons {
+			ends := make([]int, len(p.ends))
+			for i, end := range p.ends {
+				ends[i] = len(flatCoords) + end
+			}
+			flatCoords = append(flatCoords, p.flatCoords...)
+			endss = append(endss, ends)
+		}
+		return geom.NewMultiPolygonFlat(g.Layout(), flatCoords, endss).SetSRID(g.SRID()), true
+	case *geom.GeometryCollection:
+		var geoms []geom.T
+		for _, child := range g.Geoms() {
+			n, ok := normalize(child)
+			if !ok {
+				return nil, false
+			}
+			geoms = append(geoms, n)
+		}
+		sort.SliceStable(geoms, func(i, j int) bool {
+			return compareGeoms(geoms[i], geoms[j]) < 0
+		})
+		return geom.NewGeometryCollection().MustPush(geoms...).SetSRID(g.SRID()), true
+	default:
+		return nil, false
+	}
+}
+
+type normalizedPolygon struct {
+	flatCoords []float64
+	ends       []int
+}
+
+// normalizePolygon 函数规范化多边形的每个线环，外环保持在第一个位置，内环按照坐标排序
+func normalizePolygon(flatCoords []float64, offset int, ends []int, stride int) ([]float64, []int) {
+	var rings [][]float64
+	for _, end := range ends {
+		rings = append(rings, normalizeRing(flatCoords[offset:end], stride))
+		offset = end
+	}
+	if len(rings) == 0 {
+		return nil, nil
+	}
+	return joinParts(rings[:1], rings[1:])
+}
+
+// joinParts 函数将 first 和排序之后的 parts 依次连接为平面坐标数组，并返回每个部分的结束位置
+func joinParts(first, parts [][]float64) ([]float64, []int) {
+	sort.SliceStable(parts, func(i, j int) bool {
+		return compareFlatCoords(parts[i], parts[j]) < 0
+	})
+	var flatCoords []float64
+	var ends []int
+	for _, ps := range [][][]float64{first, parts} {
+		for _, part := range ps {
+			flatCoords = append(flatCoords, part...)
+			ends = append(ends, len(flatCoords))
+		}
+	}
+	return flatCoords, ends
+}
+
+// removeRepeated 函数返回删除了相邻的重复点之后的坐标
+func removeRepeated(flatCoords []float64, stride int) []float64 {
+	result := make([]float64, 0, len(flatCoords))
+	for i := 0; i < len(flatCoords); i += stride {
+		c := flatCoords[i : i+stride]
+		if len(result) == 0 || compareFlatCoords(result[len(result)-stride:], c) != 0 {
+			result = append(result, c...)
+		}
+	}
+	return result
+}
+
+// reversed 函数返回坐标顺序相反的拷贝
+func reversed(flatCoords []float64, stride int) []float64 {
+	result := make([]float64, 0, len(flatCoords))
+	for i := len(flatCoords) - stride; i >= 0; i -= stride {
+		result = append(result, flatCoords[i:i+stride]...)
+	}
+	return result
+}
+
+// normalizeLine 函数返回删除了相邻的重复点，并且在两个方向中选择坐标较小的一个方向的线
+func normalizeLine(flatCoords []float64, stride int) []float64 {
+	forward := removeRepeated(flatCoords, stride)
+	if backward := reversed(forward, stride); compareFlatCoords(backward, forward) < 0 {
+		return backward
+	}
+	return forward
+}
+
+// normalizeRing 函数返回删除了相邻的重复点，起点为最小的坐标，并且在两个方向中选择坐标较小的一个方向的闭合线环
+func normalizeRing(flatCoords []float64, stride int) []float64 {
+	coords := removeRepeated(flatCoords, stride)
+	if n := len(coords); n > stride && compareFlatCoords(coords[:stride], coords[n-stride:]) == 0 {
+		coords = coords[:n-stride]
+	}
+	if len(coords) == 0 {
+		return coords
+	}
+	var best []float64
+	for _, sequence := range [][]float64{coords, reversed(coords, stride)} {
+		min := sequence[:stride]
+		for i := stride; i < len(sequence); i += stride {
+			if compareFlatCoords(sequence[i:i+stride], min) < 0 {
+				min = sequence[i : i+stride]
+			}
+		}
+		for i := 0; i < len(sequence); i += stride {
+			if compareFlatCoords(sequence[i:i+stride], min) != 0 {
+				continue
+			}
+			rotated := make([]float64, 0, len(sequence)+stride)
+			rotated = append(rotated, sequence[i:]...)
+			rotated = append(rotated, sequence[:i]...)
+			if best == nil || compareFlatCoords(rotated, best) < 0 {
+				best = rotated
+			}
+		}
+	}
+	return append(best, best[:stride]...)
+}
+
+// normalizePoints 函数返回排序并删除了重复点之后的坐标
+func normalizePoints(flatCoords []float64, stride int) []float64 {
+	var points [][]float64
+	for i := 0; i < len(flatCoords); i += stride {
+		points = append(points, flatCoords[i:i+stride])
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return compareFlatCoords(points[i], points[j]) < 0
+	})
+	result := make([]float64, 0, len(flatCoords))
+	for _, p := range points {
+		if len(result) == 0 || compareFlatCoords(result[len(result)-stride:], p) != 0 {
+			result = append(result, p...)
+		}
+	}
+	return result
+}
+
+// compareFlatCoords 函数按照字典顺序比较两个坐标数组，返回-1、0或1
+func compareFlatCoords(a, b []float64) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// geomRank 函数返回几何图形类型的顺序，用于排序 GeometryCollection 中的几何图形
+func geomRank(g geom.T) int {
+	switch g.(type) {
+	case *geom.Point:
+		return 0
+	case *geom.MultiPoint:
+		return 1
+	case *geom.LineString:
+		return 2
+	case *geom.LinearRing:
+		return 3
+	case *geom.MultiLineString:
+		return 4
+	case *geom.Polygon:
+		return 5
+	case *geom.MultiPolygon:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// compareGeoms 函数比较两个规范化之后的几何图形，返回-1、0或1
+func compareGeoms(g1, g2 geom.T) int {
+	if c := compareInts(geomRank(g1), geomRank(g2)); c != 0 {
+		return c
+	}
+	gc1, ok1 := g1.(*geom.GeometryCollection)
+	gc2, ok2 := g2.(*geom.GeometryCollection)
+	if ok1 && ok2 {
+		for i := 0; i < gc1.NumGeoms() && i < gc2.NumGeoms(); i++ {
+			if c := compareGeoms(gc1.Geom(i), gc2.Geom(i)); c != 0 {
+				return c
+			}
+		}
+		return compareInts(gc1.NumGeoms(), gc2.NumGeoms())
+	}
+	return compareFlatCoords(g1.FlatCoords(), g2.FlatCoords())
+}
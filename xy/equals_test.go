@@ -0,0 +1,91 @@
+package xy_test
+
+import (
+	"testing"
+
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+)
+
+func TestEqualsTopo(t *testing.T) {
+	for i, tc := range []struct {
+		g1, g2 string
+		want   bool
+	}{
+		{
+			g1:   "POINT (1 2)",
+			g2:   "POINT (1 2)",
+			want: true,
+		},
+		{
+			g1:   "LINESTRING (0 0, 1 1, 2 0)",
+			g2:   "LINESTRING (2 0, 1 1, 0 0)",
+			want: true,
+		},
+		{
+			g1:   "LINESTRING (0 0, 1 1, 1 1, 2 0)",
+			g2:   "LINESTRING (0 0, 1 1, 2 0)",
+			want: true,
+		},
+		{
+			g1:   "LINESTRING (0 0, 1 1, 2 0)",
+			g2:   "LINESTRING (0 0, 1 1, 2 1)",
+			want: false,
+		},
+		{
+			g1:   "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			g2:   "POLYGON ((10 10, 10 0, 0 0, 0 10, 10 10))",
+			want: true,
+		},
+		{
+			g1:   "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 1), (5 5, 6 5, 6 6, 5 5))",
+			g2:   "POLYGON ((0 10, 10 10, 10 0, 0 0, 0 10), (6 6, 5 5, 6 5, 6 6), (2 2, 1 1, 2 1, 2 2))",
+			want: true,
+		},
+		{
+			g1:   "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			g2:   "POLYGON ((0 0, 10 0, 10 10, 0 0))",
+			want: false,
+		},
+		{
+			g1:   "MULTIPOINT ((1 1), (2 2), (1 1))",
+			g2:   "MULTIPOINT ((2 2), (1 1))",
+			want: true,
+		},
+		{
+			g1:   "MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))",
+			g2:   "MULTILINESTRING ((3 3, 2 2), (1 1, 0 0))",
+			want: true,
+		},
+		{
+			g1:   "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))",
+			g2:   "MULTIPOLYGON (((6 6, 5 5, 6 5, 6 6)), ((1 1, 0 0, 1 0, 1 1)))",
+			want: true,
+		},
+		{
+			g1:   "GEOMETRYCOLLECTION (POINT (1 1), LINESTRING (0 0, 1 1))",
+			g2:   "GEOMETRYCOLLECTION (LINESTRING (1 1, 0 0), POINT (1 1))",
+			want: true,
+		},
+		{
+			g1:   "POINT (1 1)",
+			g2:   "MULTIPOINT ((1 1))",
+			want: false,
+		},
+	} {
+		g1, err := wkt.Unmarshal(tc.g1)
+		if err != nil {
+			t.Fatalf("%d: wkt.Unmarshal(%q) == _, %v", i, tc.g1, err)
+		}
+		g2, err := wkt.Unmarshal(tc.g2)
+		if err != nil {
+			t.Fatalf("%d: wkt.Unmarshal(%q) == _, %v", i, tc.g2, err)
+		}
+		if got := xy.EqualsTopo(g1, g2); got != tc.want {
+			t.Errorf("%d: xy.EqualsTopo(%s, %s) == %v, want %v", i, tc.g1, tc.g2, got, tc.want)
+		}
+		if got := xy.EqualsTopo(g2, g1); got != tc.want {
+			t.Errorf("%d: xy.EqualsTopo(%s, %s) == %v, want %v", i, tc.g2, tc.g1, got, tc.want)
+		}
+	}
+}
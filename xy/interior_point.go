@@ -0,0 +1,218 @@
+package xy
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// InteriorPoint函数 返回一个位于几何图形内部的点，只包含 X 和 Y 坐标。与 Centroid 不同，返回的点一定在几何图形上，适合用于标注的位置。
+// 只使用维度最高的组成部分：
+// 面状几何图形使用扫描线算法，在 Y 方向的中间位置（避开所有外环顶点的 Y 坐标）作水平线，返回水平线与多边形相交的最宽的区间的中点，
+// 多边形的面积为0时返回外环的第一个顶点；
+// 线状几何图形返回距离质心最近的内部顶点，没有内部顶点时返回距离质心最近的端点；
+// 点状几何图形返回距离质心最近的点。
+// GeometryCollection 中的所有几何图形一起参与计算。几何图形为空时返回 nil，不支持的类型返回 geom.ErrUnsupportedType
+func InteriorPoint(g geom.T) (geom.Coord, error) {
+	var parts interiorPointParts
+	if err := parts.add(g); err != nil {
+		return nil, err
+	}
+	return parts.interiorPoint(), nil
+}
+
+// interiorPointParts 按照维度收集几何图形中非空的组成部分，线的坐标只包含 X 和 Y
+type interiorPointParts struct {
+	polygons []*geom.Polygon
+	lines    [][]geom.Coord
+	points   []geom.Coord
+}
+
+// interiorPoint 方法返回维度最高的组成部分的内部点，没有组成部分时返回 nil
+func (parts *interiorPointParts) interiorPoint() geom.Coord {
+	switch {
+	case len(parts.polygons) > 0:
+		return interiorPointArea(parts.polygons)
+	case len(parts.lines) > 0:
+		return interiorPointLine(parts.lines)
+	case len(parts.points) > 0:
+		return nearestCoord(parts.points, pointsCentroidXY(parts.points))
+	default:
+		return nil
+	}
+}
+
+func (parts *interiorPointParts) add(g geom.T) error {
+	switch g := g.(type) {
+	case *geom.Point:
+		if len(g.FlatCoords()) > 0 {
+			parts.points = append(parts.points, geom.Coord{g.X(), g.Y()})
+		}
+	case *geom.MultiPoint:
+		for i := 0; i < g.NumPoints(); i++ {
+			if err := parts.add(g.Point(i)); err != nil {
+				return err
+			}
+		}
+	case *geom.LineString:
+		parts.addLine(g.FlatCoords(), g.Stride())
+	case *geom.LinearRing:
+		parts.addLine(g.FlatCoords(), g.Stride())
+	case *geom.MultiLineString:
+		for i := 0; i < g.NumLineStrings(); i++ {
+			if err := parts.add(g.LineString(i)); err != nil {
+				return err
+			}
+		}
+	case *geom.Polygon:
+		if g.NumLinearRings() > 0 && g.Ends()[0] > 0 {
+			parts.polygons = append(parts.polygons, g)
+		}
+	case *geom.MultiPolygon:
+		for i := 0; i < g.NumPolygons(); i++ {
+			if err := parts.add(g.Polygon(i)); err != nil {
+				return err
+			}
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if err := parts.add(child); err != nil {
+				return err
+			}
+		}
+	default:
+		return geom.ErrUnsupportedType{Value: g}
+	}
+	return nil
+}
+
+func (parts *interiorPointParts) addLine(flatCoords []float64, stride int) {
+	if len(flatCoords) == 0 {
+		return
+	}
+	line := make([]geom.Coord, 0, len(flatCoords)/stride)
+	for i := 0; i < len(flatCoords); i += stride {
+		line = append(line, geom.Coord{flatCoords[i], flatCoords[i+1]})
+	}
+	parts.lines = append(parts.lines, line)
+}
+
+// interiorPointArea 函数返回所有多边形中与扫描线相交的最宽的区间的中点
+func interiorPointArea(polygons []*geom.Polygon) geom.Coord {
+	var result geom.Coord
+	maxWidth := -1.0
+	for _, p := range polygons {
+		if c, width := polygonInteriorPoint(p); width > maxWidth {
+			result, maxWidth = c, width
+		}
+	}
+	return result
+}
+
+// polygonInteriorPoint 函数返回扫描线与多边形相交的最宽的区间的中点和区间的宽度，
+// 多边形的面积为0时返回外环的第一个顶点和0
+func polygonInteriorPoint(p *geom.Polygon) (geom.Coord, float64) {
+	flatCoords, stride, ends := p.FlatCoords(), p.Stride(), p.Ends()
+	y := scanLineY(flatCoords[:ends[0]], stride)
+	var xs []float64
+	offset := 0
+	for _, end := range ends {
+		for i := offset; i+stride < end; i += stride {
+			x0, y0 := flatCoords[i], flatCoords[i+1]
+			x1, y1 := flatCoords[i+stride], flatCoords[i+stride+1]
+			// 半开区间的规则：Y 坐标等于扫描线的顶点视为在扫描线的下方，因此经过顶点的扫描线总是得到偶数个交点
+			if (y0 > y) != (y1 > y) {
+				xs = append(xs, x0+(y-y0)*(x1-x0)/(y1-y0))
+			}
+		}
+		offset = end
+	}
+	sort.Float64s(xs)
+	result := geom.Coord{flatCoords[0], flatCoords[1]}
+	maxWidth := 0.0
+	for i := 0; i+1 < len(xs); i += 2 {
+		if width := xs[i+1] - xs[i]; width > maxWidth {
+			result, maxWidth = geom.Coord{(xs[i] + xs[i+1]) / 2, y}, width
+		}
+	}
+	return result, maxWidth
+}
+
+// scanLineY 函数返回扫描线的 Y 坐标：线环的 Y 范围的中心两侧最近的两个顶点的 Y 坐标的平均值，
+// 因此扫描线不经过外环的任何顶点
+func scanLineY(ring []float64, stride int) float64 {
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for i := 0; i < len(ring); i += stride {
+		minY, maxY = math.Min(minY, ring[i+1]), math.Max(maxY, ring[i+1])
+	}
+	centre := (minY + maxY) / 2
+	lo, hi := minY, maxY
+	for i := 0; i < len(ring); i += stride {
+		switch y := ring[i+1]; {
+		case y <= centre && y > lo:
+			lo = y
+		case y > centre && y < hi:
+			hi = y
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// interiorPointLine 函数返回距离线的质心最近的内部顶点，没有内部顶点时返回距离质心最近的端点
+func interiorPointLine(lines [][]geom.Coord) geom.Coord {
+	centroid := linesCentroidXY(lines)
+	var interior, endpoints []geom.Coord
+	for _, line := range lines {
+		if len(line) > 2 {
+			interior = append(interior, line[1:len(line)-1]...)
+		}
+		endpoints = append(endpoints, line[0], line[len(line)-1])
+	}
+	if len(interior) > 0 {
+		return nearestCoord(interior, centroid)
+	}
+	return nearestCoord(endpoints, centroid)
+}
+
+// linesCentroidXY 函数返回以长度为权重的线段中点的平均值，线的长度为0时返回所有顶点的平均值
+func linesCentroidXY(lines [][]geom.Coord) geom.Coord {
+	var sumX, sumY, total float64
+	var points []geom.Coord
+	for _, line := range lines {
+		points = append(points, line...)
+		for i := 1; i < len(line); i++ {
+			a, b := line[i-1], line[i]
+			length := math.Hypot(b[0]-a[0], b[1]-a[1])
+			sumX += length * (a[0] + b[0]) / 2
+			sumY += length * (a[1] + b[1]) / 2
+			total += length
+		}
+	}
+	if total == 0 {
+		return pointsCentroidXY(points)
+	}
+	return geom.Coord{sumX / total, sumY / total}
+}
+
+// pointsCentroidXY 函数返回所有点的平均值
+func pointsCentroidXY(points []geom.Coord) geom.Coord {
+	var sumX, sumY float64
+	for _, p := range points {
+		sumX += p[0]
+		sumY += p[1]
+	}
+	return geom.Coord{sumX / float64(len(points)), sumY / float64(len(points))}
+}
+
+// nearestCoord 函数返回 coords 中距离 p 最近的坐标，距离相同时返回第一个
+func nearestCoord(coords []geom.Coord, p geom.Coord) geom.Coord {
+	var result geom.Coord
+	minDist := math.Inf(1)
+	for _, c := range coords {
+		if d := (c[0]-p[0])*(c[0]-p[0]) + (c[1]-p[1])*(c[1]-p[1]); d < minDist {
+			result, minDist = c, d
+		}
+	}
+	return geom.Coord{result[0], result[1]}
+}
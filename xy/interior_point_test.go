@@ -0,0 +1,237 @@
+package xy_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+func TestInteriorPoint(t *testing.T) {
+	for i, tc := range []struct {
+		desc string
+		g    string
+		want geom.Coord
+	}{
+		{
+			desc: "point",
+			g:    "POINT Z (1 2 3)",
+			want: geom.Coord{1, 2},
+		},
+		{
+			desc: "multipoint",
+			g:    "MULTIPOINT (0 0, 10 0, 4 1, 20 20)",
+			want: geom.Coord{10, 0},
+		},
+		{
+			desc: "linestring",
+			g:    "LINESTRING (0 0, 1 0, 2 0, 10 0)",
+			want: geom.Coord{2, 0},
+		},
+		{
+			desc: "segment",
+			g:    "LINESTRING (0 0, 10 0)",
+			want: geom.Coord{0, 0},
+		},
+		{
+			desc: "square",
+			g:    "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))",
+			want: geom.Coord{2, 2},
+		},
+		{
+			desc: "U shape",
+			g:    "POLYGON ((0 0, 10 0, 10 10, 8 10, 8 2, 2 2, 2 10, 0 10, 0 0))",
+			want: geom.Coord{1, 6},
+		},
+		{
+			desc: "hole",
+			g:    "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 8 1, 8 9, 1 9, 1 1))",
+			want: geom.Coord{9, 5},
+		},
+		{
+			desc: "multipolygon",
+			g:    "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((10 0, 14 0, 14 4, 10 4, 10 0)))",
+			want: geom.Coord{12, 2},
+		},
+		{
+			desc: "collection uses highest dimension",
+			g:    "GEOMETRYCOLLECTION (POINT (100 100), LINESTRING (0 0, 50 50), POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0)))",
+			want: geom.Coord{1, 1},
+		},
+		{
+			desc: "zero area polygon",
+			g:    "POLYGON ((1 1, 2 1, 3 1, 1 1))",
+			want: geom.Coord{1, 1},
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.g)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.g, err)
+		}
+		if got, err := xy.InteriorPoint(g); err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%d: %s: InteriorPoint(...) == %v, %v, want %v, nil", i, tc.desc, got, err, tc.want)
+		}
+	}
+}
+
+func TestInteriorPointEmpty(t *testing.T) {
+	for _, g := range []geom.T{
+		geom.NewGeometryCollection(),
+		geom.NewMultiPoint(geom.XY),
+		geom.NewLineString(geom.XY),
+		geom.NewPolygon(geom.XY),
+	} {
+		if got, err := xy.InteriorPoint(g); err != nil || got != nil {
+			t.Errorf("InteriorPoint(%v) == %v, %v, want nil, nil", g, got, err)
+		}
+	}
+}
+
+func TestInteriorPointUnsupportedType(t *testing.T) {
+	gc := geom.NewGeometryCollection().MustPush(geom.NewPointFlat(geom.XY, []float64{1, 2}), nil)
+	want := geom.ErrUnsupportedType{Value: nil}
+	if _, err := xy.InteriorPoint(nil); !reflect.DeepEqual(err, want) {
+		t.Errorf("InteriorPoint(nil) == _, %v, want _, %v", err, want)
+	}
+	if _, _, err := xy.Polylabel(gc, 0); !reflect.DeepEqual(err, want) {
+		t.Errorf("Polylabel(%v, 0) == _, _, %v, want _, _, %v", gc, err, want)
+	}
+}
+
+func TestInteriorPointInside(t *testing.T) {
+	// 质心在多边形之外的凹多边形和带洞的多边形
+	for _, s := range []string{
+		"POLYGON ((0 0, 10 0, 10 1, 1 1, 1 10, 0 10, 0 0))",
+		"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 9 1, 9 9, 1 9, 1 1))",
+		"POLYGON ((0 0, 5 5, 10 0, 10 10, 0 10, 0 0), (2 6, 8 6, 8 9, 2 9, 2 6))",
+		"MULTIPOLYGON (((0 0, 10 0, 10 1, 1 1, 1 10, 0 10, 0 0)), ((20 20, 21 20, 21 21, 20 21, 20 20)))",
+	} {
+		g, err := wkt.Unmarshal(s)
+		if err != nil {
+			t.Fatalf("wkt.Unmarshal(%q) == _, %v, want _, nil", s, err)
+		}
+		var mp *geom.MultiPolygon
+		switch g := g.(type) {
+		case *geom.Polygon:
+			mp = geom.NewMultiPolygon(g.Layout())
+			if err := mp.Push(g); err != nil {
+				t.Fatal(err)
+			}
+		case *geom.MultiPolygon:
+			mp = g
+		}
+		p, err := xy.InteriorPoint(g)
+		if err != nil {
+			t.Fatalf("InteriorPoint(%s) == _, %v, want _, nil", s, err)
+		}
+		if got := xy.LocatePointInMultiPolygon(mp, p); got != location.Interior {
+			t.Errorf("InteriorPoint(%s) == %v, which is %v, want interior", s, p, got)
+		}
+		p, d, err := xy.Polylabel(g, 1e-3)
+		if err != nil {
+			t.Fatalf("Polylabel(%s, 1e-3) == _, _, %v, want _, _, nil", s, err)
+		}
+		if got := xy.LocatePointInMultiPolygon(mp, p); got != location.Interior || d <= 0 {
+			t.Errorf("Polylabel(%s, 1e-3) == %v, %v, which is %v, want interior", s, p, d, got)
+		}
+	}
+}
+
+func TestPolylabel(t *testing.T) {
+	for i, tc := range []struct {
+		desc      string
+		g         string
+		precision float64
+		want      geom.Coord
+		distance  float64
+	}{
+		{
+			desc:      "square",
+			g:         "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))",
+			precision: 1e-3,
+			want:      geom.Coord{2, 2},
+			distance:  2,
+		},
+		{
+			desc:      "rectangle",
+			g:         "POLYGON ((0 0, 10 0, 10 2, 0 2, 0 0))",
+			precision: 1e-3,
+			want:      geom.Coord{5, 1},
+			distance:  1,
+		},
+		{
+			// 不可达极点在较宽的下部，而不是扫描线经过的狭长的上部
+			desc:      "L shape",
+			g:         "POLYGON ((0 0, 10 0, 10 4, 1 4, 1 20, 0 20, 0 0))",
+			precision: 1e-3,
+			distance:  2,
+		},
+		{
+			desc:      "hole",
+			g:         "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 9 1, 9 9, 1 9, 1 1))",
+			precision: 1e-3,
+			// 不可达极点在外环与洞的角之间，到外环的两条边和洞的顶点的距离相等
+			distance: 2 - math.Sqrt2,
+		},
+		{
+			desc:      "multipolygon",
+			g:         "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((10 0, 16 0, 16 6, 10 6, 10 0)))",
+			precision: 1e-3,
+			want:      geom.Coord{13, 3},
+			distance:  3,
+		},
+		{
+			desc:      "line",
+			g:         "LINESTRING (0 0, 1 0, 2 0, 10 0)",
+			precision: 1,
+			want:      geom.Coord{2, 0},
+			distance:  0,
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.g)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.g, err)
+		}
+		got, distance, err := xy.Polylabel(g, tc.precision)
+		if err != nil {
+			t.Fatalf("%d: %s: Polylabel(...) == _, _, %v, want _, _, nil", i, tc.desc, err)
+		}
+		if tc.want != nil && math.Hypot(got[0]-tc.want[0], got[1]-tc.want[1]) > 2*tc.precision {
+			t.Errorf("%d: %s: Polylabel(...) == %v, _, want %v, _", i, tc.desc, got, tc.want)
+		}
+		if math.Abs(distance-tc.distance) > tc.precision {
+			t.Errorf("%d: %s: Polylabel(...) == _, %v, want _, %v", i, tc.desc, distance, tc.distance)
+		}
+		if d, err := xy.DistanceBetween(geom.NewPointFlat(geom.XY, got), boundaryOf(t, g)); err != nil || math.Abs(d-distance) > 1e-9 {
+			t.Errorf("%d: %s: distance from Polylabel(...) to boundary == %v, want %v", i, tc.desc, d, distance)
+		}
+	}
+}
+
+// boundaryOf 函数返回面状几何图形的所有线环组成的 MultiLineString，其他几何图形原样返回
+func boundaryOf(t *testing.T, g geom.T) geom.T {
+	var polygons []*geom.Polygon
+	switch g := g.(type) {
+	case *geom.Polygon:
+		polygons = append(polygons, g)
+	case *geom.MultiPolygon:
+		for i := 0; i < g.NumPolygons(); i++ {
+			polygons = append(polygons, g.Polygon(i))
+		}
+	default:
+		return g
+	}
+	mls := geom.NewMultiLineString(geom.XY)
+	for _, p := range polygons {
+		for i := 0; i < p.NumLinearRings(); i++ {
+			if err := mls.Push(geom.NewLineStringFlat(geom.XY, p.LinearRing(i).FlatCoords())); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return mls
+}
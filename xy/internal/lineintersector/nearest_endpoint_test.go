@@ -0,0 +1,66 @@
+package lineintersector
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+func TestNearestEndpoint(t *testing.T) {
+	for i, tc := range []struct {
+		line1Start, line1End, line2Start, line2End geom.Coord
+		result                                     geom.Coord
+	}{
+		{
+			line1Start: geom.Coord{0, 0}, line1End: geom.Coord{10, 0},
+			line2Start: geom.Coord{5, 2}, line2End: geom.Coord{5, 0.5},
+			result: geom.Coord{5, 0.5},
+		},
+		{
+			line1Start: geom.Coord{0, 1}, line1End: geom.Coord{10, 0},
+			line2Start: geom.Coord{-5, 5}, line2End: geom.Coord{20, 5},
+			result: geom.Coord{0, 1},
+		},
+		{
+			line1Start: geom.Coord{0, 0}, line1End: geom.Coord{10, 0.25},
+			line2Start: geom.Coord{0, 2}, line2End: geom.Coord{10, 0.5},
+			result: geom.Coord{10, 0.25},
+		},
+		{
+			// 距离相等时返回第一个端点
+			line1Start: geom.Coord{0, 0}, line1End: geom.Coord{10, 0},
+			line2Start: geom.Coord{0, 1}, line2End: geom.Coord{10, 1},
+			result: geom.Coord{0, 0},
+		},
+		{
+			// 只返回 X 和 Y 坐标
+			line1Start: geom.Coord{0, 0, 7}, line1End: geom.Coord{10, 0, 7},
+			line2Start: geom.Coord{3, 4, 7}, line2End: geom.Coord{3, 9, 7},
+			result: geom.Coord{3, 4},
+		},
+	} {
+		if got := nearestEndpoint(tc.line1Start, tc.line1End, tc.line2Start, tc.line2End); !reflect.DeepEqual(got, tc.result) {
+			t.Errorf("%d: nearestEndpoint(...) == %v, want %v", i, got, tc.result)
+		}
+	}
+}
+
+func TestDistanceFromPointToLine(t *testing.T) {
+	for i, tc := range []struct {
+		p, lineStart, lineEnd geom.Coord
+		result                float64
+	}{
+		{p: geom.Coord{5, 3}, lineStart: geom.Coord{0, 0}, lineEnd: geom.Coord{10, 0}, result: 3},
+		{p: geom.Coord{-3, 4}, lineStart: geom.Coord{0, 0}, lineEnd: geom.Coord{10, 0}, result: 5},
+		{p: geom.Coord{13, -4}, lineStart: geom.Coord{0, 0}, lineEnd: geom.Coord{10, 0}, result: 5},
+		{p: geom.Coord{0, 2}, lineStart: geom.Coord{0, 0}, lineEnd: geom.Coord{2, 2}, result: math.Sqrt2},
+		{p: geom.Coord{4, 4}, lineStart: geom.Coord{1, 1}, lineEnd: geom.Coord{1, 1}, result: math.Sqrt(18)},
+		{p: geom.Coord{5, 0}, lineStart: geom.Coord{0, 0}, lineEnd: geom.Coord{10, 0}, result: 0},
+	} {
+		if got := distanceFromPointToLine(tc.p, tc.lineStart, tc.lineEnd); math.Abs(got-tc.result) > 1e-12 {
+			t.Errorf("%d: distanceFromPointToLine(%v, %v, %v) == %v, want %v", i, tc.p, tc.lineStart, tc.lineEnd, got, tc.result)
+		}
+	}
+}
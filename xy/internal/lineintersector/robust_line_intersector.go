@@ -1,6 +1,8 @@
 package lineintersector
 
 import (
+	"math"
+
 	"github.com/chengxiaoer/geomGo"
 	"github.com/chengxiaoer/geomGo/bigxy"
 	"github.com/chengxiaoer/geomGo/xy/internal"
@@ -191,7 +193,7 @@ func intersection(data *lineIntersectorData, line1Start, line1End, line2Start, l
 	 * 这段代码检查这种情况，并迫使一个更合理的答案。
 	 */
 	if !isInSegmentEnvelopes(data, intPt) {
-		intPt = centralendpoint.GetIntersection(line1Start, line1End, line2Start, line2End)
+		intPt = nearestEndpoint(line1Start, line1End, line2Start, line2End)
 	}
 
 	// TODO Enable if we add a precision model
@@ -233,6 +235,40 @@ func safeHCoordinateIntersection(line1Start, line1End, line2Start, line2End geom
 	return intPt
 }
 
+// nearestEndpoint 函数返回与另一条线段距离最近的端点。
+// 交点由于舍入位于线段的范围之外时，线段几乎平行或者交点非常接近某个端点，此时该端点是最合理的近似值
+func nearestEndpoint(line1Start, line1End, line2Start, line2End geom.Coord) geom.Coord {
+	nearest := line1Start
+	minDist := distanceFromPointToLine(line1Start, line2Start, line2End)
+	for _, c := range [...][3]geom.Coord{
+		{line1End, line2Start, line2End},
+		{line2Start, line1Start, line1End},
+		{line2End, line1Start, line1End},
+	} {
+		if dist := distanceFromPointToLine(c[0], c[1], c[2]); dist < minDist {
+			nearest, minDist = c[0], dist
+		}
+	}
+	return geom.Coord{nearest[0], nearest[1]}
+}
+
+// distanceFromPointToLine 计算点到线段的距离
+func distanceFromPointToLine(p, lineStart, lineEnd geom.Coord) float64 {
+	dx, dy := lineEnd[0]-lineStart[0], lineEnd[1]-lineStart[1]
+	len2 := dx*dx + dy*dy
+	if len2 == 0 {
+		return internal.Distance2D(p, lineStart)
+	}
+	r := ((p[0]-lineStart[0])*dx + (p[1]-lineStart[1])*dy) / len2
+	switch {
+	case r <= 0:
+		return internal.Distance2D(p, lineStart)
+	case r >= 1:
+		return internal.Distance2D(p, lineEnd)
+	}
+	return math.Abs((lineStart[1]-p[1])*dx-(lineStart[0]-p[0])*dy) / math.Sqrt(len2)
+}
+
 /*
  * 测试一个点是否位于输入的线段中。
  * 在测试过程中，正确计算的交点应当返回 <code>true</code>
@@ -0,0 +1,237 @@
+// Package noding 在线段的所有交点处分割线段，使分割之后的线段只在端点处相交。
+// 交点使用强健的线段求交算法计算，并吸附到非常接近的顶点上，因此结果可以用于构造平面图
+package noding
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/internal/lineintersector"
+)
+
+// snapTolerance 是交点吸附到线段端点的相对距离。计算出的交点与端点非常接近时使用端点代替交点，避免产生极短的边
+const snapTolerance = 1e-10
+
+// maxNodingIterations 是分割线段的最大迭代次数。交点经过舍入之后，分割出的线段可能与其他线段产生新的交点，因此需要迭代直到没有新的交点
+const maxNodingIterations = 5
+
+// segment 是需要分割的线段，owner 为线段所属的原始线段的序号
+type segment struct {
+	p0, p1     geom.Coord
+	owner      int
+	minX, maxX float64
+	minY, maxY float64
+	splits     []geom.Coord
+}
+
+func newSegment(p0, p1 geom.Coord, owner int) *segment {
+	return &segment{
+		p0: p0, p1: p1,
+		owner: owner,
+		minX:  math.Min(p0[0], p1[0]), maxX: math.Max(p0[0], p1[0]),
+		minY: math.Min(p0[1], p1[1]), maxY: math.Max(p0[1], p1[1]),
+	}
+}
+
+// addSplit 方法记录线段内部的一个交点
+func (s *segment) addSplit(c geom.Coord) bool {
+	if equal2D(c, s.p0) || equal2D(c, s.p1) || equal2D(s.p0, s.p1) {
+		return false
+	}
+	for _, split := range s.splits {
+		if equal2D(c, split) {
+			return false
+		}
+	}
+	s.splits = append(s.splits, geom.Coord{c[0], c[1]})
+	return true
+}
+
+// nodeIndex 记录已经出现的结点。不同的线段对计算出的同一个交点可能因为舍入误差而略有不同，
+// 新的交点与已有的结点非常接近时使用已有的结点，避免产生极短的边。
+// 结点按照网格存储，网格远大于吸附的范围，因此查找通常只需要访问一个网格
+type nodeIndex struct {
+	tolerance, cell float64
+	cells           map[[2]int64][]geom.Coord
+}
+
+func newNodeIndex(lines [][2]geom.Coord) *nodeIndex {
+	scale := 1.0
+	for _, l := range lines {
+		for _, c := range l {
+			scale = math.Max(scale, math.Max(math.Abs(c[0]), math.Abs(c[1])))
+		}
+	}
+	tolerance := snapTolerance * scale
+	n := &nodeIndex{tolerance: tolerance, cell: 1024 * tolerance, cells: make(map[[2]int64][]geom.Coord)}
+	for _, l := range lines {
+		n.snap(l[0])
+		n.snap(l[1])
+	}
+	return n
+}
+
+// snap 方法返回与 c 的距离在吸附范围内的最近的已有结点，没有这样的结点时记录并返回 c
+func (n *nodeIndex) snap(c geom.Coord) geom.Coord {
+	var nearest geom.Coord
+	best := n.tolerance * n.tolerance
+	minX, maxX := n.index(c[0]-n.tolerance), n.index(c[0]+n.tolerance)
+	minY, maxY := n.index(c[1]-n.tolerance), n.index(c[1]+n.tolerance)
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			for _, node := range n.cells[[2]int64{x, y}] {
+				if d := distance2(c, node); d <= best {
+					nearest, best = node, d
+				}
+			}
+		}
+	}
+	if nearest != nil {
+		return nearest
+	}
+	key := [2]int64{n.index(c[0]), n.index(c[1])}
+	n.cells[key] = append(n.cells[key], c)
+	return c
+}
+
+func (n *nodeIndex) index(x float64) int64 {
+	return int64(math.Floor(x / n.cell))
+}
+
+// Node函数 在所有的交点处分割线段，返回每条线段分割之后的点序列（包括两个端点）
+func Node(lines [][2]geom.Coord) [][]geom.Coord {
+	lines = snapVertices(lines)
+	nodes := newNodeIndex(lines)
+	paths := make([][]geom.Coord, len(lines))
+	for i, l := range lines {
+		if equal2D(l[0], l[1]) {
+			paths[i] = []geom.Coord{l[0]}
+			continue
+		}
+		paths[i] = []geom.Coord{l[0], l[1]}
+	}
+	for i := 0; i < maxNodingIterations; i++ {
+		var segs []*segment
+		for owner, path := range paths {
+			// 退化为一个点的线段不会被分割，但是其他线段需要在该点处分割
+			if len(path) == 1 {
+				segs = append(segs, newSegment(path[0], path[0], owner))
+			}
+			for j := 1; j < len(path); j++ {
+				segs = append(segs, newSegment(path[j-1], path[j], owner))
+			}
+		}
+		if !split(segs, nodes) {
+			break
+		}
+		for owner := range paths {
+			paths[owner] = paths[owner][:1]
+		}
+		for _, s := range segs {
+			if equal2D(s.p0, s.p1) {
+				continue
+			}
+			sort.Slice(s.splits, func(i, j int) bool {
+				return distance2(s.p0, s.splits[i]) < distance2(s.p0, s.splits[j])
+			})
+			paths[s.owner] = append(paths[s.owner], s.splits...)
+			paths[s.owner] = append(paths[s.owner], s.p1)
+		}
+	}
+	return paths
+}
+
+// snapVertices 函数将非常接近的顶点合并为同一个顶点，避免产生极短的边
+func snapVertices(lines [][2]geom.Coord) [][2]geom.Coord {
+	var vertices []geom.Coord
+	for _, l := range lines {
+		vertices = append(vertices, l[0], l[1])
+	}
+	sort.Slice(vertices, func(i, j int) bool { return vertices[i][0] < vertices[j][0] })
+	snapped := make(map[[2]float64]geom.Coord)
+	for i, v := range vertices {
+		key := [2]float64{v[0], v[1]}
+		if _, ok := snapped[key]; ok {
+			continue
+		}
+		snapped[key] = v
+		tolerance := snapTolerance * math.Max(math.Max(math.Abs(v[0]), math.Abs(v[1])), 1)
+		for _, w := range vertices[i+1:] {
+			if w[0]-v[0] > tolerance {
+				break
+			}
+			if k := [2]float64{w[0], w[1]}; snapped[k] == nil && distance2(v, w) <= tolerance*tolerance {
+				snapped[k] = v
+			}
+		}
+	}
+	result := make([][2]geom.Coord, len(lines))
+	for i, l := range lines {
+		result[i] = [2]geom.Coord{snapped[[2]float64{l[0][0], l[0][1]}], snapped[[2]float64{l[1][0], l[1][1]}]}
+	}
+	return result
+}
+
+// split 函数使用扫描线算法计算线段之间的交点，有新的交点时返回 true
+func split(segs []*segment, nodes *nodeIndex) bool {
+	sorted := make([]*segment, len(segs))
+	copy(sorted, segs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].minX < sorted[j].minX })
+	found := false
+	for i, a := range sorted {
+		for _, b := range sorted[i+1:] {
+			if b.minX > a.maxX {
+				break
+			}
+			if b.minY > a.maxY || a.minY > b.maxY {
+				continue
+			}
+			// 端点非常接近另一条线段时在该端点处分割另一条线段
+			for _, pair := range [...][2]*segment{{a, b}, {b, a}} {
+				for _, p := range [...]geom.Coord{pair[0].p0, pair[0].p1} {
+					if isNear(p, pair[1]) && pair[1].addSplit(p) {
+						found = true
+					}
+				}
+			}
+			result := lineintersector.LineIntersectsLine(lineintersector.RobustLineIntersector{}, a.p0, a.p1, b.p0, b.p1)
+			for _, c := range result.Intersection() {
+				c = nodes.snap(snap(c, a, b))
+				if a.addSplit(c) {
+					found = true
+				}
+				if b.addSplit(c) {
+					found = true
+				}
+			}
+		}
+	}
+	return found
+}
+
+// snap 函数返回与交点 c 非常接近的线段端点，没有这样的端点时返回 c
+func snap(c geom.Coord, a, b *segment) geom.Coord {
+	tolerance := snapTolerance * math.Max(math.Max(math.Abs(c[0]), math.Abs(c[1])), 1)
+	for _, p := range [...]geom.Coord{a.p0, a.p1, b.p0, b.p1} {
+		if distance2(c, p) <= tolerance*tolerance {
+			return p
+		}
+	}
+	return c
+}
+
+// isNear 函数检测点 c 与线段 s 的距离是否在吸附的范围内
+func isNear(c geom.Coord, s *segment) bool {
+	return xy.DistanceFromPointToLine(c, s.p0, s.p1) <= snapTolerance*math.Max(math.Max(math.Abs(c[0]), math.Abs(c[1])), 1)
+}
+
+func equal2D(c1, c2 geom.Coord) bool {
+	return c1[0] == c2[0] && c1[1] == c2[1]
+}
+
+func distance2(c1, c2 geom.Coord) float64 {
+	dx, dy := c2[0]-c1[0], c2[1]-c1[1]
+	return dx*dx + dy*dy
+}
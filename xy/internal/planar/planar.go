@@ -0,0 +1,77 @@
+// Package planar 包含了 overlay、relate、buffer 和 valid 包共用的坐标复制和点、边相对于多边形的位置的计算，
+// 所有的坐标都是 x、y 坐标
+package planar
+
+import (
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+// Polygon 是多边形的线环，第一个线环为外边界，每个线环都是闭合的 x、y 坐标数组
+type Polygon [][]float64
+
+// XYCoords函数 复制 x、y 坐标并去除连续的重复点
+func XYCoords(flatCoords []float64, offset, end, stride int) []float64 {
+	coords := make([]float64, 0, (end-offset)/stride*2)
+	for i := offset; i < end; i += stride {
+		if n := len(coords); n > 0 && coords[n-2] == flatCoords[i] && coords[n-1] == flatCoords[i+1] {
+			continue
+		}
+		coords = append(coords, flatCoords[i], flatCoords[i+1])
+	}
+	return coords
+}
+
+// Reverse函数 原地反转 x、y 坐标数组中点的顺序
+func Reverse(coords []float64) {
+	for i, j := 0, len(coords)-2; i < j; i, j = i+2, j-2 {
+		coords[i], coords[j] = coords[j], coords[i]
+		coords[i+1], coords[j+1] = coords[j+1], coords[i+1]
+	}
+}
+
+// LocateEdge函数 计算不在多边形边界上的边 p0-p1 相对于一组多边形的位置。依次检查边的中点和两个四分点，
+// 第一个不在边界上的点的位置就是边的位置，这些点都在边界上时返回 Exterior
+func LocateEdge(polygons []Polygon, p0, p1 geom.Coord) location.Type {
+	for _, f := range []float64{0.5, 0.25, 0.75} {
+		p := geom.Coord{p0[0] + f*(p1[0]-p0[0]), p0[1] + f*(p1[1]-p0[1])}
+		if loc := Locate(polygons, p); loc != location.Boundary {
+			return loc
+		}
+	}
+	return location.Exterior
+}
+
+// Locate函数 计算点相对于一组多边形的位置
+func Locate(polygons []Polygon, p geom.Coord) location.Type {
+	result := location.Exterior
+	for _, rings := range polygons {
+		switch LocateInPolygon(rings, p) {
+		case location.Interior:
+			return location.Interior
+		case location.Boundary:
+			result = location.Boundary
+		}
+	}
+	return result
+}
+
+// LocateInPolygon函数 计算点相对于多边形的位置，没有线环的多边形是空的
+func LocateInPolygon(rings Polygon, p geom.Coord) location.Type {
+	if len(rings) == 0 {
+		return location.Exterior
+	}
+	if loc := xy.LocatePointInRing(geom.XY, p, rings[0]); loc != location.Interior {
+		return loc
+	}
+	for _, hole := range rings[1:] {
+		switch xy.LocatePointInRing(geom.XY, p, hole) {
+		case location.Interior:
+			return location.Exterior
+		case location.Boundary:
+			return location.Boundary
+		}
+	}
+	return location.Interior
+}
@@ -0,0 +1,213 @@
+// Package polygonize 将平面图中的有向边连接为线环并组装为多边形，供 overlay 和 buffer 包使用。
+//
+// 有向边的左侧是结果的内部，边只在端点处相交。逆时针方向的线环为外边界，顺时针方向的线环为洞
+package polygonize
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/bigxy"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/location"
+	"github.com/chengxiaoer/geomGo/xy/orientation"
+)
+
+// Edge 是结果中的有向边，结果的内部在边的左侧
+type Edge struct {
+	From, To geom.Coord
+	used     bool
+}
+
+type nodeKey [2]float64
+
+// BuildRings函数 将结果的有向边连接为线环，无法连接为闭合的线环时返回 false。
+// 到达一个结点时选择从来路的反方向开始顺时针方向的第一条出边，这样得到的是最小的面，经过同一个结点多次的线环在该结点处被拆分
+func BuildRings(edges []*Edge) ([][]float64, bool) {
+	outgoing := make(map[nodeKey][]*Edge)
+	for _, e := range edges {
+		key := nodeKey{e.From[0], e.From[1]}
+		outgoing[key] = append(outgoing[key], e)
+	}
+	for _, out := range outgoing {
+		sort.Slice(out, func(i, j int) bool {
+			return CompareDirection(out[i].From, out[i].To, out[j].To) < 0
+		})
+	}
+
+	var rings [][]float64
+	for _, start := range edges {
+		if start.used {
+			continue
+		}
+		var path []geom.Coord
+		for e := start; ; {
+			e.used = true
+			path = append(path, e.From)
+			next := nextEdge(outgoing[nodeKey{e.To[0], e.To[1]}], e)
+			if next == start {
+				break
+			}
+			if next == nil || next.used {
+				return nil, false
+			}
+			e = next
+		}
+		rings = append(rings, splitPath(path)...)
+	}
+	return rings, true
+}
+
+// nextEdge 函数返回从边 e 的反方向开始顺时针方向的第一条出边
+func nextEdge(out []*Edge, e *Edge) *Edge {
+	if len(out) == 0 {
+		return nil
+	}
+	next := out[len(out)-1]
+	for _, candidate := range out {
+		if CompareDirection(e.To, candidate.To, e.From) >= 0 {
+			break
+		}
+		next = candidate
+	}
+	return next
+}
+
+// CompareDirection函数 按照从 x 轴正方向开始的逆时针角度比较从 origin 出发指向 p 和 q 的两个方向，
+// 同一象限内的方向使用强健的 bigxy.OrientationIndex 比较
+func CompareDirection(origin, p, q geom.Coord) int {
+	qp, qq := quadrant(p[0]-origin[0], p[1]-origin[1]), quadrant(q[0]-origin[0], q[1]-origin[1])
+	switch {
+	case qp < qq:
+		return -1
+	case qp > qq:
+		return 1
+	}
+	switch bigxy.OrientationIndex(origin, p, q) {
+	case orientation.CounterClockwise:
+		return -1
+	case orientation.Clockwise:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func quadrant(dx, dy float64) int {
+	switch {
+	case dx >= 0 && dy >= 0:
+		return 0
+	case dx < 0 && dy >= 0:
+		return 1
+	case dx < 0:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// splitPath 函数在重复经过的结点处拆分闭合路径，返回闭合的线环
+func splitPath(path []geom.Coord) [][]float64 {
+	var rings [][]float64
+	var stack []geom.Coord
+	seen := make(map[nodeKey]int)
+	for _, c := range path {
+		key := nodeKey{c[0], c[1]}
+		if i, ok := seen[key]; ok {
+			rings = append(rings, closeRing(stack[i:]))
+			for _, popped := range stack[i+1:] {
+				delete(seen, nodeKey{popped[0], popped[1]})
+			}
+			stack = stack[:i+1]
+			continue
+		}
+		seen[key] = len(stack)
+		stack = append(stack, c)
+	}
+	return append(rings, closeRing(stack))
+}
+
+func closeRing(coords []geom.Coord) []float64 {
+	ring := make([]float64, 0, 2*len(coords)+2)
+	for _, c := range coords {
+		ring = append(ring, c[0], c[1])
+	}
+	return append(ring, coords[0][0], coords[0][1])
+}
+
+// Assemble函数 将线环组装为多边形，逆时针方向的线环为外边界，顺时针方向的线环为洞，洞属于包含它的最小的外边界。
+// 只有一个多边形时返回 *geom.Polygon，否则返回 *geom.MultiPolygon，没有多边形时返回空的 *geom.Polygon
+func Assemble(rings [][]float64, srid int) geom.T {
+	var shells, holes [][]float64
+	for _, ring := range rings {
+		switch area := xy.SignedArea(geom.XY, ring); {
+		case area < 0:
+			shells = append(shells, ring)
+		case area > 0:
+			holes = append(holes, ring)
+		}
+	}
+	polygons := make([][][]float64, len(shells))
+	for i, shell := range shells {
+		polygons[i] = [][]float64{shell}
+	}
+	for _, hole := range holes {
+		best, bestArea := -1, math.Inf(1)
+		for i, shell := range shells {
+			area := -xy.SignedArea(geom.XY, shell)
+			if area < bestArea && contains(shell, hole) {
+				best, bestArea = i, area
+			}
+		}
+		if best >= 0 {
+			polygons[best] = append(polygons[best], hole)
+		}
+	}
+	return newPolygonal(polygons, srid)
+}
+
+// contains 函数检测线环 inner 是否位于线环 outer 之内，两者只能在有限个点上接触
+func contains(outer, inner []float64) bool {
+	for i := 0; i < len(inner); i += 2 {
+		switch xy.LocatePointInRing(geom.XY, inner[i:i+2], outer) {
+		case location.Interior:
+			return true
+		case location.Exterior:
+			return false
+		}
+	}
+	for i := 2; i < len(inner); i += 2 {
+		switch xy.LocatePointInRing(geom.XY, midpoint(inner[i-2:i], inner[i:i+2]), outer) {
+		case location.Interior:
+			return true
+		case location.Exterior:
+			return false
+		}
+	}
+	return false
+}
+
+// newPolygonal 函数创建多边形，只有一个多边形时返回 *geom.Polygon，否则返回 *geom.MultiPolygon，没有多边形时返回空的 *geom.Polygon
+func newPolygonal(polygons [][][]float64, srid int) geom.T {
+	var flatCoords []float64
+	endss := make([][]int, len(polygons))
+	for i, rings := range polygons {
+		for _, ring := range rings {
+			flatCoords = append(flatCoords, ring...)
+			endss[i] = append(endss[i], len(flatCoords))
+		}
+	}
+	switch len(polygons) {
+	case 0:
+		return geom.NewPolygon(geom.XY).SetSRID(srid)
+	case 1:
+		return geom.NewPolygonFlat(geom.XY, flatCoords, endss[0]).SetSRID(srid)
+	default:
+		return geom.NewMultiPolygonFlat(geom.XY, flatCoords, endss).SetSRID(srid)
+	}
+}
+
+func midpoint(c1, c2 geom.Coord) geom.Coord {
+	return geom.Coord{(c1[0] + c2[0]) / 2, (c1[1] + c2[1]) / 2}
+}
@@ -0,0 +1,261 @@
+package polygonize
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/bigxy"
+	"github.com/chengxiaoer/geomGo/index/strtree"
+	"github.com/chengxiaoer/geomGo/xy/internal/noding"
+	"github.com/chengxiaoer/geomGo/xy/orientation"
+)
+
+// Sources 是可以添加到 WindingBuilder 的线环的来源的数目
+const Sources = 2
+
+// Winding 是一个点相对于每个来源的所有线环的环绕数之和
+type Winding [Sources]int
+
+// WindingBuilder 根据环绕数构造多边形。
+//
+// 线环可以自相交，也可以与其他线环相交或重合。逆时针方向的线环使其内部的环绕数加1，顺时针方向的减1，
+// 因此有效的多边形的外边界为逆时针方向、洞为顺时针方向时，其内部的环绕数为1，外部为0；
+// 多个线环的环绕数之和则是覆盖一个点的多边形的数目。所有线环只需要一次分割，耗时接近线段的数目的线性函数
+type WindingBuilder struct {
+	lines   [][2]geom.Coord
+	sources []int
+}
+
+// AddRing方法 添加一个闭合的 x、y 坐标数组表示的线环
+func (b *WindingBuilder) AddRing(source int, ring []float64) {
+	for i := 2; i < len(ring); i += 2 {
+		b.lines = append(b.lines, [2]geom.Coord{ring[i-2 : i], ring[i : i+2]})
+		b.sources = append(b.sources, source)
+	}
+}
+
+// windingEdge 是分割之后的一条边，delta 为边的左侧与右侧的环绕数之差
+type windingEdge struct {
+	p0, p1  geom.Coord
+	delta   Winding
+	forward *halfEdge
+}
+
+// halfEdge 是边的一个方向，winding 为其左侧的环绕数
+type halfEdge struct {
+	from, to geom.Coord
+	edge     *windingEdge
+	forward  bool
+	twin     *halfEdge
+	index    int
+	winding  Winding
+	labeled  bool
+}
+
+// component 是平面图的一个连通分量，leftmost 为其中 x 坐标最小（相同时 y 坐标最小）的结点
+type component struct {
+	edges    []*windingEdge
+	leftmost geom.Coord
+	bounds   *geom.Bounds
+}
+
+// Build方法 返回使 selects 返回 true 的环绕数的区域组成的多边形，无法确定每条边两侧的环绕数时返回 false
+func (b *WindingBuilder) Build(selects func(w Winding) bool, srid int) (geom.T, bool) {
+	paths := noding.Node(b.lines)
+	index := make(map[[4]float64]*windingEdge)
+	var edges []*windingEdge
+	for i, path := range paths {
+		for j := 1; j < len(path); j++ {
+			p0, p1 := path[j-1], path[j]
+			if p0[0] == p1[0] && p0[1] == p1[1] {
+				continue
+			}
+			sign := 1
+			if p1[0] < p0[0] || p1[0] == p0[0] && p1[1] < p0[1] {
+				p0, p1, sign = p1, p0, -1
+			}
+			key := [4]float64{p0[0], p0[1], p1[0], p1[1]}
+			e, ok := index[key]
+			if !ok {
+				e = &windingEdge{p0: p0, p1: p1}
+				index[key] = e
+				edges = append(edges, e)
+			}
+			e.delta[b.sources[i]] += sign
+		}
+	}
+
+	// 两侧环绕数相同的边不是任何区域的边界
+	outgoing := make(map[nodeKey][]*halfEdge)
+	var nodes []nodeKey
+	kept := edges[:0]
+	for _, e := range edges {
+		if e.delta == (Winding{}) {
+			continue
+		}
+		kept = append(kept, e)
+		h := &halfEdge{from: e.p0, to: e.p1, edge: e, forward: true}
+		t := &halfEdge{from: e.p1, to: e.p0, edge: e, twin: h}
+		h.twin, e.forward = t, h
+		for _, he := range [...]*halfEdge{h, t} {
+			key := nodeKey{he.from[0], he.from[1]}
+			if _, ok := outgoing[key]; !ok {
+				nodes = append(nodes, key)
+			}
+			outgoing[key] = append(outgoing[key], he)
+		}
+	}
+	edges = kept
+	for _, out := range outgoing {
+		sort.Slice(out, func(i, j int) bool {
+			return CompareDirection(out[i].from, out[i].to, out[j].to) < 0
+		})
+		for i, he := range out {
+			he.index = i
+		}
+	}
+
+	components := findComponents(nodes, outgoing)
+	tree := strtree.NewTree(strtree.DefaultNodeCapacity)
+	for _, c := range components {
+		_ = tree.Insert(c.bounds, c)
+	}
+	for _, c := range components {
+		// 最左侧的结点左边的区域位于分量之外，其环绕数由包含它的其他分量决定
+		var outside Winding
+		point := geom.NewBounds(geom.XY).Set(c.leftmost[0], c.leftmost[1], c.leftmost[0], c.leftmost[1])
+		for _, item := range tree.Query(point) {
+			if other := item.(*component); other != c {
+				other.addWinding(&outside, c.leftmost)
+			}
+		}
+		if !label(leftmostEdge(outgoing[nodeKey{c.leftmost[0], c.leftmost[1]}]), outside, outgoing) {
+			return nil, false
+		}
+	}
+
+	var result []*Edge
+	for _, e := range edges {
+		left := e.forward.winding
+		var right Winding
+		for s := range right {
+			right[s] = left[s] - e.delta[s]
+		}
+		inLeft, inRight := selects(left), selects(right)
+		switch {
+		case inLeft && !inRight:
+			result = append(result, &Edge{From: e.p0, To: e.p1})
+		case inRight && !inLeft:
+			result = append(result, &Edge{From: e.p1, To: e.p0})
+		}
+	}
+	rings, ok := BuildRings(result)
+	if !ok {
+		return nil, false
+	}
+	return Assemble(rings, srid), true
+}
+
+// findComponents 函数返回平面图的连通分量
+func findComponents(nodes []nodeKey, outgoing map[nodeKey][]*halfEdge) []*component {
+	seen := make(map[nodeKey]bool, len(nodes))
+	var components []*component
+	for _, start := range nodes {
+		if seen[start] {
+			continue
+		}
+		seen[start] = true
+		c := &component{leftmost: geom.Coord{start[0], start[1]}}
+		minX, minY, maxX, maxY := start[0], start[1], start[0], start[1]
+		for stack := []nodeKey{start}; len(stack) > 0; {
+			key := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if key[0] < c.leftmost[0] || key[0] == c.leftmost[0] && key[1] < c.leftmost[1] {
+				c.leftmost = geom.Coord{key[0], key[1]}
+			}
+			minX, minY = math.Min(minX, key[0]), math.Min(minY, key[1])
+			maxX, maxY = math.Max(maxX, key[0]), math.Max(maxY, key[1])
+			for _, he := range outgoing[key] {
+				if he.forward {
+					c.edges = append(c.edges, he.edge)
+				}
+				if next := (nodeKey{he.to[0], he.to[1]}); !seen[next] {
+					seen[next] = true
+					stack = append(stack, next)
+				}
+			}
+		}
+		c.bounds = geom.NewBounds(geom.XY).Set(minX, minY, maxX, maxY)
+		components = append(components, c)
+	}
+	return components
+}
+
+// addWinding 方法将点 p 相对于分量的环绕数加到 w 上，p 不能位于分量的边上
+func (c *component) addWinding(w *Winding, p geom.Coord) {
+	for _, e := range c.edges {
+		switch {
+		case e.p0[1] <= p[1] && p[1] < e.p1[1]:
+			if bigxy.OrientationIndex(e.p0, e.p1, p) == orientation.CounterClockwise {
+				for s := range w {
+					w[s] += e.delta[s]
+				}
+			}
+		case e.p1[1] <= p[1] && p[1] < e.p0[1]:
+			if bigxy.OrientationIndex(e.p0, e.p1, p) == orientation.Clockwise {
+				for s := range w {
+					w[s] -= e.delta[s]
+				}
+			}
+		}
+	}
+}
+
+// leftmostEdge 函数返回最左侧的结点的出边中，左侧的区域包含该结点左边的点的边。
+// 其他结点都在最左侧的结点的右边或正上方，因此这条边是第一象限中最后一条出边，第一象限中没有出边时为最后一条出边
+func leftmostEdge(out []*halfEdge) *halfEdge {
+	result := out[len(out)-1]
+	for _, he := range out {
+		if quadrant(he.to[0]-he.from[0], he.to[1]-he.from[1]) == 0 {
+			result = he
+		}
+	}
+	return result
+}
+
+// label 函数从 start 开始计算同一个连通分量中所有半边左侧的环绕数：
+// 沿着面的边界前进时左侧的环绕数不变，跨过一条边时环绕数改变该边两侧的差值。环绕数互相矛盾时返回 false
+func label(start *halfEdge, winding Winding, outgoing map[nodeKey][]*halfEdge) bool {
+	var stack []*halfEdge
+	visit := func(he *halfEdge, winding Winding) bool {
+		if he.labeled {
+			return he.winding == winding
+		}
+		he.winding, he.labeled = winding, true
+		stack = append(stack, he)
+		return true
+	}
+	visit(start, winding)
+	for len(stack) > 0 {
+		he := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		// 面的下一条边是终点处从来路的反方向开始顺时针方向的第一条出边
+		out := outgoing[nodeKey{he.to[0], he.to[1]}]
+		if !visit(out[(he.twin.index+len(out)-1)%len(out)], he.winding) {
+			return false
+		}
+		var twin Winding
+		for s := range twin {
+			if he.forward {
+				twin[s] = he.winding[s] - he.edge.delta[s]
+			} else {
+				twin[s] = he.winding[s] + he.edge.delta[s]
+			}
+		}
+		if !visit(he.twin, twin) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package xy
+
+import (
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/bigxy"
+	"github.com/chengxiaoer/geomGo/xy/location"
+	"github.com/chengxiaoer/geomGo/xy/orientation"
+)
+
+// LocatePointInRing函数 计算点相对于线环的拓扑位置。
+// 使用射线交叉数算法，方向判断使用强健的 bigxy.OrientationIndex，因此点位于线环的边或顶点上时结果是准确的。
+//
+// Param layout - 线环坐标的视图
+// Param p - 需要定位的点
+// Param ring - 线环的坐标数组，第一个和最后一个点必须相等
+// Returns 点在线环内部时返回 location.Interior，在线环上时返回 location.Boundary，否则返回 location.Exterior
+func LocatePointInRing(layout geom.Layout, p geom.Coord, ring []float64) location.Type {
+	counter := rayCrossingCounter{p: p}
+	stride := layout.Stride()
+	for i := stride; i < len(ring); i += stride {
+		if counter.countSegment(ring[i-stride:i-stride+2], ring[i:i+2]) {
+			return location.Boundary
+		}
+	}
+	if counter.crossings%2 == 1 {
+		return location.Interior
+	}
+	return location.Exterior
+}
+
+// LocatePointInPolygon函数 计算点相对于多边形的拓扑位置。
+// 位于洞内部的点在多边形外部，位于洞边界上的点在多边形边界上。
+// 空多边形的结果总是 location.Exterior
+func LocatePointInPolygon(polygon *geom.Polygon, p geom.Coord) location.Type {
+	return locatePointInPolygon(polygon.Layout(), p, polygon.FlatCoords(), 0, polygon.Ends())
+}
+
+// LocatePointInMultiPolygon函数 计算点相对于多边形集合的拓扑位置。
+// 点在任意一个多边形内部时返回 location.Interior，在任意一个多边形边界上时返回 location.Boundary
+func LocatePointInMultiPolygon(multiPolygon *geom.MultiPolygon, p geom.Coord) location.Type {
+	result := location.Exterior
+	offset := 0
+	for _, ends := range multiPolygon.Endss() {
+		switch locatePointInPolygon(multiPolygon.Layout(), p, multiPolygon.FlatCoords(), offset, ends) {
+		case location.Interior:
+			return location.Interior
+		case location.Boundary:
+			result = location.Boundary
+		}
+		if len(ends) > 0 {
+			offset = ends[len(ends)-1]
+		}
+	}
+	return result
+}
+
+func locatePointInPolygon(layout geom.Layout, p geom.Coord, flatCoords []float64, offset int, ends []int) location.Type {
+	if len(ends) == 0 {
+		return location.Exterior
+	}
+	shellLocation := LocatePointInRing(layout, p, flatCoords[offset:ends[0]])
+	if shellLocation != location.Interior {
+		return shellLocation
+	}
+	for i := 1; i < len(ends); i++ {
+		switch LocatePointInRing(layout, p, flatCoords[ends[i-1]:ends[i]]) {
+		case location.Interior:
+			return location.Exterior
+		case location.Boundary:
+			return location.Boundary
+		}
+	}
+	return location.Interior
+}
+
+// rayCrossingCounter 统计从点 p 沿 x 轴正方向发出的射线与线环的边相交的次数
+type rayCrossingCounter struct {
+	p         geom.Coord
+	crossings int
+}
+
+// countSegment 方法统计线段 p1-p2 与射线的相交，如果点 p 位于线段上则返回 true
+func (c *rayCrossingCounter) countSegment(p1, p2 geom.Coord) bool {
+	p := c.p
+
+	// 线段完全位于点的左侧
+	if p1[0] < p[0] && p2[0] < p[0] {
+		return false
+	}
+
+	// 点与线段的终点重合
+	if p[0] == p2[0] && p[1] == p2[1] {
+		return true
+	}
+
+	// 水平线段只检测点是否在线段上，不计入相交次数
+	if p1[1] == p[1] && p2[1] == p[1] {
+		minX, maxX := p1[0], p2[0]
+		if minX > maxX {
+			minX, maxX = maxX, minX
+		}
+		return minX <= p[0] && p[0] <= maxX
+	}
+
+	// 统计跨过射线所在水平线的非水平线段，下端点包含在内，上端点不包含在内
+	if (p1[1] > p[1] && p2[1] <= p[1]) || (p2[1] > p[1] && p1[1] <= p[1]) {
+		orient := bigxy.OrientationIndex(p1, p2, p)
+		if orient == orientation.Collinear {
+			return true
+		}
+		// 调整方向，使线段等效为向上的方向
+		if p2[1] < p1[1] {
+			orient = -orient
+		}
+		// 点位于向上的线段左侧时，线段与射线相交
+		if orient == orientation.CounterClockwise {
+			c.crossings++
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package xy_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+)
+
+func ExampleLocatePointInRing() {
+	ring := geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0})
+	fmt.Println(xy.LocatePointInRing(ring.Layout(), geom.Coord{5, 5}, ring.FlatCoords()))
+	fmt.Println(xy.LocatePointInRing(ring.Layout(), geom.Coord{10, 5}, ring.FlatCoords()))
+	fmt.Println(xy.LocatePointInRing(ring.Layout(), geom.Coord{15, 5}, ring.FlatCoords()))
+	// Output:
+	// Interior
+	// Boundary
+	// Exterior
+}
+
+func ExampleLocatePointInPolygon() {
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+	})
+	fmt.Println(xy.LocatePointInPolygon(polygon, geom.Coord{3, 3}))
+	// Output: Exterior
+}
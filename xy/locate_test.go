@@ -0,0 +1,103 @@
+package xy_test
+
+import (
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+func TestLocatePointInRing(t *testing.T) {
+	square := []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}
+	clockwiseSquare := []float64{0, 0, 0, 10, 10, 10, 10, 0, 0, 0}
+	diamond := []float64{5, 0, 10, 5, 5, 10, 0, 5, 5, 0}
+	concave := []float64{0, 0, 10, 0, 10, 10, 5, 5, 0, 10, 0, 0}
+	for i, tc := range []struct {
+		desc     string
+		layout   geom.Layout
+		ring     []float64
+		p        geom.Coord
+		expected location.Type
+	}{
+		{desc: "centre", layout: geom.XY, ring: square, p: geom.Coord{5, 5}, expected: location.Interior},
+		{desc: "vertical edge", layout: geom.XY, ring: square, p: geom.Coord{0, 5}, expected: location.Boundary},
+		{desc: "horizontal edge", layout: geom.XY, ring: square, p: geom.Coord{5, 0}, expected: location.Boundary},
+		{desc: "top horizontal edge", layout: geom.XY, ring: square, p: geom.Coord{5, 10}, expected: location.Boundary},
+		{desc: "first vertex", layout: geom.XY, ring: square, p: geom.Coord{0, 0}, expected: location.Boundary},
+		{desc: "other vertex", layout: geom.XY, ring: square, p: geom.Coord{10, 10}, expected: location.Boundary},
+		{desc: "right", layout: geom.XY, ring: square, p: geom.Coord{11, 5}, expected: location.Exterior},
+		{desc: "left", layout: geom.XY, ring: square, p: geom.Coord{-1, 5}, expected: location.Exterior},
+		{desc: "left of horizontal edge", layout: geom.XY, ring: square, p: geom.Coord{-1, 0}, expected: location.Exterior},
+		{desc: "above", layout: geom.XY, ring: square, p: geom.Coord{5, 11}, expected: location.Exterior},
+		{desc: "clockwise centre", layout: geom.XY, ring: clockwiseSquare, p: geom.Coord{5, 5}, expected: location.Interior},
+		{desc: "clockwise edge", layout: geom.XY, ring: clockwiseSquare, p: geom.Coord{10, 5}, expected: location.Boundary},
+		{desc: "ray through vertex inside", layout: geom.XY, ring: diamond, p: geom.Coord{2, 5}, expected: location.Interior},
+		{desc: "ray through vertex outside", layout: geom.XY, ring: diamond, p: geom.Coord{-2, 5}, expected: location.Exterior},
+		{desc: "beyond vertex", layout: geom.XY, ring: diamond, p: geom.Coord{12, 5}, expected: location.Exterior},
+		{desc: "diagonal edge", layout: geom.XY, ring: diamond, p: geom.Coord{7.5, 2.5}, expected: location.Boundary},
+		{desc: "concave notch", layout: geom.XY, ring: concave, p: geom.Coord{5, 8}, expected: location.Exterior},
+		{desc: "concave reflex vertex", layout: geom.XY, ring: concave, p: geom.Coord{5, 5}, expected: location.Boundary},
+		{desc: "concave below notch", layout: geom.XY, ring: concave, p: geom.Coord{5, 2}, expected: location.Interior},
+		{desc: "XYZ", layout: geom.XYZ, ring: []float64{0, 0, 1, 10, 0, 2, 10, 10, 3, 0, 10, 4, 0, 0, 1}, p: geom.Coord{5, 5}, expected: location.Interior},
+		{desc: "XYZ edge", layout: geom.XYZ, ring: []float64{0, 0, 1, 10, 0, 2, 10, 10, 3, 0, 10, 4, 0, 0, 1}, p: geom.Coord{10, 5, 100}, expected: location.Boundary},
+	} {
+		if got := xy.LocatePointInRing(tc.layout, tc.p, tc.ring); got != tc.expected {
+			t.Errorf("%d: %s: LocatePointInRing(%v, %v, %v) == %v, want %v", i, tc.desc, tc.layout, tc.p, tc.ring, got, tc.expected)
+		}
+	}
+}
+
+func TestLocatePointInPolygon(t *testing.T) {
+	polygon := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+	})
+	for i, tc := range []struct {
+		p        geom.Coord
+		expected location.Type
+	}{
+		{p: geom.Coord{5, 5}, expected: location.Interior},
+		{p: geom.Coord{3, 3}, expected: location.Exterior},
+		{p: geom.Coord{2, 3}, expected: location.Boundary},
+		{p: geom.Coord{4, 4}, expected: location.Boundary},
+		{p: geom.Coord{10, 3}, expected: location.Boundary},
+		{p: geom.Coord{11, 11}, expected: location.Exterior},
+	} {
+		if got := xy.LocatePointInPolygon(polygon, tc.p); got != tc.expected {
+			t.Errorf("%d: LocatePointInPolygon(polygon, %v) == %v, want %v", i, tc.p, got, tc.expected)
+		}
+	}
+	if got := xy.LocatePointInPolygon(geom.NewPolygon(geom.XY), geom.Coord{0, 0}); got != location.Exterior {
+		t.Errorf("LocatePointInPolygon(empty, ...) == %v, want %v", got, location.Exterior)
+	}
+}
+
+func TestLocatePointInMultiPolygon(t *testing.T) {
+	multiPolygon := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+		{
+			{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+			{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}},
+		},
+		{
+			{{20, 0}, {30, 0}, {30, 10}, {20, 10}, {20, 0}},
+		},
+	})
+	for i, tc := range []struct {
+		p        geom.Coord
+		expected location.Type
+	}{
+		{p: geom.Coord{5, 5}, expected: location.Interior},
+		{p: geom.Coord{3, 3}, expected: location.Exterior},
+		{p: geom.Coord{25, 5}, expected: location.Interior},
+		{p: geom.Coord{20, 5}, expected: location.Boundary},
+		{p: geom.Coord{15, 5}, expected: location.Exterior},
+	} {
+		if got := xy.LocatePointInMultiPolygon(multiPolygon, tc.p); got != tc.expected {
+			t.Errorf("%d: LocatePointInMultiPolygon(multiPolygon, %v) == %v, want %v", i, tc.p, got, tc.expected)
+		}
+	}
+	if got := xy.LocatePointInMultiPolygon(geom.NewMultiPolygon(geom.XY), geom.Coord{0, 0}); got != location.Exterior {
+		t.Errorf("LocatePointInMultiPolygon(empty, ...) == %v, want %v", got, location.Exterior)
+	}
+}
@@ -0,0 +1,215 @@
+package xy
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// LocateAlong函数 返回几何图形上 M 值等于 m 的所有点，线上的点的所有维度都按照线性插值计算。
+// 支持 Point、MultiPoint、LineString、MultiLineString 和由这些类型组成的 GeometryCollection，
+// 几何图形的视图必须包含 M 值（XYM 或 XYZM），否则返回 geom.ErrUnsupportedLayout
+func LocateAlong(g geom.T, m float64) (*geom.MultiPoint, error) {
+	mIndex := g.Layout().MIndex()
+	if mIndex < 0 {
+		return nil, geom.ErrUnsupportedLayout(g.Layout())
+	}
+	var flatCoords []float64
+	err := forEachMeasuredPart(g, g.Layout(), func(part []float64, isLine bool) error {
+		flatCoords = locateAlong1(flatCoords, part, g.Stride(), mIndex, m, isLine)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return geom.NewMultiPointFlat(g.Layout(), flatCoords).SetSRID(g.SRID()), nil
+}
+
+// LocateBetween函数 返回线上 M 值位于 mStart 与 mEnd 之间（包括两端）的部分，每一段连续的部分对应结果中的一个 LineString，
+// 被截断的端点的所有维度都按照线性插值计算。线只在一个点上达到该范围时，该点对应一个由两个相同的点组成的 LineString。
+// 支持 LineString、MultiLineString 和由这些类型组成的 GeometryCollection，
+// 几何图形的视图必须包含 M 值（XYM 或 XYZM），否则返回 geom.ErrUnsupportedLayout
+func LocateBetween(g geom.T, mStart, mEnd float64) (*geom.MultiLineString, error) {
+	mIndex := g.Layout().MIndex()
+	if mIndex < 0 {
+		return nil, geom.ErrUnsupportedLayout(g.Layout())
+	}
+	lower, upper := math.Min(mStart, mEnd), math.Max(mStart, mEnd)
+	var flatCoords []float64
+	var ends []int
+	err := forEachMeasuredPart(g, g.Layout(), func(part []float64, isLine bool) error {
+		if !isLine {
+			return geom.ErrUnsupportedType{Value: g}
+		}
+		flatCoords, ends = locateBetween1(flatCoords, ends, part, g.Stride(), mIndex, lower, upper)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return geom.NewMultiLineStringFlat(g.Layout(), flatCoords, ends).SetSRID(g.SRID()), nil
+}
+
+// AddMeasure函数 返回一条新的线，M 值沿线的长度从 start 线性变化到 end。
+// XY 和 XYZ 视图的线分别转换为 XYM 和 XYZM 视图，已经有 M 值的线的 M 值被替换。长度为0的线所有的 M 值都为 start
+func AddMeasure(ls *geom.LineString, start, end float64) (*geom.LineString, error) {
+	var layout geom.Layout
+	switch ls.Layout() {
+	case geom.XY, geom.XYM:
+		layout = geom.XYM
+	case geom.XYZ, geom.XYZM:
+		layout = geom.XYZM
+	default:
+		return nil, geom.ErrUnsupportedLayout(ls.Layout())
+	}
+	stride, newStride, mIndex := ls.Stride(), layout.Stride(), layout.MIndex()
+	src := ls.FlatCoords()
+	length := ls.Length()
+	flatCoords := make([]float64, 0, len(src)/stride*newStride)
+	var distance float64
+	for i := 0; i < len(src); i += stride {
+		if i > 0 {
+			distance += math.Hypot(src[i]-src[i-stride], src[i+1]-src[i-stride+1])
+		}
+		m := start
+		if length > 0 {
+			m += (end - start) * distance / length
+		}
+		flatCoords = append(append(flatCoords, src[i:i+mIndex]...), m)
+	}
+	return geom.NewLineStringFlat(layout, flatCoords).SetSRID(ls.SRID()), nil
+}
+
+// forEachMeasuredPart 函数对几何图形中的每个点集或线调用 f。
+// 不支持的类型返回 geom.ErrUnsupportedType，GeometryCollection 中的几何图形视图不一致时返回 geom.ErrLayoutMismatch
+func forEachMeasuredPart(g geom.T, layout geom.Layout, f func(part []float64, isLine bool) error) error {
+	if g.Layout() != layout {
+		return geom.ErrLayoutMismatch{Got: g.Layout(), Want: layout}
+	}
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		return f(g.FlatCoords(), false)
+	case *geom.LineString:
+		return f(g.FlatCoords(), true)
+	case *geom.MultiLineString:
+		offset := 0
+		for _, end := range g.Ends() {
+			if err := f(g.FlatCoords()[offset:end], true); err != nil {
+				return err
+			}
+			offset = end
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if err := forEachMeasuredPart(child, layout, f); err != nil {
+				return err
+			}
+		}
+	default:
+		return geom.ErrUnsupportedType{Value: g}
+	}
+	return nil
+}
+
+// locateAlong1 函数将 coords 中 M 值等于 m 的点追加到 dst。isLine 为 true 时 coords 是一条线，线段上的点通过插值计算
+func locateAlong1(dst, coords []float64, stride, mIndex int, m float64, isLine bool) []float64 {
+	for i := 0; i < len(coords); i += stride {
+		if coords[i+mIndex] == m {
+			dst = append(dst, coords[i:i+stride]...)
+		}
+		if !isLine || i+stride >= len(coords) {
+			continue
+		}
+		m0, m1 := coords[i+mIndex], coords[i+stride+mIndex]
+		if (m0 < m && m < m1) || (m1 < m && m < m0) {
+			c := interpolateMeasured(coords[i:i+2*stride], stride, (m-m0)/(m1-m0))
+			c[mIndex] = m
+			dst = append(dst, c...)
+		}
+	}
+	return dst
+}
+
+// locateBetween1 函数将线 coords 中 M 值位于 lower 与 upper 之间的每一段连续的部分追加到 dst 和 ends
+func locateBetween1(dst []float64, ends []int, coords []float64, stride, mIndex int, lower, upper float64) ([]float64, []int) {
+	start := -1
+	closePiece := func() {
+		if start < 0 {
+			return
+		}
+		if len(dst)-start == stride {
+			dst = append(dst, dst[start:start+stride]...)
+		}
+		ends = append(ends, len(dst))
+		start = -1
+	}
+	appendCoord := func(c []float64) {
+		if start < 0 {
+			start = len(dst)
+			dst = append(dst, c...)
+			return
+		}
+		if !equalMeasured(dst[len(dst)-stride:], c) {
+			dst = append(dst, c...)
+		}
+	}
+	if len(coords) == stride {
+		if m := coords[mIndex]; lower <= m && m <= upper {
+			appendCoord(coords)
+		}
+		closePiece()
+		return dst, ends
+	}
+	for i := stride; i < len(coords); i += stride {
+		segment := coords[i-stride : i+stride]
+		t0, t1, ok := measureRange(segment[mIndex], segment[stride+mIndex], lower, upper)
+		if !ok {
+			closePiece()
+			continue
+		}
+		if t0 > 0 {
+			closePiece()
+		}
+		appendCoord(interpolateMeasured(segment, stride, t0))
+		appendCoord(interpolateMeasured(segment, stride, t1))
+		if t1 < 1 {
+			closePiece()
+		}
+	}
+	closePiece()
+	return dst, ends
+}
+
+// measureRange 函数返回 M 值从 m0 线性变化到 m1 的线段上 M 值位于 lower 与 upper 之间的参数范围
+func measureRange(m0, m1, lower, upper float64) (float64, float64, bool) {
+	if m0 == m1 {
+		return 0, 1, lower <= m0 && m0 <= upper
+	}
+	ta, tb := (lower-m0)/(m1-m0), (upper-m0)/(m1-m0)
+	t0, t1 := math.Max(0, math.Min(ta, tb)), math.Min(1, math.Max(ta, tb))
+	return t0, t1, t0 <= t1
+}
+
+// interpolateMeasured 函数在线段 segment 的两个端点之间按照参数 t 插值，所有的维度都参与插值
+func interpolateMeasured(segment []float64, stride int, t float64) []float64 {
+	switch t {
+	case 0:
+		return segment[:stride]
+	case 1:
+		return segment[stride : 2*stride]
+	}
+	c := make([]float64, stride)
+	for i := range c {
+		c[i] = segment[i] + t*(segment[stride+i]-segment[i])
+	}
+	return c
+}
+
+func equalMeasured(c1, c2 []float64) bool {
+	for i := range c1 {
+		if c1[i] != c2[i] {
+			return false
+		}
+	}
+	return true
+}
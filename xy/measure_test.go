@@ -0,0 +1,221 @@
+package xy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+)
+
+func TestLocateAlong(t *testing.T) {
+	for i, tc := range []struct {
+		desc string
+		g    string
+		m    float64
+		want string
+	}{
+		{
+			desc: "point",
+			g:    "POINT M (1 2 3)",
+			m:    3,
+			want: "MULTIPOINT M (1 2 3)",
+		},
+		{
+			desc: "multipoint",
+			g:    "MULTIPOINT M ((1 2 3), (4 5 6), (7 8 3))",
+			m:    3,
+			want: "MULTIPOINT M (1 2 3, 7 8 3)",
+		},
+		{
+			desc: "interpolated",
+			g:    "LINESTRING ZM (0 0 0 0, 10 0 10 10)",
+			m:    2.5,
+			want: "MULTIPOINT ZM (2.5 0 2.5 2.5)",
+		},
+		{
+			desc: "vertex",
+			g:    "LINESTRING M (0 0 0, 10 0 10, 20 0 0)",
+			m:    10,
+			want: "MULTIPOINT M (10 0 10)",
+		},
+		{
+			desc: "multiple crossings",
+			g:    "LINESTRING M (0 0 0, 10 0 10, 20 0 0)",
+			m:    5,
+			want: "MULTIPOINT M (5 0 5, 15 0 5)",
+		},
+		{
+			desc: "multilinestring",
+			g:    "MULTILINESTRING M ((0 0 0, 10 0 10), (0 10 10, 10 10 20))",
+			m:    10,
+			want: "MULTIPOINT M (10 0 10, 0 10 10)",
+		},
+		{
+			desc: "not found",
+			g:    "LINESTRING M (0 0 0, 10 0 10)",
+			m:    20,
+			want: "MULTIPOINT M EMPTY",
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.g)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v", i, tc.desc, tc.g, err)
+		}
+		got, err := xy.LocateAlong(g, tc.m)
+		if err != nil {
+			t.Errorf("%d: %s: xy.LocateAlong(%s, %v) == _, %v, want _, <nil>", i, tc.desc, tc.g, tc.m, err)
+			continue
+		}
+		if s, _ := wkt.Marshal(got); s != tc.want {
+			t.Errorf("%d: %s: xy.LocateAlong(%s, %v) == %s, want %s", i, tc.desc, tc.g, tc.m, s, tc.want)
+		}
+	}
+}
+
+func TestLocateBetween(t *testing.T) {
+	for i, tc := range []struct {
+		desc         string
+		g            string
+		mStart, mEnd float64
+		want         string
+	}{
+		{
+			desc:   "clip both ends",
+			g:      "LINESTRING ZM (0 0 0 0, 10 0 10 10, 10 10 20 20)",
+			mStart: 5,
+			mEnd:   15,
+			want:   "MULTILINESTRING ZM ((5 0 5 5, 10 0 10 10, 10 5 15 15))",
+		},
+		{
+			desc:   "reversed range",
+			g:      "LINESTRING M (0 0 0, 10 0 10)",
+			mStart: 8,
+			mEnd:   2,
+			want:   "MULTILINESTRING M ((2 0 2, 8 0 8))",
+		},
+		{
+			desc:   "several pieces",
+			g:      "LINESTRING M (0 0 0, 10 0 10, 20 0 0, 30 0 10)",
+			mStart: 8,
+			mEnd:   20,
+			want:   "MULTILINESTRING M ((8 0 8, 10 0 10, 12 0 8), (28 0 8, 30 0 10))",
+		},
+		{
+			desc:   "touch",
+			g:      "LINESTRING M (0 0 0, 10 0 10, 20 0 0)",
+			mStart: 10,
+			mEnd:   20,
+			want:   "MULTILINESTRING M ((10 0 10, 10 0 10))",
+		},
+		{
+			desc:   "constant measure",
+			g:      "LINESTRING M (0 0 5, 10 0 5, 20 0 10)",
+			mStart: 0,
+			mEnd:   5,
+			want:   "MULTILINESTRING M ((0 0 5, 10 0 5))",
+		},
+		{
+			desc:   "multilinestring",
+			g:      "MULTILINESTRING M ((0 0 0, 10 0 10), (0 10 10, 10 10 20))",
+			mStart: 5,
+			mEnd:   15,
+			want:   "MULTILINESTRING M ((5 0 5, 10 0 10), (0 10 10, 5 10 15))",
+		},
+		{
+			desc:   "outside",
+			g:      "LINESTRING M (0 0 0, 10 0 10)",
+			mStart: 20,
+			mEnd:   30,
+			want:   "MULTILINESTRING M EMPTY",
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.g)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v", i, tc.desc, tc.g, err)
+		}
+		got, err := xy.LocateBetween(g, tc.mStart, tc.mEnd)
+		if err != nil {
+			t.Errorf("%d: %s: xy.LocateBetween(%s, %v, %v) == _, %v, want _, <nil>", i, tc.desc, tc.g, tc.mStart, tc.mEnd, err)
+			continue
+		}
+		if s, _ := wkt.Marshal(got); s != tc.want {
+			t.Errorf("%d: %s: xy.LocateBetween(%s, %v, %v) == %s, want %s", i, tc.desc, tc.g, tc.mStart, tc.mEnd, s, tc.want)
+		}
+	}
+}
+
+func TestLocateErrors(t *testing.T) {
+	polygon := geom.NewPolygon(geom.XYM).MustSetCoords([][]geom.Coord{{{0, 0, 0}, {1, 0, 1}, {1, 1, 2}, {0, 0, 0}}})
+	for i, tc := range []struct {
+		g    geom.T
+		want error
+	}{
+		{
+			g:    geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+			want: geom.ErrUnsupportedLayout(geom.XY),
+		},
+		{
+			g:    polygon,
+			want: geom.ErrUnsupportedType{Value: polygon},
+		},
+	} {
+		if _, err := xy.LocateAlong(tc.g, 0); !reflect.DeepEqual(err, tc.want) {
+			t.Errorf("%d: xy.LocateAlong(...) == _, %v, want _, %v", i, err, tc.want)
+		}
+		if _, err := xy.LocateBetween(tc.g, 0, 1); !reflect.DeepEqual(err, tc.want) {
+			t.Errorf("%d: xy.LocateBetween(...) == _, %v, want _, %v", i, err, tc.want)
+		}
+	}
+	mp := geom.NewMultiPoint(geom.XYM).MustSetCoords([]geom.Coord{{0, 0, 0}})
+	if _, err := xy.LocateBetween(mp, 0, 1); !reflect.DeepEqual(err, geom.ErrUnsupportedType{Value: mp}) {
+		t.Errorf("xy.LocateBetween(multipoint, 0, 1) == _, %v, want _, %v", err, geom.ErrUnsupportedType{Value: mp})
+	}
+}
+
+func TestAddMeasure(t *testing.T) {
+	for i, tc := range []struct {
+		ls         string
+		start, end float64
+		want       string
+	}{
+		{
+			ls:    "LINESTRING (0 0, 10 0, 10 30)",
+			start: 0,
+			end:   100,
+			want:  "LINESTRING M (0 0 0, 10 0 25, 10 30 100)",
+		},
+		{
+			ls:    "LINESTRING Z (0 0 5, 10 0 6)",
+			start: 10,
+			end:   0,
+			want:  "LINESTRING ZM (0 0 5 10, 10 0 6 0)",
+		},
+		{
+			ls:    "LINESTRING M (0 0 7, 10 0 7)",
+			start: 1,
+			end:   2,
+			want:  "LINESTRING M (0 0 1, 10 0 2)",
+		},
+		{
+			ls:    "LINESTRING (1 1, 1 1)",
+			start: 3,
+			end:   4,
+			want:  "LINESTRING M (1 1 3, 1 1 3)",
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.ls)
+		if err != nil {
+			t.Fatalf("%d: wkt.Unmarshal(%q) == _, %v", i, tc.ls, err)
+		}
+		got, err := xy.AddMeasure(g.(*geom.LineString), tc.start, tc.end)
+		if err != nil {
+			t.Errorf("%d: xy.AddMeasure(%s, %v, %v) == _, %v, want _, <nil>", i, tc.ls, tc.start, tc.end, err)
+			continue
+		}
+		if s, _ := wkt.Marshal(got); s != tc.want {
+			t.Errorf("%d: xy.AddMeasure(%s, %v, %v) == %s, want %s", i, tc.ls, tc.start, tc.end, s, tc.want)
+		}
+	}
+}
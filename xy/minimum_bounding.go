@@ -0,0 +1,253 @@
+package xy
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// MinimumRectangle函数 计算包含几何图形的面积最小的矩形，矩形的方向可以是任意的。
+// 使用旋转卡壳算法：面积最小的矩形总有一条边与凸包的一条边重合。
+// 返回的多边形只包含 X 和 Y 坐标，外环为逆时针方向，SRID 与几何图形相同。
+// 凸包为点或线段时返回面积为0的退化多边形，几何图形为空时返回空的多边形
+func MinimumRectangle(g geom.T) *geom.Polygon {
+	hull := convexHullXY(g)
+	var corners [4]geom.Coord
+	switch len(hull) {
+	case 0:
+		return geom.NewPolygon(geom.XY).SetSRID(g.SRID())
+	case 1:
+		corners = [4]geom.Coord{hull[0], hull[0], hull[0], hull[0]}
+	case 2:
+		corners = [4]geom.Coord{hull[0], hull[1], hull[1], hull[0]}
+	default:
+		minArea := math.Inf(1)
+		rotatingCalipers(hull, func(i int, u, v geom.Coord, minU, maxU, maxV float64) {
+			if area := (maxU - minU) * maxV; area < minArea {
+				minArea = area
+				o := hull[i]
+				corners = [4]geom.Coord{
+					{o[0] + minU*u[0], o[1] + minU*u[1]},
+					{o[0] + maxU*u[0], o[1] + maxU*u[1]},
+					{o[0] + maxU*u[0] + maxV*v[0], o[1] + maxU*u[1] + maxV*v[1]},
+					{o[0] + minU*u[0] + maxV*v[0], o[1] + minU*u[1] + maxV*v[1]},
+				}
+			}
+		})
+	}
+	flatCoords := make([]float64, 0, 10)
+	for _, c := range append(corners[:], corners[0]) {
+		flatCoords = append(flatCoords, c[0], c[1])
+	}
+	return geom.NewPolygonFlat(geom.XY, flatCoords, []int{len(flatCoords)}).SetSRID(g.SRID())
+}
+
+// MinimumWidth函数 计算几何图形的最小宽度，即包含几何图形的两条平行线之间的最小距离。
+// 凸包为点或线段以及几何图形为空时返回0
+func MinimumWidth(g geom.T) float64 {
+	hull := convexHullXY(g)
+	if len(hull) < 3 {
+		return 0
+	}
+	width := math.Inf(1)
+	rotatingCalipers(hull, func(i int, u, v geom.Coord, minU, maxU, maxV float64) {
+		width = math.Min(width, maxV)
+	})
+	return width
+}
+
+// Diameter函数 计算几何图形的直径，即几何图形中距离最远的两个点之间的距离。几何图形为空时返回0
+func Diameter(g geom.T) float64 {
+	p1, p2 := FarthestPoints(g)
+	if p1 == nil {
+		return 0
+	}
+	return math.Hypot(p2[0]-p1[0], p2[1]-p1[1])
+}
+
+// FarthestPoints函数 返回几何图形中距离最远的两个点，只包含 X 和 Y 坐标。
+// 距离最远的两个点一定是凸包上一对对踵的顶点，使用旋转卡壳算法枚举。几何图形为空时返回 nil, nil
+func FarthestPoints(g geom.T) (geom.Coord, geom.Coord) {
+	hull := convexHullXY(g)
+	switch len(hull) {
+	case 0:
+		return nil, nil
+	case 1:
+		return hull[0], hull[0]
+	case 2:
+		return hull[0], hull[1]
+	}
+	n := len(hull)
+	var p1, p2 geom.Coord
+	maxDist := -1.0
+	update := func(a, b geom.Coord) {
+		if d := (b[0]-a[0])*(b[0]-a[0]) + (b[1]-a[1])*(b[1]-a[1]); d > maxDist {
+			maxDist, p1, p2 = d, a, b
+		}
+	}
+	top := 0
+	for i := 0; i < n; i++ {
+		a, b := hull[i], hull[(i+1)%n]
+		// 与边 a-b 距离最远的顶点，存在两个距离相同的顶点时 top 是后一个，因此也检查前一个顶点
+		height := func(c geom.Coord) float64 {
+			return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+		}
+		for steps := 0; steps < n && height(hull[(top+1)%n]) >= height(hull[top]); steps++ {
+			top = (top + 1) % n
+		}
+		for _, c := range []geom.Coord{hull[top], hull[(top+n-1)%n]} {
+			update(a, c)
+			update(b, c)
+		}
+	}
+	return p1, p2
+}
+
+// MinimumBoundingCircle函数 计算包含几何图形的最小圆，返回圆心（只包含 X 和 Y 坐标）和半径。
+// 使用 Welzl 算法的迭代形式，只有凸包的顶点参与计算。几何图形为空时返回 nil, 0
+func MinimumBoundingCircle(g geom.T) (geom.Coord, float64) {
+	hull := convexHullXY(g)
+	if len(hull) == 0 {
+		return nil, 0
+	}
+	points := append([]geom.Coord(nil), hull...)
+	// 随机的顺序使得算法的期望时间复杂度为线性，固定的种子保证结果是确定的
+	r := rand.New(rand.NewSource(1))
+	r.Shuffle(len(points), func(i, j int) {
+		points[i], points[j] = points[j], points[i]
+	})
+	c := circle{center: points[0]}
+	for i := 1; i < len(points); i++ {
+		if c.contains(points[i]) {
+			continue
+		}
+		c = circle{center: points[i]}
+		for j := 0; j < i; j++ {
+			if c.contains(points[j]) {
+				continue
+			}
+			c = diameterCircle(points[i], points[j])
+			for k := 0; k < j; k++ {
+				if !c.contains(points[k]) {
+					c = circumcircle(points[i], points[j], points[k])
+				}
+			}
+		}
+	}
+	return c.center, c.radius
+}
+
+type circle struct {
+	center geom.Coord
+	radius float64
+}
+
+// contains 方法检测点是否在圆内，允许与半径成比例的舍入误差
+func (c circle) contains(p geom.Coord) bool {
+	return math.Hypot(p[0]-c.center[0], p[1]-c.center[1]) <= c.radius*(1+1e-12)
+}
+
+// diameterCircle 函数返回以线段 a-b 为直径的圆
+func diameterCircle(a, b geom.Coord) circle {
+	return circle{
+		center: geom.Coord{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2},
+		radius: math.Hypot(b[0]-a[0], b[1]-a[1]) / 2,
+	}
+}
+
+// circumcircle 函数返回经过三个点的圆，三个点共线时返回以距离最远的两个点为直径的圆
+func circumcircle(a, b, c geom.Coord) circle {
+	bx, by := b[0]-a[0], b[1]-a[1]
+	cx, cy := c[0]-a[0], c[1]-a[1]
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		result := diameterCircle(a, b)
+		for _, candidate := range []circle{diameterCircle(a, c), diameterCircle(b, c)} {
+			if candidate.radius > result.radius {
+				result = candidate
+			}
+		}
+		return result
+	}
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	ux, uy := (cy*b2-by*c2)/d, (bx*c2-cx*b2)/d
+	return circle{
+		center: geom.Coord{a[0] + ux, a[1] + uy},
+		radius: math.Hypot(ux, uy),
+	}
+}
+
+// rotatingCalipers 函数对逆时针方向的凸包（至少3个顶点）的每条边调用 f。
+// u 是边 i 的单位方向向量，v 是指向凸包内部的单位法向量，
+// minU 和 maxU 是顶点相对于 hull[i] 在 u 方向上投影的最小值和最大值，maxV 是顶点到边的最大距离。
+// 三个方向上的极值顶点随着边的旋转单调地前进，因此总的时间复杂度为线性
+func rotatingCalipers(hull []geom.Coord, f func(i int, u, v geom.Coord, minU, maxU, maxV float64)) {
+	n := len(hull)
+	project := func(o, d, c geom.Coord) float64 {
+		return (c[0]-o[0])*d[0] + (c[1]-o[1])*d[1]
+	}
+	var left, right, top int
+	for i := 0; i < n; i++ {
+		o, next := hull[i], hull[(i+1)%n]
+		length := math.Hypot(next[0]-o[0], next[1]-o[1])
+		u := geom.Coord{(next[0] - o[0]) / length, (next[1] - o[1]) / length}
+		v := geom.Coord{-u[1], u[0]}
+		if i == 0 {
+			for j := range hull {
+				if project(o, u, hull[j]) > project(o, u, hull[right]) {
+					right = j
+				}
+				if project(o, u, hull[j]) < project(o, u, hull[left]) {
+					left = j
+				}
+				if project(o, v, hull[j]) > project(o, v, hull[top]) {
+					top = j
+				}
+			}
+		}
+		for steps := 0; steps < n && project(o, u, hull[(right+1)%n]) >= project(o, u, hull[right]); steps++ {
+			right = (right + 1) % n
+		}
+		for steps := 0; steps < n && project(o, v, hull[(top+1)%n]) >= project(o, v, hull[top]); steps++ {
+			top = (top + 1) % n
+		}
+		for steps := 0; steps < n && project(o, u, hull[(left+1)%n]) <= project(o, u, hull[left]); steps++ {
+			left = (left + 1) % n
+		}
+		f(i, u, v, project(o, u, hull[left]), project(o, u, hull[right]), project(o, v, hull[top]))
+	}
+}
+
+// convexHullXY 函数返回几何图形凸包的顶点的 X 和 Y 坐标，多边形的顶点为逆时针方向并且不重复第一个顶点
+func convexHullXY(g geom.T) []geom.Coord {
+	hull := ConvexHull(g)
+	var flatCoords []float64
+	stride := hull.Stride()
+	switch hull := hull.(type) {
+	case *geom.Point, *geom.LineString:
+		flatCoords = hull.FlatCoords()
+	case *geom.Polygon:
+		flatCoords = hull.FlatCoords()[:len(hull.FlatCoords())-stride]
+	default:
+		// 几何图形为空时凸包是空的 GeometryCollection
+		return nil
+	}
+	var coords []geom.Coord
+	for i := 0; i < len(flatCoords); i += stride {
+		coords = append(coords, geom.Coord{flatCoords[i], flatCoords[i+1]})
+	}
+	if len(coords) >= 3 {
+		var area float64
+		for i := range coords {
+			a, b := coords[i], coords[(i+1)%len(coords)]
+			area += a[0]*b[1] - b[0]*a[1]
+		}
+		if area < 0 {
+			for i, j := 0, len(coords)-1; i < j; i, j = i+1, j-1 {
+				coords[i], coords[j] = coords[j], coords[i]
+			}
+		}
+	}
+	return coords
+}
@@ -0,0 +1,255 @@
+package xy_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+)
+
+func TestMinimumBounding(t *testing.T) {
+	for i, tc := range []struct {
+		desc          string
+		g             string
+		rectangleArea float64
+		width         float64
+		diameter      float64
+		center        geom.Coord
+		radius        float64
+	}{
+		{
+			desc:          "diamond",
+			g:             "POLYGON ((0 1, 1 0, 2 1, 1 2, 0 1))",
+			rectangleArea: 2,
+			width:         math.Sqrt2,
+			diameter:      2,
+			center:        geom.Coord{1, 1},
+			radius:        1,
+		},
+		{
+			desc:          "rectangle with interior points",
+			g:             "MULTIPOINT (0 0, 4 0, 4 2, 0 2, 1 1, 2 1, 3 0.5)",
+			rectangleArea: 8,
+			width:         2,
+			diameter:      math.Sqrt(20),
+			center:        geom.Coord{2, 1},
+			radius:        math.Sqrt(5),
+		},
+		{
+			desc:          "right triangle",
+			g:             "POLYGON ((0 0, 4 0, 0 3, 0 0))",
+			rectangleArea: 12,
+			width:         2.4,
+			diameter:      5,
+			center:        geom.Coord{2, 1.5},
+			radius:        2.5,
+		},
+		{
+			desc:          "obtuse triangle",
+			g:             "LINESTRING (0 0, 10 0, 5 1)",
+			rectangleArea: 10,
+			width:         1,
+			diameter:      10,
+			center:        geom.Coord{5, 0},
+			radius:        5,
+		},
+		{
+			desc:          "collinear",
+			g:             "MULTIPOINT Z (0 0 1, 3 4 2, 1.5 2 3)",
+			rectangleArea: 0,
+			width:         0,
+			diameter:      5,
+			center:        geom.Coord{1.5, 2},
+			radius:        2.5,
+		},
+		{
+			desc:          "point",
+			g:             "POINT (1 2)",
+			rectangleArea: 0,
+			width:         0,
+			diameter:      0,
+			center:        geom.Coord{1, 2},
+			radius:        0,
+		},
+		{
+			desc:          "collection",
+			g:             "GEOMETRYCOLLECTION (POINT (0 0), LINESTRING (2 0, 2 2), POINT (0 2))",
+			rectangleArea: 4,
+			width:         2,
+			diameter:      math.Sqrt(8),
+			center:        geom.Coord{1, 1},
+			radius:        math.Sqrt2,
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.g)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.g, err)
+		}
+		rectangle := xy.MinimumRectangle(g)
+		if got := math.Abs(xy.SignedArea(geom.XY, rectangle.FlatCoords())); math.Abs(got-tc.rectangleArea) > 1e-9 {
+			t.Errorf("%d: %s: area of MinimumRectangle(...) == %v, want %v", i, tc.desc, got, tc.rectangleArea)
+		}
+		checkEnclosingRectangle(t, tc.desc, g, rectangle)
+		if got := xy.MinimumWidth(g); math.Abs(got-tc.width) > 1e-9 {
+			t.Errorf("%d: %s: MinimumWidth(...) == %v, want %v", i, tc.desc, got, tc.width)
+		}
+		if got := xy.Diameter(g); math.Abs(got-tc.diameter) > 1e-9 {
+			t.Errorf("%d: %s: Diameter(...) == %v, want %v", i, tc.desc, got, tc.diameter)
+		}
+		center, radius := xy.MinimumBoundingCircle(g)
+		if math.Hypot(center[0]-tc.center[0], center[1]-tc.center[1]) > 1e-9 || math.Abs(radius-tc.radius) > 1e-9 {
+			t.Errorf("%d: %s: MinimumBoundingCircle(...) == %v, %v, want %v, %v", i, tc.desc, center, radius, tc.center, tc.radius)
+		}
+	}
+}
+
+func TestMinimumBoundingEmpty(t *testing.T) {
+	g := geom.NewGeometryCollection().SetSRID(4326)
+	if got := xy.MinimumRectangle(g); got.NumLinearRings() != 0 || got.SRID() != 4326 {
+		t.Errorf("MinimumRectangle(empty) == %v, want empty polygon with SRID 4326", got)
+	}
+	if got := xy.MinimumWidth(g); got != 0 {
+		t.Errorf("MinimumWidth(empty) == %v, want 0", got)
+	}
+	if got := xy.Diameter(g); got != 0 {
+		t.Errorf("Diameter(empty) == %v, want 0", got)
+	}
+	if p1, p2 := xy.FarthestPoints(g); p1 != nil || p2 != nil {
+		t.Errorf("FarthestPoints(empty) == %v, %v, want nil, nil", p1, p2)
+	}
+	if center, radius := xy.MinimumBoundingCircle(g); center != nil || radius != 0 {
+		t.Errorf("MinimumBoundingCircle(empty) == %v, %v, want nil, 0", center, radius)
+	}
+}
+
+func TestMinimumBoundingRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		// 超过50个点时 ConvexHull 先使用八边形剔除内部的点
+		coords := make([]geom.Coord, 3+r.Intn(150))
+		for j := range coords {
+			if i%2 == 0 {
+				coords[j] = geom.Coord{r.Float64() * 100, r.Float64() * 50}
+			} else {
+				// 椭圆上的点都是凸包的顶点
+				angle := r.Float64() * 2 * math.Pi
+				coords[j] = geom.Coord{50 + 50*math.Cos(angle), 25 + 20*math.Sin(angle)}
+			}
+		}
+		mp := geom.NewMultiPoint(geom.XY).MustSetCoords(coords)
+		rectangle := xy.MinimumRectangle(mp)
+		checkEnclosingRectangle(t, "random", mp, rectangle)
+
+		// 使用凸包的每条边暴力计算面积最小的矩形和最小宽度
+		hull := xy.ConvexHull(mp).(*geom.Polygon)
+		hullCoords := hull.FlatCoords()
+		wantArea, wantWidth := math.Inf(1), math.Inf(1)
+		for j := 0; j+3 < len(hullCoords); j += 2 {
+			ox, oy := hullCoords[j], hullCoords[j+1]
+			dx, dy := hullCoords[j+2]-ox, hullCoords[j+3]-oy
+			length := math.Hypot(dx, dy)
+			minU, maxU, maxV := math.Inf(1), math.Inf(-1), 0.0
+			for _, c := range coords {
+				u := ((c[0]-ox)*dx + (c[1]-oy)*dy) / length
+				v := math.Abs((c[1]-oy)*dx-(c[0]-ox)*dy) / length
+				minU, maxU, maxV = math.Min(minU, u), math.Max(maxU, u), math.Max(maxV, v)
+			}
+			wantArea = math.Min(wantArea, (maxU-minU)*maxV)
+			wantWidth = math.Min(wantWidth, maxV)
+		}
+		if got := math.Abs(xy.SignedArea(geom.XY, rectangle.FlatCoords())); math.Abs(got-wantArea) > 1e-9*wantArea {
+			t.Errorf("%d: area of MinimumRectangle(...) == %v, want %v", i, got, wantArea)
+		}
+		if got := xy.MinimumWidth(mp); math.Abs(got-wantWidth) > 1e-9*wantWidth {
+			t.Errorf("%d: MinimumWidth(...) == %v, want %v", i, got, wantWidth)
+		}
+
+		wantDiameter := 0.0
+		for _, a := range coords {
+			for _, b := range coords {
+				wantDiameter = math.Max(wantDiameter, math.Hypot(b[0]-a[0], b[1]-a[1]))
+			}
+		}
+		if got := xy.Diameter(mp); math.Abs(got-wantDiameter) > 1e-9*wantDiameter {
+			t.Errorf("%d: Diameter(...) == %v, want %v", i, got, wantDiameter)
+		}
+
+		center, radius := xy.MinimumBoundingCircle(mp)
+		for _, c := range coords {
+			if d := math.Hypot(c[0]-center[0], c[1]-center[1]); d > radius*(1+1e-9) {
+				t.Errorf("%d: point %v is outside of MinimumBoundingCircle(...) == %v, %v", i, c, center, radius)
+			}
+		}
+		// 最小外接圆的半径不小于直径的一半，不大于直径除以根号3
+		if radius < wantDiameter/2*(1-1e-9) || radius > wantDiameter/math.Sqrt(3)*(1+1e-9) {
+			t.Errorf("%d: radius of MinimumBoundingCircle(...) == %v, diameter %v", i, radius, wantDiameter)
+		}
+	}
+}
+
+func TestMinimumBoundingRegularPolygon(t *testing.T) {
+	// 半径为10的正100边形，100是4的倍数，因此最小的外接矩形是正方形
+	const n, radius = 100, 10.0
+	coords := make([]geom.Coord, n)
+	for i := range coords {
+		angle := 2 * math.Pi * float64(i) / n
+		coords[i] = geom.Coord{radius * math.Cos(angle), radius * math.Sin(angle)}
+	}
+	mp := geom.NewMultiPoint(geom.XY).MustSetCoords(coords)
+	width := 2 * radius * math.Cos(math.Pi/n)
+
+	rectangle := xy.MinimumRectangle(mp)
+	checkEnclosingRectangle(t, "regular polygon", mp, rectangle)
+	if got := math.Abs(xy.SignedArea(geom.XY, rectangle.FlatCoords())); math.Abs(got-width*width) > 1e-9 {
+		t.Errorf("area of MinimumRectangle(...) == %v, want %v", got, width*width)
+	}
+	if got := xy.MinimumWidth(mp); math.Abs(got-width) > 1e-9 {
+		t.Errorf("MinimumWidth(...) == %v, want %v", got, width)
+	}
+	if got := xy.Diameter(mp); math.Abs(got-2*radius) > 1e-9 {
+		t.Errorf("Diameter(...) == %v, want %v", got, 2*radius)
+	}
+	if p1, p2 := xy.FarthestPoints(mp); math.Abs(p1[0]+p2[0]) > 1e-9 || math.Abs(p1[1]+p2[1]) > 1e-9 {
+		t.Errorf("FarthestPoints(...) == %v, %v, want two opposite vertices", p1, p2)
+	}
+	if center, r := xy.MinimumBoundingCircle(mp); math.Hypot(center[0], center[1]) > 1e-9 || math.Abs(r-radius) > 1e-9 {
+		t.Errorf("MinimumBoundingCircle(...) == %v, %v, want (0 0), %v", center, r, radius)
+	}
+}
+
+// checkEnclosingRectangle 函数检查矩形为逆时针方向，并且几何图形的每个点都在矩形之内
+func checkEnclosingRectangle(t *testing.T, desc string, g geom.T, rectangle *geom.Polygon) {
+	flatCoords := rectangle.FlatCoords()
+	if got := len(flatCoords); got != 10 {
+		t.Errorf("%s: MinimumRectangle(...) has %d coordinates, want 10", desc, got)
+		return
+	}
+	// SignedArea 对于顺时针方向的线环返回正数
+	if xy.SignedArea(geom.XY, flatCoords) > 0 {
+		t.Errorf("%s: MinimumRectangle(...) is clockwise", desc)
+	}
+	var gFlatCoords []float64
+	var stride int
+	if gc, ok := g.(*geom.GeometryCollection); ok {
+		stride = 2
+		for _, child := range gc.Geoms() {
+			for j := 0; j < len(child.FlatCoords()); j += child.Stride() {
+				gFlatCoords = append(gFlatCoords, child.FlatCoords()[j], child.FlatCoords()[j+1])
+			}
+		}
+	} else {
+		gFlatCoords, stride = g.FlatCoords(), g.Stride()
+	}
+	for j := 0; j < len(gFlatCoords); j += stride {
+		x, y := gFlatCoords[j], gFlatCoords[j+1]
+		for k := 0; k+3 < len(flatCoords); k += 2 {
+			ax, ay, bx, by := flatCoords[k], flatCoords[k+1], flatCoords[k+2], flatCoords[k+3]
+			if cross := (bx-ax)*(y-ay) - (by-ay)*(x-ax); cross < -1e-9*math.Max(1, math.Hypot(bx-ax, by-ay)) {
+				t.Errorf("%s: point (%v %v) is outside of MinimumRectangle(...)", desc, x, y)
+			}
+		}
+	}
+}
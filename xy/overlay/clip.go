@@ -0,0 +1,80 @@
+package overlay
+
+import (
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/internal/noding"
+	"github.com/chengxiaoer/geomGo/xy/internal/planar"
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+// clipLines 函数返回线位于多边形内部和边界上（inside 为 true）或者外部（inside 为 false）的部分
+func clipLines(lines [][]float64, polygons []planar.Polygon, inside bool, srid int) (geom.T, error) {
+	var segs [][2]geom.Coord
+	for _, l := range lines {
+		for i := 2; i < len(l); i += 2 {
+			segs = append(segs, [2]geom.Coord{l[i-2 : i], l[i : i+2]})
+		}
+	}
+	numLineSegs := len(segs)
+	for _, p := range polygons {
+		for _, ring := range p {
+			for i := 2; i < len(ring); i += 2 {
+				segs = append(segs, [2]geom.Coord{ring[i-2 : i], ring[i : i+2]})
+			}
+		}
+	}
+	paths := noding.Node(segs)
+
+	// 与多边形的边重合的线段位于多边形的边界上
+	boundary := make(map[edgeKey]bool)
+	for _, path := range paths[numLineSegs:] {
+		for i := 1; i < len(path); i++ {
+			key, _ := newEdgeKey(path[i-1], path[i])
+			boundary[key] = true
+		}
+	}
+
+	var flatCoords []float64
+	var ends []int
+	k := 0
+	for _, l := range lines {
+		// start 为当前正在构造的线在 flatCoords 中的起点，没有正在构造的线时为 -1
+		start := -1
+		finish := func() {
+			if start >= 0 {
+				ends = append(ends, len(flatCoords))
+				start = -1
+			}
+		}
+		for i := 2; i < len(l); i += 2 {
+			path := paths[k]
+			k++
+			for j := 1; j < len(path); j++ {
+				p0, p1 := path[j-1], path[j]
+				key, _ := newEdgeKey(p0, p1)
+				in := boundary[key]
+				if !in {
+					in = planar.LocateEdge(polygons, p0, p1) == location.Interior
+				}
+				if in != inside {
+					finish()
+					continue
+				}
+				if start < 0 {
+					start = len(flatCoords)
+					flatCoords = append(flatCoords, p0[0], p0[1])
+				}
+				flatCoords = append(flatCoords, p1[0], p1[1])
+			}
+		}
+		finish()
+	}
+	switch len(ends) {
+	case 0:
+		return geom.NewLineString(geom.XY).SetSRID(srid), nil
+	case 1:
+		return geom.NewLineStringFlat(geom.XY, flatCoords).SetSRID(srid), nil
+	default:
+		return geom.NewMultiLineStringFlat(geom.XY, flatCoords, ends).SetSRID(srid), nil
+	}
+}
@@ -0,0 +1,132 @@
+package overlay
+
+import (
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/internal/noding"
+	"github.com/chengxiaoer/geomGo/xy/internal/planar"
+	"github.com/chengxiaoer/geomGo/xy/internal/polygonize"
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+// edge 是分割之后的一条边，left 和 right 为边的左侧和右侧相对于两个输入几何图形的位置，未知时为 location.None
+type edge struct {
+	p0, p1      geom.Coord
+	left, right [2]location.Type
+}
+
+// edgeKey 是与方向无关的边的键
+type edgeKey [4]float64
+
+func newEdgeKey(p0, p1 geom.Coord) (edgeKey, bool) {
+	if p1[0] < p0[0] || p1[0] == p0[0] && p1[1] < p0[1] {
+		return edgeKey{p1[0], p1[1], p0[0], p0[1]}, true
+	}
+	return edgeKey{p0[0], p0[1], p1[0], p1[1]}, false
+}
+
+// graph 是两个多边形分割之后的所有边组成的平面图
+type graph struct {
+	edges []*edge
+	index map[edgeKey]*edge
+}
+
+// addRing 方法添加线环分割之后的边，source 为线环所属的几何图形的序号
+func (g *graph) addRing(source int, path []geom.Coord) {
+	for i := 1; i < len(path); i++ {
+		p0, p1 := path[i-1], path[i]
+		key, reversed := newEdgeKey(p0, p1)
+		e, ok := g.index[key]
+		if !ok {
+			e = &edge{p0: p0, p1: p1}
+			if reversed {
+				e.p0, e.p1 = p1, p0
+			}
+			for s := range e.left {
+				e.left[s], e.right[s] = location.None, location.None
+			}
+			g.index[key] = e
+			g.edges = append(g.edges, e)
+		}
+		// 线环的内部在边的左侧
+		left, right := location.Interior, location.Exterior
+		if reversed {
+			left, right = right, left
+		}
+		e.left[source] = mergeLocation(e.left[source], left)
+		e.right[source] = mergeLocation(e.right[source], right)
+	}
+}
+
+// mergeLocation 函数合并同一个几何图形的两条重合的边的位置，任意一侧为内部时该侧为内部
+func mergeLocation(l1, l2 location.Type) location.Type {
+	if l1 == location.None {
+		return l2
+	}
+	if l1 == location.Interior || l2 == location.Interior {
+		return location.Interior
+	}
+	return location.Exterior
+}
+
+// overlayPolygons 函数计算两组多边形的叠加
+func overlayPolygons(a, b []planar.Polygon, op opCode, srid int) (geom.T, error) {
+	var lines [][2]geom.Coord
+	var sources []int
+	var ringStarts []int
+	for source, polygons := range [2][]planar.Polygon{a, b} {
+		for _, p := range polygons {
+			for _, ring := range p {
+				ringStarts = append(ringStarts, len(lines))
+				sources = append(sources, source)
+				for i := 2; i < len(ring); i += 2 {
+					lines = append(lines, [2]geom.Coord{ring[i-2 : i], ring[i : i+2]})
+				}
+			}
+		}
+	}
+	ringStarts = append(ringStarts, len(lines))
+	paths := noding.Node(lines)
+
+	g := &graph{index: make(map[edgeKey]*edge)}
+	for i, source := range sources {
+		var path []geom.Coord
+		for _, p := range paths[ringStarts[i]:ringStarts[i+1]] {
+			if len(path) == 0 {
+				path = append(path, p[0])
+			}
+			path = append(path, p[1:]...)
+		}
+		g.addRing(source, path)
+	}
+
+	// 不在某个几何图形边界上的边，两侧相对于该几何图形的位置相同
+	inputs := [2][]planar.Polygon{a, b}
+	var result []*polygonize.Edge
+	for _, e := range g.edges {
+		for s := range e.left {
+			if e.left[s] == location.None {
+				loc := planar.LocateEdge(inputs[s], e.p0, e.p1)
+				e.left[s], e.right[s] = loc, loc
+			}
+		}
+		inLeft := op.selects(e.left[0] == location.Interior, e.left[1] == location.Interior)
+		inRight := op.selects(e.right[0] == location.Interior, e.right[1] == location.Interior)
+		switch {
+		case inLeft && !inRight:
+			result = append(result, &polygonize.Edge{From: e.p0, To: e.p1})
+		case inRight && !inLeft:
+			result = append(result, &polygonize.Edge{From: e.p1, To: e.p0})
+		}
+	}
+
+	rings, ok := polygonize.BuildRings(result)
+	if !ok {
+		return nil, ErrTopology
+	}
+	return polygonize.Assemble(rings, srid), nil
+}
+
+func isClockwise(ring []float64) bool {
+	return xy.SignedArea(geom.XY, ring) > 0
+}
@@ -0,0 +1,140 @@
+// Package overlay 包含了计算平面（XY）几何图形的交集、并集、差集和对称差集的函数。
+//
+// 多边形和多多边形之间支持全部四种运算，结果是符合 OGC 规范的有效的多边形或多多边形：
+// 外边界为逆时针方向，洞为顺时针方向，接触于一点的线环被拆分为独立的线环。
+// 线和多线可以与多边形求交集（裁剪）和差集。
+//
+// 计算分为三步：使用强健的线段求交算法在所有交点处分割线段，根据每条边两侧相对于两个输入几何图形的位置选择结果的边，
+// 最后将选中的边连接为线环并组装为多边形。输入的几何图形必须是有效的，结果只包含 x、y 坐标，SRID 与第一个几何图形相同
+package overlay
+
+import (
+	"errors"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/internal/planar"
+)
+
+// ErrTopology 表示由于数值精度的原因无法将结果的边连接为闭合的线环，通常是因为输入的几何图形无效
+var ErrTopology = errors.New("overlay: topology error")
+
+// opCode 是叠加运算的类型
+type opCode int
+
+const (
+	intersection opCode = iota
+	union
+	difference
+	symDifference
+)
+
+// selects 方法根据一个位置是否位于两个几何图形的内部判断该位置是否属于运算的结果
+func (op opCode) selects(inA, inB bool) bool {
+	switch op {
+	case intersection:
+		return inA && inB
+	case union:
+		return inA || inB
+	case difference:
+		return inA && !inB
+	default:
+		return inA != inB
+	}
+}
+
+// Intersection函数 计算两个几何图形的交集。
+// 两个几何图形都是多边形或多多边形时返回多边形或多多边形，一个是线或多线而另一个是多边形或多多边形时返回线位于多边形内部和边界上的部分
+func Intersection(g1, g2 geom.T) (geom.T, error) {
+	return overlay(g1, g2, intersection)
+}
+
+// Union函数 计算两个多边形或多多边形的并集
+func Union(g1, g2 geom.T) (geom.T, error) {
+	return overlay(g1, g2, union)
+}
+
+// Difference函数 计算第一个几何图形中不属于第二个几何图形的部分。
+// 第一个几何图形可以是线或多线，此时返回线位于多边形外部的部分
+func Difference(g1, g2 geom.T) (geom.T, error) {
+	return overlay(g1, g2, difference)
+}
+
+// SymDifference函数 计算两个多边形或多多边形的对称差集，即只属于其中一个几何图形的部分
+func SymDifference(g1, g2 geom.T) (geom.T, error) {
+	return overlay(g1, g2, symDifference)
+}
+
+func overlay(g1, g2 geom.T, op opCode) (geom.T, error) {
+	a, aPolygonal := polygonal(g1)
+	b, bPolygonal := polygonal(g2)
+	switch {
+	case aPolygonal && bPolygonal:
+		return overlayPolygons(a, b, op, g1.SRID())
+	case !bPolygonal:
+		if lines, ok := lineal(g2); ok && aPolygonal && op == intersection {
+			return clipLines(lines, a, true, g1.SRID())
+		}
+		return nil, geom.ErrUnsupportedType{Value: g2}
+	default:
+		if lines, ok := lineal(g1); ok && (op == intersection || op == difference) {
+			return clipLines(lines, b, op == intersection, g1.SRID())
+		}
+		return nil, geom.ErrUnsupportedType{Value: g1}
+	}
+}
+
+// polygonal 函数返回多边形或多多边形中的所有多边形，线环的外边界为逆时针方向，洞为顺时针方向
+func polygonal(g geom.T) ([]planar.Polygon, bool) {
+	switch g := g.(type) {
+	case *geom.Polygon:
+		return []planar.Polygon{newPolygon(g.FlatCoords(), 0, g.Ends(), g.Stride())}, true
+	case *geom.MultiPolygon:
+		var polygons []planar.Polygon
+		offset := 0
+		for _, ends := range g.Endss() {
+			if len(ends) == 0 {
+				continue
+			}
+			polygons = append(polygons, newPolygon(g.FlatCoords(), offset, ends, g.Stride()))
+			offset = ends[len(ends)-1]
+		}
+		return polygons, true
+	default:
+		return nil, false
+	}
+}
+
+func newPolygon(flatCoords []float64, offset int, ends []int, stride int) planar.Polygon {
+	p := make(planar.Polygon, 0, len(ends))
+	for i, end := range ends {
+		ring := planar.XYCoords(flatCoords, offset, end, stride)
+		offset = end
+		if len(ring) < 8 {
+			continue
+		}
+		// 线环的内部总是在边的左侧
+		if isClockwise(ring) != (i > 0) {
+			planar.Reverse(ring)
+		}
+		p = append(p, ring)
+	}
+	return p
+}
+
+// lineal 函数返回线或多线中的所有线
+func lineal(g geom.T) ([][]float64, bool) {
+	switch g := g.(type) {
+	case *geom.LineString:
+		return [][]float64{planar.XYCoords(g.FlatCoords(), 0, len(g.FlatCoords()), g.Stride())}, true
+	case *geom.MultiLineString:
+		lines := make([][]float64, len(g.Ends()))
+		offset := 0
+		for i, end := range g.Ends() {
+			lines[i] = planar.XYCoords(g.FlatCoords(), offset, end, g.Stride())
+			offset = end
+		}
+		return lines, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,33 @@
+package overlay_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy/overlay"
+)
+
+func ExampleIntersection() {
+	parcel := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, []int{10})
+	district := geom.NewPolygonFlat(geom.XY, []float64{5, 5, 15, 5, 15, 15, 5, 15, 5, 5}, []int{10})
+	g, err := overlay.Intersection(parcel, district)
+	if err != nil {
+		panic(err)
+	}
+	s, _ := wkt.Marshal(g)
+	fmt.Println(s)
+	// Output: POLYGON ((10 5, 10 10, 5 10, 5 5, 10 5))
+}
+
+func ExampleDifference() {
+	road := geom.NewLineStringFlat(geom.XY, []float64{-5, 5, 15, 5})
+	park := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, []int{10})
+	g, err := overlay.Difference(road, park)
+	if err != nil {
+		panic(err)
+	}
+	s, _ := wkt.Marshal(g)
+	fmt.Println(s)
+	// Output: MULTILINESTRING ((-5 5, 0 5), (10 5, 15 5))
+}
@@ -0,0 +1,320 @@
+package overlay_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/overlay"
+	"github.com/chengxiaoer/geomGo/xy/valid"
+)
+
+var ops = []struct {
+	name string
+	f    func(g1, g2 geom.T) (geom.T, error)
+}{
+	{"Intersection", overlay.Intersection},
+	{"Union", overlay.Union},
+	{"Difference", overlay.Difference},
+	{"SymDifference", overlay.SymDifference},
+}
+
+func TestOverlay(t *testing.T) {
+	for i, tc := range []struct {
+		desc string
+		a, b string
+		// 依次为交集、并集、差集和对称差集
+		expected [4]string
+	}{
+		{
+			desc: "overlapping squares",
+			a:    "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			b:    "POLYGON ((5 5, 15 5, 15 15, 5 15, 5 5))",
+			expected: [4]string{
+				"POLYGON ((10 5, 10 10, 5 10, 5 5, 10 5))",
+				"POLYGON ((0 0, 10 0, 10 5, 15 5, 15 15, 5 15, 5 10, 0 10, 0 0))",
+				"POLYGON ((0 0, 10 0, 10 5, 5 5, 5 10, 0 10, 0 0))",
+				"MULTIPOLYGON (((0 0, 10 0, 10 5, 5 5, 5 10, 0 10, 0 0)), ((10 10, 10 5, 15 5, 15 15, 5 15, 5 10, 10 10)))",
+			},
+		},
+		{
+			desc: "shared edge",
+			a:    "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			b:    "POLYGON ((10 0, 20 0, 20 10, 10 10, 10 0))",
+			expected: [4]string{
+				"POLYGON EMPTY",
+				"POLYGON ((0 0, 10 0, 20 0, 20 10, 10 10, 0 10, 0 0))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+				"POLYGON ((0 0, 10 0, 20 0, 20 10, 10 10, 0 10, 0 0))",
+			},
+		},
+		{
+			desc: "touching at a point",
+			a:    "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			b:    "POLYGON ((10 10, 20 10, 20 20, 10 20, 10 10))",
+			expected: [4]string{
+				"POLYGON EMPTY",
+				"MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0)), ((10 10, 20 10, 20 20, 10 20, 10 10)))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+				"MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0)), ((10 10, 20 10, 20 20, 10 20, 10 10)))",
+			},
+		},
+		{
+			desc: "contained",
+			a:    "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			b:    "POLYGON ((2 2, 8 2, 8 8, 2 8, 2 2))",
+			expected: [4]string{
+				"POLYGON ((2 2, 8 2, 8 8, 2 8, 2 2))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (8 2, 2 2, 2 8, 8 8, 8 2))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (8 2, 2 2, 2 8, 8 8, 8 2))",
+			},
+		},
+		{
+			desc: "filling a hole",
+			a:    "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 8 2, 8 8, 2 8, 2 2))",
+			b:    "POLYGON ((2 2, 8 2, 8 8, 2 8, 2 2))",
+			expected: [4]string{
+				"POLYGON EMPTY",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 8 2, 2 2))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			},
+		},
+		{
+			desc: "diamond touching the sides",
+			a:    "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			b:    "POLYGON ((0 5, 5 0, 10 5, 5 10, 0 5))",
+			expected: [4]string{
+				"POLYGON ((0 5, 5 0, 10 5, 5 10, 0 5))",
+				"POLYGON ((0 0, 5 0, 10 0, 10 5, 10 10, 5 10, 0 10, 0 5, 0 0))",
+				"MULTIPOLYGON (((0 0, 5 0, 0 5, 0 0)), ((5 0, 10 0, 10 5, 5 0)), ((10 5, 10 10, 5 10, 10 5)), ((5 10, 0 10, 0 5, 5 10)))",
+				"MULTIPOLYGON (((0 0, 5 0, 0 5, 0 0)), ((5 0, 10 0, 10 5, 5 0)), ((10 5, 10 10, 5 10, 10 5)), ((5 10, 0 10, 0 5, 5 10)))",
+			},
+		},
+		{
+			desc: "clockwise shell and empty polygon",
+			a:    "POLYGON ((0 0, 0 10, 10 10, 10 0, 0 0))",
+			b:    "POLYGON EMPTY",
+			expected: [4]string{
+				"POLYGON EMPTY",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+				"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+			},
+		},
+	} {
+		a, err := wkt.Unmarshal(tc.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := wkt.Unmarshal(tc.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, op := range ops {
+			g, err := op.f(a, b)
+			if err != nil {
+				t.Errorf("%d: %s: %s(%s, %s) == _, %v, want nil error", i, tc.desc, op.name, tc.a, tc.b, err)
+				continue
+			}
+			if got, err := wkt.Marshal(g); err != nil || got != tc.expected[j] {
+				t.Errorf("%d: %s: %s(%s, %s) == %s, %v, want %s", i, tc.desc, op.name, tc.a, tc.b, got, err, tc.expected[j])
+			}
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	polygon := "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 8 2, 8 8, 2 8, 2 2))"
+	for i, tc := range []struct {
+		line         string
+		intersection string
+		difference   string
+	}{
+		{
+			line:         "LINESTRING (-5 5, 15 5)",
+			intersection: "MULTILINESTRING ((0 5, 2 5), (8 5, 10 5))",
+			difference:   "MULTILINESTRING ((-5 5, 0 5), (2 5, 8 5), (10 5, 15 5))",
+		},
+		{
+			line:         "LINESTRING (-5 0, 5 0, 5 1)",
+			intersection: "LINESTRING (0 0, 5 0, 5 1)",
+			difference:   "LINESTRING (-5 0, 0 0)",
+		},
+		{
+			line:         "LINESTRING (20 20, 30 30)",
+			intersection: "LINESTRING EMPTY",
+			difference:   "LINESTRING (20 20, 30 30)",
+		},
+		{
+			line:         "MULTILINESTRING ((1 1, 1 9), (3 3, 7 7))",
+			intersection: "LINESTRING (1 1, 1 9)",
+			difference:   "LINESTRING (3 3, 7 7)",
+		},
+		{
+			line:         "LINESTRING (1 1, 1 9, 9 9, 9 1, 1 1)",
+			intersection: "LINESTRING (1 1, 1 9, 9 9, 9 1, 1 1)",
+			difference:   "LINESTRING EMPTY",
+		},
+	} {
+		l, err := wkt.Unmarshal(tc.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := wkt.Unmarshal(polygon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range []struct {
+			name     string
+			g        func() (geom.T, error)
+			expected string
+		}{
+			{"Intersection", func() (geom.T, error) { return overlay.Intersection(l, p) }, tc.intersection},
+			{"Intersection", func() (geom.T, error) { return overlay.Intersection(p, l) }, tc.intersection},
+			{"Difference", func() (geom.T, error) { return overlay.Difference(l, p) }, tc.difference},
+		} {
+			g, err := c.g()
+			if err != nil {
+				t.Errorf("%d: %s(%s) == _, %v, want nil error", i, c.name, tc.line, err)
+				continue
+			}
+			if got, _ := wkt.Marshal(g); got != c.expected {
+				t.Errorf("%d: %s(%s) == %s, want %s", i, c.name, tc.line, got, c.expected)
+			}
+		}
+	}
+}
+
+func TestUnsupportedType(t *testing.T) {
+	polygon := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8})
+	line := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1})
+	point := geom.NewPointFlat(geom.XY, []float64{0, 0})
+	for i, tc := range []struct {
+		f    func(g1, g2 geom.T) (geom.T, error)
+		a, b geom.T
+	}{
+		{overlay.Union, line, polygon},
+		{overlay.SymDifference, polygon, line},
+		{overlay.Difference, polygon, line},
+		{overlay.Intersection, line, line},
+		{overlay.Intersection, point, polygon},
+		{overlay.Union, polygon, point},
+	} {
+		if _, err := tc.f(tc.a, tc.b); err == nil {
+			t.Errorf("%d: want error", i)
+		} else if _, ok := err.(geom.ErrUnsupportedType); !ok {
+			t.Errorf("%d: got %v, want geom.ErrUnsupportedType", i, err)
+		}
+	}
+}
+
+func TestSRID(t *testing.T) {
+	a := geom.NewPolygonFlat(geom.XYZ, []float64{0, 0, 1, 2, 0, 1, 2, 2, 1, 0, 2, 1, 0, 0, 1}, []int{15}).SetSRID(4326)
+	b := geom.NewPolygonFlat(geom.XY, []float64{1, 1, 3, 1, 3, 3, 1, 3, 1, 1}, []int{10})
+	g, err := overlay.Intersection(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.SRID() != 4326 || g.Layout() != geom.XY {
+		t.Errorf("got SRID %d and layout %v, want 4326 and XY", g.SRID(), g.Layout())
+	}
+}
+
+// area 函数计算多边形的面积，不依赖线环的方向
+func area(g geom.T) float64 {
+	var endss [][]int
+	switch g := g.(type) {
+	case *geom.Polygon:
+		endss = [][]int{g.Ends()}
+	case *geom.MultiPolygon:
+		endss = g.Endss()
+	}
+	sum, offset := 0.0, 0
+	for _, ends := range endss {
+		for i, end := range ends {
+			a := math.Abs(xy.SignedArea(g.Layout(), g.FlatCoords()[offset:end]))
+			if i > 0 {
+				a = -a
+			}
+			sum += a
+			offset = end
+		}
+	}
+	return sum
+}
+
+// randomPolygon 函数返回以 (cx, cy) 为中心的星形多边形，grid 为 true 时坐标取整，以产生大量的重合点和重合边
+func randomPolygon(r *rand.Rand, cx, cy float64, grid bool) *geom.Polygon {
+	n := 3 + r.Intn(20)
+	var flatCoords []float64
+	for i := 0; i < n; i++ {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(n))
+		radius := 1 + 3*r.Float64()
+		x, y := cx+radius*cos, cy+radius*sin
+		if grid {
+			x, y = math.Round(x), math.Round(y)
+		}
+		if k := len(flatCoords); k > 0 && flatCoords[k-2] == x && flatCoords[k-1] == y {
+			continue
+		}
+		flatCoords = append(flatCoords, x, y)
+	}
+	flatCoords = append(flatCoords, flatCoords[0], flatCoords[1])
+	return geom.NewPolygonFlat(geom.XY, flatCoords, []int{len(flatCoords)})
+}
+
+// validPolygon 函数返回一个有效的随机多边形
+func validPolygon(r *rand.Rand, cx, cy float64, grid bool) *geom.Polygon {
+	for {
+		if p := randomPolygon(r, cx, cy, grid); isValid(p) {
+			return p
+		}
+	}
+}
+
+func isValid(g geom.T) bool {
+	ok, _ := valid.IsValid(g)
+	return ok
+}
+
+// TestRandom 检查随机多边形（包括前一次运算的结果）的运算结果是有效的，并且面积满足恒等式
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		grid := i%2 == 0
+		a, err := overlay.SymDifference(validPolygon(r, 0, 0, grid), validPolygon(r, float64(r.Intn(4)), float64(r.Intn(4)), grid))
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		b, err := overlay.Union(validPolygon(r, 1, 1, grid), validPolygon(r, float64(r.Intn(4)), float64(r.Intn(4)), grid))
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if !isValid(a) || !isValid(b) {
+			t.Fatalf("%d: invalid input", i)
+		}
+		var areas [4]float64
+		for j, op := range ops {
+			g, err := op.f(a, b)
+			if err != nil {
+				t.Fatalf("%d: %s: %v", i, op.name, err)
+			}
+			if ok, reason := valid.IsValid(g); !ok {
+				t.Errorf("%d: %s: invalid result: %v", i, op.name, reason)
+			}
+			areas[j] = area(g)
+		}
+		areaA, areaB := area(a), area(b)
+		tolerance := 1e-9 * (areaA + areaB)
+		if math.Abs(areas[1]-(areaA+areaB-areas[0])) > tolerance ||
+			math.Abs(areas[2]-(areaA-areas[0])) > tolerance ||
+			math.Abs(areas[3]-(areas[1]-areas[0])) > tolerance {
+			t.Errorf("%d: inconsistent areas %v for input areas %v and %v", i, areas, areaA, areaB)
+		}
+	}
+}
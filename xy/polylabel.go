@@ -0,0 +1,145 @@
+package xy
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// Polylabel函数 计算面状几何图形的不可达极点（pole of inaccessibility），即内部距离边界最远的点，返回该点（只包含 X 和 Y 坐标）和它到边界的距离。
+// 不可达极点是多边形标注的最佳位置，对于狭长或弯曲的多边形比 InteriorPoint 的结果更居中。
+// 使用 polylabel 算法：用正方形的网格覆盖多边形，按照每个格子可能达到的最大距离的顺序不断地细分，
+// 直到任何格子都不可能比当前的最优结果好 precision 以上。precision 不大于0时使用多边形范围的较长边的 1e-3 倍。
+// 多边形中存在距离相等的区域（例如矩形的中线）时，过小的 precision 会使格子的数目大量增加。
+// GeometryCollection 中的所有多边形一起参与计算，没有多边形时返回 InteriorPoint(g) 和0。
+// 不支持的类型返回 geom.ErrUnsupportedType
+func Polylabel(g geom.T, precision float64) (geom.Coord, float64, error) {
+	var parts interiorPointParts
+	if err := parts.add(g); err != nil {
+		return nil, 0, err
+	}
+	if len(parts.polygons) == 0 {
+		return parts.interiorPoint(), 0, nil
+	}
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range parts.polygons {
+		shell, stride := p.FlatCoords()[:p.Ends()[0]], p.Stride()
+		for i := 0; i < len(shell); i += stride {
+			minX, maxX = math.Min(minX, shell[i]), math.Max(maxX, shell[i])
+			minY, maxY = math.Min(minY, shell[i+1]), math.Max(maxY, shell[i+1])
+		}
+	}
+	width, height := maxX-minX, maxY-minY
+	if precision <= 0 {
+		precision = 1e-3 * math.Max(width, height)
+	}
+	interior := interiorPointArea(parts.polygons)
+	cellSize := math.Min(width, height)
+	if cellSize == 0 {
+		return interior, 0, nil
+	}
+
+	newCell := func(x, y, h float64) *polylabelCell {
+		d := signedDistanceToPolygons(parts.polygons, x, y)
+		return &polylabelCell{x: x, y: y, h: h, d: d, max: d + h*math.Sqrt2}
+	}
+	// InteriorPoint 的结果一定在多边形内部，作为最优结果的初始值
+	best := newCell(interior[0], interior[1], 0)
+	if c := newCell(minX+width/2, minY+height/2, 0); c.d > best.d {
+		best = c
+	}
+	var queue polylabelQueue
+	h := cellSize / 2
+	for x := minX; x < maxX; x += cellSize {
+		for y := minY; y < maxY; y += cellSize {
+			queue = append(queue, newCell(x+h, y+h, h))
+		}
+	}
+	heap.Init(&queue)
+	for queue.Len() > 0 {
+		c := heap.Pop(&queue).(*polylabelCell)
+		if c.d > best.d {
+			best = c
+		}
+		if c.max-best.d <= precision {
+			continue
+		}
+		h := c.h / 2
+		heap.Push(&queue, newCell(c.x-h, c.y-h, h))
+		heap.Push(&queue, newCell(c.x+h, c.y-h, h))
+		heap.Push(&queue, newCell(c.x-h, c.y+h, h))
+		heap.Push(&queue, newCell(c.x+h, c.y+h, h))
+	}
+	return geom.Coord{best.x, best.y}, best.d, nil
+}
+
+// polylabelCell 是中心为 (x, y)、边长的一半为 h 的正方形格子，
+// d 是中心到多边形边界的有向距离，max 是格子内的点可能达到的最大距离
+type polylabelCell struct {
+	x, y, h float64
+	d, max  float64
+}
+
+// polylabelQueue 是按照 max 从大到小排列的优先队列
+type polylabelQueue []*polylabelCell
+
+func (q polylabelQueue) Len() int { return len(q) }
+
+func (q polylabelQueue) Less(i, j int) bool { return q[i].max > q[j].max }
+
+func (q polylabelQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *polylabelQueue) Push(x interface{}) {
+	*q = append(*q, x.(*polylabelCell))
+}
+
+func (q *polylabelQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	c := old[n-1]
+	*q = old[:n-1]
+	return c
+}
+
+// signedDistanceToPolygons 函数返回点到所有多边形的线环的最短距离，点在多边形内部时为正，否则为负。
+// 使用奇偶规则判断点是否在内部
+func signedDistanceToPolygons(polygons []*geom.Polygon, x, y float64) float64 {
+	inside := false
+	minDist2 := math.Inf(1)
+	for _, p := range polygons {
+		flatCoords, stride := p.FlatCoords(), p.Stride()
+		offset := 0
+		for _, end := range p.Ends() {
+			for i := offset; i+stride < end; i += stride {
+				x0, y0 := flatCoords[i], flatCoords[i+1]
+				x1, y1 := flatCoords[i+stride], flatCoords[i+stride+1]
+				if (y0 > y) != (y1 > y) && x < (x1-x0)*(y-y0)/(y1-y0)+x0 {
+					inside = !inside
+				}
+				minDist2 = math.Min(minDist2, segmentDistance2(x, y, x0, y0, x1, y1))
+			}
+			offset = end
+		}
+	}
+	if inside {
+		return math.Sqrt(minDist2)
+	}
+	return -math.Sqrt(minDist2)
+}
+
+// segmentDistance2 函数返回点 (x, y) 到线段 (x0, y0)-(x1, y1) 的距离的平方
+func segmentDistance2(x, y, x0, y0, x1, y1 float64) float64 {
+	dx, dy := x1-x0, y1-y0
+	if dx != 0 || dy != 0 {
+		t := ((x-x0)*dx + (y-y0)*dy) / (dx*dx + dy*dy)
+		switch {
+		case t > 1:
+			x0, y0 = x1, y1
+		case t > 0:
+			x0, y0 = x0+dx*t, y0+dy*t
+		}
+	}
+	return (x-x0)*(x-x0) + (y-y0)*(y-y0)
+}
@@ -0,0 +1,111 @@
+package relate
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+// ErrInvalidPattern 表示 DE-9IM 模式不是由 T、F、*、0、1、2 组成的9个字符
+var ErrInvalidPattern = errors.New("relate: invalid DE-9IM pattern")
+
+// Dimension 是两个几何图形的内部、边界或外部的交集的维数
+type Dimension int
+
+const (
+	// DimFalse 表示交集为空
+	DimFalse Dimension = iota - 1
+	// Dim0 表示交集的维数为0，即由点组成
+	Dim0
+	// Dim1 表示交集的维数为1，即包含线
+	Dim1
+	// Dim2 表示交集的维数为2，即包含面
+	Dim2
+)
+
+// Symbol方法 返回维数在 DE-9IM 字符串中的字符
+func (d Dimension) Symbol() byte {
+	if d == DimFalse {
+		return 'F'
+	}
+	return byte('0' + d)
+}
+
+// IntersectionMatrix 是两个几何图形的 DE-9IM 交集矩阵，
+// 行和列分别使用第一个和第二个几何图形的 location.Interior、location.Boundary、location.Exterior 作为索引
+type IntersectionMatrix [3][3]Dimension
+
+func newIntersectionMatrix() IntersectionMatrix {
+	var im IntersectionMatrix
+	for i := range im {
+		for j := range im[i] {
+			im[i][j] = DimFalse
+		}
+	}
+	return im
+}
+
+// Get方法 返回第一个几何图形的位置 l1 与第二个几何图形的位置 l2 的交集的维数
+func (im IntersectionMatrix) Get(l1, l2 location.Type) Dimension {
+	return im[l1][l2]
+}
+
+// setAtLeast 方法将交集的维数更新为 d 和原有的维数中较大的一个
+func (im *IntersectionMatrix) setAtLeast(l1, l2 location.Type, d Dimension) {
+	if im[l1][l2] < d {
+		im[l1][l2] = d
+	}
+}
+
+// String方法 返回9个字符的 DE-9IM 字符串，例如 "212101212"
+func (im IntersectionMatrix) String() string {
+	b := make([]byte, 0, 9)
+	for i := range im {
+		for j := range im[i] {
+			b = append(b, im[i][j].Symbol())
+		}
+	}
+	return string(b)
+}
+
+// Transpose方法 返回交换两个几何图形之后的交集矩阵
+func (im IntersectionMatrix) Transpose() IntersectionMatrix {
+	var t IntersectionMatrix
+	for i := range im {
+		for j := range im[i] {
+			t[j][i] = im[i][j]
+		}
+	}
+	return t
+}
+
+// Matches方法 检测交集矩阵是否与 DE-9IM 模式匹配。
+// 模式中 T 匹配任意非空的交集，F 匹配空的交集，* 匹配任意值，0、1、2 匹配对应的维数，大小写均可
+func (im IntersectionMatrix) Matches(pattern string) (bool, error) {
+	if len(pattern) != 9 {
+		return false, ErrInvalidPattern
+	}
+	result := true
+	for k, c := range strings.ToUpper(pattern) {
+		d := im[k/3][k%3]
+		switch c {
+		case 'T':
+			result = result && d != DimFalse
+		case 'F':
+			result = result && d == DimFalse
+		case '*':
+		case '0', '1', '2':
+			result = result && d == Dimension(c-'0')
+		default:
+			return false, ErrInvalidPattern
+		}
+	}
+	return result, nil
+}
+
+// matches 方法与 Matches 相同，用于内部固定的模式
+func (im IntersectionMatrix) matches(pattern string) bool {
+	ok, _ := im.Matches(pattern)
+	return ok
+}
@@ -0,0 +1,125 @@
+package relate
+
+import (
+	"github.com/chengxiaoer/geomGo"
+)
+
+// predicate 是根据交集矩阵和两个几何图形的维数计算的空间谓词
+type predicate func(im IntersectionMatrix, d1, d2 Dimension) bool
+
+func evaluate(g1, g2 geom.T, p predicate) (bool, error) {
+	a, err := newFacets(g1)
+	if err != nil {
+		return false, err
+	}
+	b, err := newFacets(g2)
+	if err != nil {
+		return false, err
+	}
+	im, err := computeMatrix(a, b)
+	if err != nil {
+		return false, err
+	}
+	return p(im, a.dimension, b.dimension), nil
+}
+
+// Intersects函数 检测两个几何图形是否有公共点
+func Intersects(g1, g2 geom.T) (bool, error) {
+	return evaluate(g1, g2, isIntersects)
+}
+
+// Disjoint函数 检测两个几何图形是否没有公共点
+func Disjoint(g1, g2 geom.T) (bool, error) {
+	intersects, err := Intersects(g1, g2)
+	return !intersects && err == nil, err
+}
+
+// Contains函数 检测 g1 是否包含 g2，即 g2 的所有点都在 g1 之内并且两者的内部有公共点
+func Contains(g1, g2 geom.T) (bool, error) {
+	return evaluate(g1, g2, isContains)
+}
+
+// Within函数 检测 g1 是否位于 g2 之内，与 Contains(g2, g1) 相同
+func Within(g1, g2 geom.T) (bool, error) {
+	return Contains(g2, g1)
+}
+
+// Covers函数 检测 g2 的所有点是否都在 g1 之内（包括边界）。与 Contains 不同，g2 可以只位于 g1 的边界上
+func Covers(g1, g2 geom.T) (bool, error) {
+	return evaluate(g1, g2, isCovers)
+}
+
+// CoveredBy函数 检测 g1 的所有点是否都在 g2 之内，与 Covers(g2, g1) 相同
+func CoveredBy(g1, g2 geom.T) (bool, error) {
+	return Covers(g2, g1)
+}
+
+// Touches函数 检测两个几何图形是否只在边界上接触，即有公共点但内部没有公共点。两个几何图形都是点时总是返回 false
+func Touches(g1, g2 geom.T) (bool, error) {
+	return evaluate(g1, g2, isTouches)
+}
+
+// Crosses函数 检测两个几何图形是否交叉，即内部有公共点并且公共部分的维数小于两者中较大的维数。
+// 只适用于点和线、点和面、线和面以及线和线，其他情况返回 false
+func Crosses(g1, g2 geom.T) (bool, error) {
+	return evaluate(g1, g2, isCrosses)
+}
+
+// Overlaps函数 检测两个维数相同的几何图形是否重叠，即公共部分与两者的维数相同但互不包含
+func Overlaps(g1, g2 geom.T) (bool, error) {
+	return evaluate(g1, g2, isOverlaps)
+}
+
+// Equals函数 检测两个几何图形在拓扑上是否相等，即点集相同，与坐标的顺序和重复的点无关。
+// 两个空的几何图形的点集都是空集，因此相等
+func Equals(g1, g2 geom.T) (bool, error) {
+	return evaluate(g1, g2, isEquals)
+}
+
+func isIntersects(im IntersectionMatrix, _, _ Dimension) bool {
+	return im.matches("T********") || im.matches("*T*******") || im.matches("***T*****") || im.matches("****T****")
+}
+
+func isContains(im IntersectionMatrix, _, _ Dimension) bool {
+	return im.matches("T*****FF*")
+}
+
+func isCovers(im IntersectionMatrix, _, _ Dimension) bool {
+	return im.matches("T*****FF*") || im.matches("*T****FF*") || im.matches("***T**FF*") || im.matches("****T*FF*")
+}
+
+func isTouches(im IntersectionMatrix, d1, d2 Dimension) bool {
+	if d1 == Dim0 && d2 == Dim0 {
+		return false
+	}
+	return im.matches("FT*******") || im.matches("F**T*****") || im.matches("F***T****")
+}
+
+func isCrosses(im IntersectionMatrix, d1, d2 Dimension) bool {
+	switch {
+	case d1 == Dim1 && d2 == Dim1:
+		return im.matches("0********")
+	case d1 < d2:
+		return im.matches("T*T******")
+	case d1 > d2:
+		return im.matches("T*****T**")
+	}
+	return false
+}
+
+func isOverlaps(im IntersectionMatrix, d1, d2 Dimension) bool {
+	switch {
+	case d1 != d2:
+		return false
+	case d1 == Dim1:
+		return im.matches("1*T***T**")
+	}
+	return im.matches("T*T***T**")
+}
+
+func isEquals(im IntersectionMatrix, d1, d2 Dimension) bool {
+	if d1 == DimFalse && d2 == DimFalse {
+		return true
+	}
+	return im.matches("T*F**FFF*")
+}
@@ -0,0 +1,336 @@
+// Package relate 包含了计算两个平面（XY）几何图形的 DE-9IM 交集矩阵和空间谓词的函数，语义与 PostGIS 相同。
+//
+// 计算时在所有交点处分割两个几何图形的线段，分割之后的每个结点和每条边相对于两个几何图形的位置都是确定的，
+// 由此得到交集矩阵中维数为0和1的部分；两个几何图形的内部和外部之间维数为2的交集使用 overlay 包计算。
+// 线的边界使用 mod-2 规则，即只属于奇数条线的端点位于边界上，闭合的线没有边界。
+// 输入的几何图形必须是有效的，只使用坐标的 x、y 值
+package relate
+
+import (
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/internal/noding"
+	"github.com/chengxiaoer/geomGo/xy/internal/planar"
+	"github.com/chengxiaoer/geomGo/xy/location"
+	"github.com/chengxiaoer/geomGo/xy/overlay"
+)
+
+// Matrix函数 计算两个几何图形的 DE-9IM 交集矩阵
+func Matrix(g1, g2 geom.T) (IntersectionMatrix, error) {
+	a, err := newFacets(g1)
+	if err != nil {
+		return IntersectionMatrix{}, err
+	}
+	b, err := newFacets(g2)
+	if err != nil {
+		return IntersectionMatrix{}, err
+	}
+	return computeMatrix(a, b)
+}
+
+// Relate函数 检测两个几何图形的 DE-9IM 交集矩阵是否与模式匹配，与 PostGIS 的 ST_Relate(g1, g2, pattern) 相同
+func Relate(g1, g2 geom.T, pattern string) (bool, error) {
+	im, err := Matrix(g1, g2)
+	if err != nil {
+		return false, err
+	}
+	return im.Matches(pattern)
+}
+
+// facets 是几何图形分解之后的点、线和多边形，所有的坐标都是 x、y 坐标
+type facets struct {
+	dimension Dimension
+	points    []geom.Coord
+	lines     [][]float64
+	polygons  []planar.Polygon
+}
+
+func newFacets(g geom.T) (*facets, error) {
+	f := &facets{dimension: DimFalse}
+	if err := f.add(g); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *facets) add(g geom.T) error {
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		for i := 0; i < len(g.FlatCoords()); i += g.Stride() {
+			f.points = append(f.points, geom.Coord{g.FlatCoords()[i], g.FlatCoords()[i+1]})
+			f.setDimension(Dim0)
+		}
+	case *geom.LineString, *geom.LinearRing:
+		f.addLine(planar.XYCoords(g.FlatCoords(), 0, len(g.FlatCoords()), g.Stride()))
+	case *geom.MultiLineString:
+		offset := 0
+		for _, end := range g.Ends() {
+			f.addLine(planar.XYCoords(g.FlatCoords(), offset, end, g.Stride()))
+			offset = end
+		}
+	case *geom.Polygon:
+		f.addPolygon(g.FlatCoords(), 0, g.Ends(), g.Stride())
+	case *geom.MultiPolygon:
+		offset := 0
+		for _, ends := range g.Endss() {
+			f.addPolygon(g.FlatCoords(), offset, ends, g.Stride())
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if err := f.add(child); err != nil {
+				return err
+			}
+		}
+	default:
+		return geom.ErrUnsupportedType{Value: g}
+	}
+	return nil
+}
+
+func (f *facets) addLine(line []float64) {
+	switch len(line) {
+	case 0:
+		return
+	case 2:
+		// 只有一个点的线按照点处理
+		f.points = append(f.points, geom.Coord(line))
+		f.setDimension(Dim0)
+	default:
+		f.lines = append(f.lines, line)
+		f.setDimension(Dim1)
+	}
+}
+
+func (f *facets) addPolygon(flatCoords []float64, offset int, ends []int, stride int) {
+	var rings [][]float64
+	for _, end := range ends {
+		rings = append(rings, planar.XYCoords(flatCoords, offset, end, stride))
+		offset = end
+	}
+	if len(rings) == 0 || len(rings[0]) < 8 {
+		return
+	}
+	f.polygons = append(f.polygons, rings)
+	f.setDimension(Dim2)
+}
+
+func (f *facets) setDimension(d Dimension) {
+	if f.dimension < d {
+		f.dimension = d
+	}
+}
+
+// polygonal 方法返回由所有多边形组成的多多边形，用于计算维数为2的交集
+func (f *facets) polygonal() *geom.MultiPolygon {
+	var flatCoords []float64
+	endss := make([][]int, len(f.polygons))
+	for i, rings := range f.polygons {
+		for _, ring := range rings {
+			flatCoords = append(flatCoords, ring...)
+			endss[i] = append(endss[i], len(flatCoords))
+		}
+	}
+	return geom.NewMultiPolygonFlat(geom.XY, flatCoords, endss)
+}
+
+type nodeKey [2]float64
+
+// node 是分割之后的结点，记录结点是否位于每个几何图形的线、线环、线的边界上或者与点重合
+type node struct {
+	c            geom.Coord
+	onLine       [2]bool
+	onRing       [2]bool
+	lineEndpoint [2]int
+	point        [2]bool
+}
+
+type edgeKey [4]float64
+
+func newEdgeKey(p0, p1 geom.Coord) edgeKey {
+	if p1[0] < p0[0] || p1[0] == p0[0] && p1[1] < p0[1] {
+		p0, p1 = p1, p0
+	}
+	return edgeKey{p0[0], p0[1], p1[0], p1[1]}
+}
+
+// edge 是分割之后的边，记录边是否位于每个几何图形的线或线环上
+type edge struct {
+	p0, p1 geom.Coord
+	onLine [2]bool
+	onRing [2]bool
+}
+
+// segmentType 是分割之前的线段的类型
+type segmentType int
+
+const (
+	lineSegment segmentType = iota
+	ringSegment
+	// pointSegment 是由点构成的退化线段，使其他线段在该点处被分割
+	pointSegment
+)
+
+// segmentSource 记录分割之前的线段所属的几何图形和线段的类型，first 和 last 表示线段是线的第一条和最后一条线段
+type segmentSource struct {
+	source      int
+	typ         segmentType
+	first, last bool
+}
+
+// planarGraph 是两个几何图形的线段在所有交点处分割之后组成的平面图
+type planarGraph struct {
+	nodes map[nodeKey]*node
+	edges map[edgeKey]*edge
+}
+
+func (g *planarGraph) node(c geom.Coord) *node {
+	key := nodeKey{c[0], c[1]}
+	n, ok := g.nodes[key]
+	if !ok {
+		n = &node{c: c}
+		g.nodes[key] = n
+	}
+	return n
+}
+
+func newPlanarGraph(f [2]*facets) *planarGraph {
+	var segs [][2]geom.Coord
+	var sources []segmentSource
+	addSegments := func(source int, coords []float64, typ segmentType) {
+		for i := 2; i < len(coords); i += 2 {
+			segs = append(segs, [2]geom.Coord{coords[i-2 : i], coords[i : i+2]})
+			sources = append(sources, segmentSource{source: source, typ: typ, first: i == 2, last: i == len(coords)-2})
+		}
+	}
+	for s := range f {
+		for _, p := range f[s].points {
+			addSegments(s, []float64{p[0], p[1], p[0], p[1]}, pointSegment)
+		}
+		for _, line := range f[s].lines {
+			addSegments(s, line, lineSegment)
+		}
+		for _, rings := range f[s].polygons {
+			for _, ring := range rings {
+				addSegments(s, ring, ringSegment)
+			}
+		}
+	}
+	paths := noding.Node(segs)
+
+	g := &planarGraph{nodes: make(map[nodeKey]*node), edges: make(map[edgeKey]*edge)}
+	for i, path := range paths {
+		src := sources[i]
+		for j, c := range path {
+			n := g.node(c)
+			switch src.typ {
+			case pointSegment:
+				n.point[src.source] = true
+			case ringSegment:
+				n.onRing[src.source] = true
+			default:
+				n.onLine[src.source] = true
+				if src.first && j == 0 || src.last && j == len(path)-1 {
+					n.lineEndpoint[src.source]++
+				}
+			}
+			if j == 0 || src.typ == pointSegment {
+				continue
+			}
+			key := newEdgeKey(path[j-1], c)
+			e, ok := g.edges[key]
+			if !ok {
+				e = &edge{p0: path[j-1], p1: c}
+				g.edges[key] = e
+			}
+			if src.typ == ringSegment {
+				e.onRing[src.source] = true
+			} else {
+				e.onLine[src.source] = true
+			}
+		}
+	}
+	return g
+}
+
+// locateNode 函数计算结点相对于第 s 个几何图形的位置
+func locateNode(n *node, f *facets, s int) location.Type {
+	if n.onRing[s] {
+		return location.Boundary
+	}
+	if len(f.polygons) > 0 {
+		if loc := planar.Locate(f.polygons, n.c); loc != location.Exterior {
+			return loc
+		}
+	}
+	switch {
+	case n.lineEndpoint[s]%2 == 1:
+		return location.Boundary
+	case n.onLine[s], n.point[s]:
+		return location.Interior
+	}
+	return location.Exterior
+}
+
+// locateEdge 函数计算边的内部相对于第 s 个几何图形的位置
+func locateEdge(e *edge, f *facets, s int) location.Type {
+	if e.onRing[s] {
+		return location.Boundary
+	}
+	if len(f.polygons) > 0 && planar.LocateEdge(f.polygons, e.p0, e.p1) == location.Interior {
+		return location.Interior
+	}
+	if e.onLine[s] {
+		return location.Interior
+	}
+	return location.Exterior
+}
+
+func computeMatrix(a, b *facets) (IntersectionMatrix, error) {
+	im := newIntersectionMatrix()
+	im[location.Exterior][location.Exterior] = Dim2
+	f := [2]*facets{a, b}
+	g := newPlanarGraph(f)
+	for _, n := range g.nodes {
+		im.setAtLeast(locateNode(n, a, 0), locateNode(n, b, 1), Dim0)
+	}
+	for _, e := range g.edges {
+		im.setAtLeast(locateEdge(e, a, 0), locateEdge(e, b, 1), Dim1)
+	}
+	if err := computeAreas(&im, a, b); err != nil {
+		return IntersectionMatrix{}, err
+	}
+	return im, nil
+}
+
+// computeAreas 函数计算两个几何图形的内部和外部之间维数为2的交集
+func computeAreas(im *IntersectionMatrix, a, b *facets) error {
+	aArea, bArea := len(a.polygons) > 0, len(b.polygons) > 0
+	switch {
+	case aArea && bArea:
+		pa, pb := a.polygonal(), b.polygonal()
+		for _, c := range []struct {
+			l1, l2 location.Type
+			f      func(g1, g2 geom.T) (geom.T, error)
+			g1, g2 geom.T
+		}{
+			{location.Interior, location.Interior, overlay.Intersection, pa, pb},
+			{location.Interior, location.Exterior, overlay.Difference, pa, pb},
+			{location.Exterior, location.Interior, overlay.Difference, pb, pa},
+		} {
+			result, err := c.f(c.g1, c.g2)
+			if err != nil {
+				return err
+			}
+			if len(result.FlatCoords()) > 0 {
+				im.setAtLeast(c.l1, c.l2, Dim2)
+			}
+		}
+	case aArea:
+		im.setAtLeast(location.Interior, location.Exterior, Dim2)
+	case bArea:
+		im.setAtLeast(location.Exterior, location.Interior, Dim2)
+	}
+	return nil
+}
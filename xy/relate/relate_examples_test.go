@@ -0,0 +1,26 @@
+package relate_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/relate"
+)
+
+func ExampleMatrix() {
+	a := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, []int{10})
+	b := geom.NewPolygonFlat(geom.XY, []float64{5, 5, 15, 5, 15, 15, 5, 15, 5, 5}, []int{10})
+	im, err := relate.Matrix(a, b)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(im)
+	// Output: 212101212
+}
+
+func ExampleContains() {
+	parcel := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, []int{10})
+	site := geom.NewPointFlat(geom.XY, []float64{5, 5})
+	fmt.Println(relate.Contains(parcel, site))
+	// Output: true <nil>
+}
@@ -0,0 +1,142 @@
+package relate_test
+
+import (
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy/relate"
+)
+
+const square = "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))"
+
+func mustUnmarshal(t *testing.T, s string) geom.T {
+	g, err := wkt.Unmarshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestMatrix(t *testing.T) {
+	for i, tc := range []struct {
+		desc     string
+		g1, g2   string
+		expected string
+	}{
+		{"equal points", "POINT (0 0)", "POINT (0 0)", "0FFFFFFF2"},
+		{"disjoint points", "POINT (0 0)", "POINT (1 1)", "FF0FFF0F2"},
+		{"point in line", "POINT (5 0)", "LINESTRING (0 0, 10 0)", "0FFFFF102"},
+		{"point at line end", "POINT (0 0)", "LINESTRING (0 0, 10 0)", "F0FFFF102"},
+		{"point on closed line", "LINESTRING (0 0, 10 0, 10 10, 0 0)", "POINT (0 0)", "0F1FFFFF2"},
+		{"mod-2 boundary rule", "MULTILINESTRING ((0 0, 5 0), (5 0, 10 0))", "POINT (5 0)", "0F1FF0FF2"},
+		{"point in polygon", "POINT (5 5)", square, "0FFFFF212"},
+		{"point on polygon boundary", "POINT (0 5)", square, "F0FFFF212"},
+		{"point in hole", "POINT (5 5)", "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 8 2, 8 8, 2 8, 2 2))", "FF0FFF212"},
+		{"crossing lines", "LINESTRING (0 0, 10 10)", "LINESTRING (0 10, 10 0)", "0F1FF0102"},
+		{"overlapping lines", "LINESTRING (0 0, 10 0)", "LINESTRING (5 0, 15 0)", "1010F0102"},
+		{"touching lines", "LINESTRING (0 0, 10 0)", "LINESTRING (10 0, 20 0)", "FF1F00102"},
+		{"equal lines", "LINESTRING (0 0, 10 0)", "LINESTRING (10 0, 5 0, 0 0)", "1FFF0FFF2"},
+		{"line crossing polygon", "LINESTRING (-5 5, 15 5)", square, "101FF0212"},
+		{"line in polygon", "LINESTRING (2 2, 8 8)", square, "1FF0FF212"},
+		{"line on polygon boundary", "LINESTRING (0 0, 10 0)", square, "F1FF0F212"},
+		{"line touching polygon", "LINESTRING (10 5, 20 5)", square, "FF1F00212"},
+		{"overlapping polygons", square, "POLYGON ((5 5, 15 5, 15 15, 5 15, 5 5))", "212101212"},
+		{"polygons sharing an edge", square, "POLYGON ((10 0, 20 0, 20 10, 10 10, 10 0))", "FF2F11212"},
+		{"polygons touching at a point", square, "POLYGON ((10 10, 20 10, 20 20, 10 20, 10 10))", "FF2F01212"},
+		{"polygon in polygon", square, "POLYGON ((2 2, 8 2, 8 8, 2 8, 2 2))", "212FF1FF2"},
+		{"equal polygons", square, "POLYGON ((0 0, 0 10, 10 10, 10 0, 0 0))", "2FFF1FFF2"},
+		{"polygon in hole", "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 8 2, 8 8, 2 8, 2 2))", "POLYGON ((3 3, 7 3, 7 7, 3 7, 3 3))", "FF2FF1212"},
+		{"XYZ", "POINT Z (5 5 100)", "POLYGON Z ((0 0 1, 10 0 2, 10 10 3, 0 10 4, 0 0 1))", "0FFFFF212"},
+		{"empty", "POINT EMPTY", square, "FFFFFF212"},
+	} {
+		g1, g2 := mustUnmarshal(t, tc.g1), mustUnmarshal(t, tc.g2)
+		im, err := relate.Matrix(g1, g2)
+		if err != nil {
+			t.Errorf("%d: %s: Matrix(%s, %s) == _, %v, want nil error", i, tc.desc, tc.g1, tc.g2, err)
+			continue
+		}
+		if got := im.String(); got != tc.expected {
+			t.Errorf("%d: %s: Matrix(%s, %s) == %s, want %s", i, tc.desc, tc.g1, tc.g2, got, tc.expected)
+		}
+		im, err = relate.Matrix(g2, g1)
+		if err != nil {
+			t.Errorf("%d: %s: Matrix(%s, %s) == _, %v, want nil error", i, tc.desc, tc.g2, tc.g1, err)
+			continue
+		}
+		if got, want := im.Transpose().String(), tc.expected; got != want {
+			t.Errorf("%d: %s: Matrix(%s, %s).Transpose() == %s, want %s", i, tc.desc, tc.g2, tc.g1, got, want)
+		}
+	}
+}
+
+func TestRelate(t *testing.T) {
+	g1 := mustUnmarshal(t, square)
+	g2 := mustUnmarshal(t, "POLYGON ((5 5, 15 5, 15 15, 5 15, 5 5))")
+	for i, tc := range []struct {
+		pattern  string
+		expected bool
+		err      error
+	}{
+		{"212101212", true, nil},
+		{"T*T***T**", true, nil},
+		{"t*t***t**", true, nil},
+		{"*********", true, nil},
+		{"FF*FF****", false, nil},
+		{"2121012120", false, relate.ErrInvalidPattern},
+		{"21210121X", false, relate.ErrInvalidPattern},
+	} {
+		if got, err := relate.Relate(g1, g2, tc.pattern); got != tc.expected || err != tc.err {
+			t.Errorf("%d: Relate(_, _, %q) == %v, %v, want %v, %v", i, tc.pattern, got, err, tc.expected, tc.err)
+		}
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	type predicates struct {
+		intersects, contains, within, covers, coveredBy, touches, crosses, overlaps, equals bool
+	}
+	for i, tc := range []struct {
+		g1, g2   string
+		expected predicates
+	}{
+		{"POINT (5 5)", square, predicates{intersects: true, within: true, coveredBy: true}},
+		{"POINT (0 5)", square, predicates{intersects: true, coveredBy: true, touches: true}},
+		{"POINT (20 20)", square, predicates{}},
+		{"MULTIPOINT ((5 5), (20 20))", square, predicates{intersects: true, crosses: true}},
+		{"LINESTRING (-5 5, 15 5)", square, predicates{intersects: true, crosses: true}},
+		{"LINESTRING (0 0, 10 0)", square, predicates{intersects: true, coveredBy: true, touches: true}},
+		{"LINESTRING (0 0, 10 10)", "LINESTRING (0 10, 10 0)", predicates{intersects: true, crosses: true}},
+		{"LINESTRING (0 0, 10 0)", "LINESTRING (5 0, 15 0)", predicates{intersects: true, overlaps: true}},
+		{"LINESTRING (0 0, 10 0)", "LINESTRING (0 0, 5 0, 10 0)", predicates{intersects: true, contains: true, within: true, covers: true, coveredBy: true, equals: true}},
+		{square, "POLYGON ((5 5, 15 5, 15 15, 5 15, 5 5))", predicates{intersects: true, overlaps: true}},
+		{square, "POLYGON ((10 0, 20 0, 20 10, 10 10, 10 0))", predicates{intersects: true, touches: true}},
+		{square, "POLYGON ((0 0, 5 0, 5 5, 0 5, 0 0))", predicates{intersects: true, contains: true, covers: true}},
+		{square, "GEOMETRYCOLLECTION (POINT (1 1), LINESTRING (2 2, 3 3))", predicates{intersects: true, contains: true, covers: true}},
+		{square, "POLYGON EMPTY", predicates{}},
+		{"POLYGON EMPTY", "POLYGON EMPTY", predicates{equals: true}},
+		{"POINT EMPTY", "GEOMETRYCOLLECTION EMPTY", predicates{equals: true}},
+	} {
+		g1, g2 := mustUnmarshal(t, tc.g1), mustUnmarshal(t, tc.g2)
+		for _, p := range []struct {
+			name     string
+			f        func(g1, g2 geom.T) (bool, error)
+			expected bool
+		}{
+			{"Intersects", relate.Intersects, tc.expected.intersects},
+			{"Disjoint", relate.Disjoint, !tc.expected.intersects},
+			{"Contains", relate.Contains, tc.expected.contains},
+			{"Within", relate.Within, tc.expected.within},
+			{"Covers", relate.Covers, tc.expected.covers},
+			{"CoveredBy", relate.CoveredBy, tc.expected.coveredBy},
+			{"Touches", relate.Touches, tc.expected.touches},
+			{"Crosses", relate.Crosses, tc.expected.crosses},
+			{"Overlaps", relate.Overlaps, tc.expected.overlaps},
+			{"Equals", relate.Equals, tc.expected.equals},
+		} {
+			if got, err := p.f(g1, g2); got != p.expected || err != nil {
+				t.Errorf("%d: %s(%s, %s) == %v, %v, want %v, nil", i, p.name, tc.g1, tc.g2, got, err, p.expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package xy
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// HausdorffDistance函数 计算两个几何图形之间的离散 Hausdorff 距离，返回距离以及实现该距离的两个点（只包含 X 和 Y 坐标），第一个点在 g1 上，第二个点在 g2 上。
+// Hausdorff 距离是一个几何图形上的点到另一个几何图形的最短距离的最大值（两个方向中较大的一个），用于衡量两个形状的相似程度。
+// 离散的算法只计算顶点到另一个几何图形的距离，位于多边形内部的点的距离为0。
+// 可选的 densifyFrac 在 (0, 1) 之间时，每条线段被等分为 ceil(1/densifyFrac) 段并且计算等分点的距离，结果更接近连续的 Hausdorff 距离。
+// 任意一个几何图形为空时返回 math.Inf(1), nil, nil，不支持的类型返回 geom.ErrUnsupportedType
+func HausdorffDistance(g1, g2 geom.T, densifyFrac ...float64) (float64, geom.Coord, geom.Coord, error) {
+	var f1, f2 distanceFacets
+	if err := f1.add(g1); err != nil {
+		return 0, nil, nil, err
+	}
+	if err := f2.add(g2); err != nil {
+		return 0, nil, nil, err
+	}
+	if f1.isEmpty() || f2.isEmpty() {
+		return math.Inf(1), nil, nil, nil
+	}
+	frac := optionalDensifyFrac(densifyFrac)
+	distance := -1.0
+	var p1, p2 geom.Coord
+	for _, p := range f1.samples(frac) {
+		if d, q := distanceToFacets(p, &f2); d > distance {
+			distance, p1, p2 = d, copyXY(p), q
+		}
+	}
+	for _, p := range f2.samples(frac) {
+		if d, q := distanceToFacets(p, &f1); d > distance {
+			distance, p1, p2 = d, q, copyXY(p)
+		}
+	}
+	return distance, p1, p2, nil
+}
+
+// FrechetDistance函数 计算两条线之间的离散 Fréchet 距离，返回距离以及实现该距离的两个点（只包含 X 和 Y 坐标），第一个点在 ls1 上，第二个点在 ls2 上。
+// 与 Hausdorff 距离不同，Fréchet 距离考虑了点的顺序：两个点分别沿着两条线从起点单调地移动到终点，Fréchet 距离是所有移动方式中两个点之间的最大距离的最小值，
+// 因此适合比较轨迹。使用 Eiter 和 Mannila 的动态规划算法，时间复杂度为 O(nm)，空间复杂度为 O(m)。
+// 离散的算法只使用顶点，可选的 densifyFrac 在 (0, 1) 之间时，每条线段被等分为 ceil(1/densifyFrac) 段，结果是连续 Fréchet 距离的近似值。
+// 任意一条线为空时返回 math.Inf(1), nil, nil
+func FrechetDistance(ls1, ls2 *geom.LineString, densifyFrac ...float64) (float64, geom.Coord, geom.Coord) {
+	frac := optionalDensifyFrac(densifyFrac)
+	a := densify(ls1.FlatCoords(), ls1.Stride(), frac)
+	b := densify(ls2.FlatCoords(), ls2.Stride(), frac)
+	if len(a) == 0 || len(b) == 0 {
+		return math.Inf(1), nil, nil
+	}
+	// prev[j] 和 curr[j] 是前一行和当前行的耦合距离，pairs 记录实现该距离的顶点对
+	prev, curr := make([]float64, len(b)), make([]float64, len(b))
+	prevPairs, currPairs := make([][2]int, len(b)), make([][2]int, len(b))
+	for i := range a {
+		for j := range b {
+			d := math.Hypot(a[i][0]-b[j][0], a[i][1]-b[j][1])
+			if i == 0 && j == 0 {
+				curr[j], currPairs[j] = d, [2]int{0, 0}
+				continue
+			}
+			// 从左边、下边和左下的格子中选择耦合距离最小的一个
+			minDist, pair := math.Inf(1), [2]int{}
+			if i > 0 && j > 0 && prev[j-1] < minDist {
+				minDist, pair = prev[j-1], prevPairs[j-1]
+			}
+			if i > 0 && prev[j] < minDist {
+				minDist, pair = prev[j], prevPairs[j]
+			}
+			if j > 0 && curr[j-1] < minDist {
+				minDist, pair = curr[j-1], currPairs[j-1]
+			}
+			// 距离相等时保留较早的顶点对
+			if d > minDist {
+				curr[j], currPairs[j] = d, [2]int{i, j}
+			} else {
+				curr[j], currPairs[j] = minDist, pair
+			}
+		}
+		prev, curr = curr, prev
+		prevPairs, currPairs = currPairs, prevPairs
+	}
+	pair := prevPairs[len(b)-1]
+	return prev[len(b)-1], a[pair[0]], b[pair[1]]
+}
+
+// optionalDensifyFrac 函数返回可选参数中的加密比例，没有参数时返回0
+func optionalDensifyFrac(densifyFrac []float64) float64 {
+	if len(densifyFrac) == 0 {
+		return 0
+	}
+	return densifyFrac[0]
+}
+
+// distanceToFacets 函数返回点到几何图形的组成部分的最短距离和几何图形上最近的点
+func distanceToFacets(p geom.Coord, f *distanceFacets) (float64, geom.Coord) {
+	op := &distanceOp{minDistance: math.Inf(1)}
+	op.facets[0].points = []geom.Coord{p}
+	op.facets[1] = *f
+	op.compute()
+	return op.minDistance, op.nearest[1]
+}
+
+// samples 方法返回所有的点和线的顶点，frac 在 (0, 1) 之间时还包括线段的等分点
+func (f *distanceFacets) samples(frac float64) []geom.Coord {
+	coords := append([]geom.Coord(nil), f.points...)
+	for _, l := range f.lines {
+		coords = append(coords, densify(l.flatCoords, l.stride, frac)...)
+	}
+	return coords
+}
+
+// densify 函数返回线的顶点的 X 和 Y 坐标，frac 在 (0, 1) 之间时在每条线段中按顺序插入 ceil(1/frac)-1 个等分点
+func densify(flatCoords []float64, stride int, frac float64) []geom.Coord {
+	n := 1
+	if frac > 0 && frac < 1 {
+		n = int(math.Ceil(1 / frac))
+	}
+	if len(flatCoords) == 0 {
+		return nil
+	}
+	coords := make([]geom.Coord, 0, (len(flatCoords)/stride-1)*n+1)
+	for i := 0; i < len(flatCoords); i += stride {
+		if i > 0 {
+			x0, y0 := flatCoords[i-stride], flatCoords[i-stride+1]
+			dx, dy := flatCoords[i]-x0, flatCoords[i+1]-y0
+			for k := 1; k < n; k++ {
+				t := float64(k) / float64(n)
+				coords = append(coords, geom.Coord{x0 + t*dx, y0 + t*dy})
+			}
+		}
+		coords = append(coords, geom.Coord{flatCoords[i], flatCoords[i+1]})
+	}
+	return coords
+}
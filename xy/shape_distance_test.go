@@ -0,0 +1,219 @@
+package xy_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+)
+
+func TestHausdorffDistance(t *testing.T) {
+	for i, tc := range []struct {
+		desc        string
+		g1, g2      string
+		densifyFrac []float64
+		distance    float64
+		points      [2]geom.Coord
+	}{
+		{
+			desc:     "segments",
+			g1:       "LINESTRING (0 0, 2 1)",
+			g2:       "LINESTRING (0 0, 2 0)",
+			distance: 1,
+			points:   [2]geom.Coord{{2, 1}, {2, 0}},
+		},
+		{
+			desc:     "segment and line",
+			g1:       "LINESTRING (0 0, 2 0)",
+			g2:       "LINESTRING (0 1, 1 2, 2 1)",
+			distance: 2,
+			points:   [2]geom.Coord{{1, 0}, {1, 2}},
+		},
+		{
+			desc:     "discreteness",
+			g1:       "LINESTRING (130 0, 0 0, 0 150)",
+			g2:       "LINESTRING (10 10, 10 150, 130 10)",
+			distance: 14.142135623730951,
+			points:   [2]geom.Coord{{0, 0}, {10, 10}},
+		},
+		{
+			desc:        "densified",
+			g1:          "LINESTRING (130 0, 0 0, 0 150)",
+			g2:          "LINESTRING (10 10, 10 150, 130 10)",
+			densifyFrac: []float64{0.5},
+			distance:    70,
+			points:      [2]geom.Coord{{0, 80}, {70, 80}},
+		},
+		{
+			desc:     "point inside polygon",
+			g1:       "POINT (1 1)",
+			g2:       "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))",
+			distance: math.Sqrt2,
+			points:   [2]geom.Coord{{1, 1}, {0, 0}},
+		},
+		{
+			desc:     "identical",
+			g1:       "MULTIPOINT (0 0, 1 1)",
+			g2:       "MULTIPOINT (1 1, 0 0)",
+			distance: 0,
+			points:   [2]geom.Coord{{0, 0}, {0, 0}},
+		},
+	} {
+		g1, err := wkt.Unmarshal(tc.g1)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.g1, err)
+		}
+		g2, err := wkt.Unmarshal(tc.g2)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.g2, err)
+		}
+		distance, p1, p2, err := xy.HausdorffDistance(g1, g2, tc.densifyFrac...)
+		if err != nil || math.Abs(distance-tc.distance) > 1e-9 || !reflect.DeepEqual([2]geom.Coord{p1, p2}, tc.points) {
+			t.Errorf("%d: %s: HausdorffDistance(...) == %v, %v, %v, %v, want %v, %v, %v, nil", i, tc.desc, distance, p1, p2, err, tc.distance, tc.points[0], tc.points[1])
+		}
+		if reversed, _, _, _ := xy.HausdorffDistance(g2, g1, tc.densifyFrac...); reversed != distance {
+			t.Errorf("%d: %s: HausdorffDistance is not symmetric: %v != %v", i, tc.desc, reversed, distance)
+		}
+	}
+}
+
+func TestFrechetDistance(t *testing.T) {
+	for i, tc := range []struct {
+		desc        string
+		ls1, ls2    string
+		densifyFrac []float64
+		distance    float64
+		points      [2]geom.Coord
+	}{
+		{
+			desc:     "parallel",
+			ls1:      "LINESTRING (1 1, 2 2)",
+			ls2:      "LINESTRING (1 4, 2 3)",
+			distance: 3,
+			points:   [2]geom.Coord{{1, 1}, {1, 4}},
+		},
+		{
+			desc:     "detour",
+			ls1:      "LINESTRING (0 0, 100 0)",
+			ls2:      "LINESTRING (0 0, 50 50, 100 0)",
+			distance: 70.71067811865476,
+			points:   [2]geom.Coord{{0, 0}, {50, 50}},
+		},
+		{
+			desc:     "order matters",
+			ls1:      "LINESTRING (0 0, 10 0)",
+			ls2:      "LINESTRING (10 0, 0 0)",
+			distance: 10,
+			points:   [2]geom.Coord{{0, 0}, {10, 0}},
+		},
+		{
+			desc:     "discrete",
+			ls1:      "LINESTRING (0 0, 10 0)",
+			ls2:      "LINESTRING (0 1, 5 1, 10 1)",
+			distance: math.Sqrt(26),
+			points:   [2]geom.Coord{{0, 0}, {5, 1}},
+		},
+		{
+			desc:        "continuous approximation",
+			ls1:         "LINESTRING (0 0, 10 0)",
+			ls2:         "LINESTRING (0 1, 5 1, 10 1)",
+			densifyFrac: []float64{0.1},
+			// 连续的 Fréchet 距离为1，近似值的误差不超过等分点间距的一半
+			distance: math.Sqrt(1.25),
+			points:   [2]geom.Coord{{0, 0}, {0.5, 1}},
+		},
+		{
+			desc:     "layouts",
+			ls1:      "LINESTRING Z (0 0 5, 3 0 5)",
+			ls2:      "LINESTRING M (0 4 1, 3 4 2)",
+			distance: 4,
+			points:   [2]geom.Coord{{0, 0}, {0, 4}},
+		},
+	} {
+		ls1, err := wkt.Unmarshal(tc.ls1)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.ls1, err)
+		}
+		ls2, err := wkt.Unmarshal(tc.ls2)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.ls2, err)
+		}
+		distance, p1, p2 := xy.FrechetDistance(ls1.(*geom.LineString), ls2.(*geom.LineString), tc.densifyFrac...)
+		if math.Abs(distance-tc.distance) > 1e-9 || !reflect.DeepEqual([2]geom.Coord{p1, p2}, tc.points) {
+			t.Errorf("%d: %s: FrechetDistance(...) == %v, %v, %v, want %v, %v, %v", i, tc.desc, distance, p1, p2, tc.distance, tc.points[0], tc.points[1])
+		}
+	}
+}
+
+func TestShapeDistanceEmpty(t *testing.T) {
+	empty := geom.NewLineString(geom.XY)
+	line := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1})
+	if d, p1, p2, err := xy.HausdorffDistance(empty, line); !math.IsInf(d, 1) || p1 != nil || p2 != nil || err != nil {
+		t.Errorf("HausdorffDistance(empty, line) == %v, %v, %v, %v, want +Inf, nil, nil, nil", d, p1, p2, err)
+	}
+	if d, p1, p2 := xy.FrechetDistance(line, empty); !math.IsInf(d, 1) || p1 != nil || p2 != nil {
+		t.Errorf("FrechetDistance(line, empty) == %v, %v, %v, want +Inf, nil, nil", d, p1, p2)
+	}
+}
+
+func TestHausdorffDistanceUnsupportedType(t *testing.T) {
+	line := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1})
+	want := geom.ErrUnsupportedType{Value: nil}
+	if _, _, _, err := xy.HausdorffDistance(line, nil); !reflect.DeepEqual(err, want) {
+		t.Errorf("HausdorffDistance(line, nil) == _, _, _, %v, want _, _, _, %v", err, want)
+	}
+}
+
+func TestShapeDistanceRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomLine := func() *geom.LineString {
+		flatCoords := make([]float64, 2*(2+r.Intn(15)))
+		for i := range flatCoords {
+			flatCoords[i] = r.Float64() * 100
+		}
+		return geom.NewLineStringFlat(geom.XY, flatCoords)
+	}
+	for i := 0; i < 50; i++ {
+		ls1, ls2 := randomLine(), randomLine()
+		hausdorff, h1, h2, err := xy.HausdorffDistance(ls1, ls2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		frechet, f1, f2 := xy.FrechetDistance(ls1, ls2)
+		if reversed, _, _ := xy.FrechetDistance(ls2, ls1); reversed != frechet {
+			t.Errorf("%d: FrechetDistance is not symmetric: %v != %v", i, reversed, frechet)
+		}
+		// 离散的 Fréchet 距离只使用顶点之间的距离，因此不小于顶点到线的 Hausdorff 距离
+		if frechet < hausdorff {
+			t.Errorf("%d: FrechetDistance(...) == %v < HausdorffDistance(...) == %v", i, frechet, hausdorff)
+		}
+		for _, tc := range []struct {
+			name     string
+			distance float64
+			p1, p2   geom.Coord
+		}{
+			{name: "HausdorffDistance", distance: hausdorff, p1: h1, p2: h2},
+			{name: "FrechetDistance", distance: frechet, p1: f1, p2: f2},
+		} {
+			if d := math.Hypot(tc.p2[0]-tc.p1[0], tc.p2[1]-tc.p1[1]); math.Abs(d-tc.distance) > 1e-9 {
+				t.Errorf("%d: %s(...) == %v, %v, %v, but the points are %v apart", i, tc.name, tc.distance, tc.p1, tc.p2, d)
+			}
+			if d, err := xy.DistanceBetween(geom.NewPointFlat(geom.XY, tc.p1), ls1); err != nil || d > 1e-9 {
+				t.Errorf("%d: %s(...) returned %v which is not on ls1", i, tc.name, tc.p1)
+			}
+			if d, err := xy.DistanceBetween(geom.NewPointFlat(geom.XY, tc.p2), ls2); err != nil || d > 1e-9 {
+				t.Errorf("%d: %s(...) returned %v which is not on ls2", i, tc.name, tc.p2)
+			}
+		}
+		// 加密之后的 Hausdorff 距离不会更小，并且不超过 Fréchet 距离的连续近似
+		densified, _, _, _ := xy.HausdorffDistance(ls1, ls2, 0.1)
+		continuous, _, _ := xy.FrechetDistance(ls1, ls2, 0.1)
+		if densified < hausdorff-1e-9 || continuous < densified-1e-9 {
+			t.Errorf("%d: densified Hausdorff %v, discrete Hausdorff %v, continuous Fréchet %v", i, densified, hausdorff, continuous)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package simplify
+
+import (
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+)
+
+// DouglasPeuckerFlat函数 使用 Douglas-Peucker 算法化简坐标数组表示的线，返回一个新的坐标数组。
+// 起点和终点总是被保留，因此闭合的线化简后仍然是闭合的，但可能少于4个坐标
+func DouglasPeuckerFlat(layout geom.Layout, flatCoords []float64, tolerance float64) []float64 {
+	stride := layout.Stride()
+	n := len(flatCoords) / stride
+	if n < 3 {
+		return append([]float64(nil), flatCoords...)
+	}
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+	douglasPeuckerSection(flatCoords, stride, 0, n-1, tolerance, keep)
+
+	var result []float64
+	for i, k := range keep {
+		if k {
+			result = append(result, flatCoords[i*stride:(i+1)*stride]...)
+		}
+	}
+	return result
+}
+
+// douglasPeuckerSection 函数化简第 i 个到第 j 个顶点之间的部分，需要保留的顶点在 keep 中标记
+func douglasPeuckerSection(flatCoords []float64, stride, i, j int, tolerance float64, keep []bool) {
+	if i+1 >= j {
+		return
+	}
+	index, maxDistance := findFurthestPoint(flatCoords, stride, i, j)
+	if maxDistance <= tolerance {
+		return
+	}
+	keep[index] = true
+	douglasPeuckerSection(flatCoords, stride, i, index, tolerance, keep)
+	douglasPeuckerSection(flatCoords, stride, index, j, tolerance, keep)
+}
+
+// findFurthestPoint 函数返回第 i 个和第 j 个顶点之间距离线段 i-j 最远的顶点及其距离
+func findFurthestPoint(flatCoords []float64, stride, i, j int) (int, float64) {
+	start := geom.Coord(flatCoords[i*stride : i*stride+2])
+	end := geom.Coord(flatCoords[j*stride : j*stride+2])
+	index, maxDistance := i+1, -1.0
+	for k := i + 1; k < j; k++ {
+		distance := xy.DistanceFromPointToLine(geom.Coord(flatCoords[k*stride:k*stride+2]), start, end)
+		if distance > maxDistance {
+			index, maxDistance = k, distance
+		}
+	}
+	return index, maxDistance
+}
@@ -0,0 +1,139 @@
+// Package simplify 包含了线和多边形的化简（概化）算法。
+//
+// 所有的算法都直接作用于平面坐标数组，只使用每个坐标的 x、y 坐标进行计算，保留下来的顶点的
+// 其他坐标（例如 z、m）原样保留，因此化简结果与输入具有相同的视图。
+package simplify
+
+import (
+	"github.com/chengxiaoer/geomGo"
+)
+
+// minLineSize 和 minRingSize 分别是有效的线和线环所需的最少坐标数目
+const (
+	minLineSize = 2
+	minRingSize = 4
+)
+
+// DouglasPeucker函数 使用 Douglas-Peucker 算法化简几何图形。
+// 与化简后线段的距离不超过 tolerance 的顶点将被移除。
+// 化简可能使多边形的线环退化，退化的洞将被移除，外边界退化的多边形将被移除（或成为空多边形）。
+// 化简也可能产生自相交，需要保持拓扑关系时请使用 TopologyPreserving
+func DouglasPeucker(g geom.T, tolerance float64) (geom.T, error) {
+	return transformLines(g, func(layout geom.Layout, flatCoords []float64, _ bool) []float64 {
+		return DouglasPeuckerFlat(layout, flatCoords, tolerance)
+	})
+}
+
+// VisvalingamWhyatt函数 使用 Visvalingam-Whyatt 算法化简几何图形。
+// 依次移除与相邻两个顶点构成的三角形面积（有效面积）最小的顶点，直到所有顶点的有效面积都不小于 tolerance。
+// 注意 tolerance 是面积，单位是坐标单位的平方。
+// 与 DouglasPeucker 相同，退化的线环将被移除
+func VisvalingamWhyatt(g geom.T, tolerance float64) (geom.T, error) {
+	return transformLines(g, func(layout geom.Layout, flatCoords []float64, _ bool) []float64 {
+		return VisvalingamWhyattFlat(layout, flatCoords, tolerance)
+	})
+}
+
+// TopologyPreserving函数 使用保持拓扑关系的 Douglas-Peucker 算法化简几何图形。
+// 只有当化简后的线段不与几何图形中其他任何线段相交时才会移除顶点，因此化简结果不会产生自相交，
+// 线环至少保留4个坐标，不会退化，洞也不会移动到外边界之外
+func TopologyPreserving(g geom.T, tolerance float64) (geom.T, error) {
+	var lines []*taggedLine
+	if _, err := transformLines(g, func(layout geom.Layout, flatCoords []float64, isRing bool) []float64 {
+		lines = append(lines, newTaggedLine(layout, flatCoords, isRing))
+		return flatCoords
+	}); err != nil {
+		return nil, err
+	}
+	newTopologyPreservingSimplifier(lines, tolerance).simplify()
+	i := 0
+	return transformLines(g, func(_ geom.Layout, _ []float64, _ bool) []float64 {
+		i++
+		return lines[i-1].resultCoords()
+	})
+}
+
+// lineTransformer 函数转换一条线或线环的坐标数组，返回新的坐标数组
+type lineTransformer func(layout geom.Layout, flatCoords []float64, isRing bool) []float64
+
+// transformLines 函数按顺序对几何图形中的每一条线和线环调用 f，并使用返回的坐标构建新的几何图形。
+// 点和多点不会被转换，只会被复制。少于4个坐标的多边形线环将被移除
+func transformLines(g geom.T, f lineTransformer) (geom.T, error) {
+	switch g := g.(type) {
+	case *geom.Point:
+		return g.Clone(), nil
+	case *geom.MultiPoint:
+		return g.Clone(), nil
+	case *geom.LineString:
+		return geom.NewLineStringFlat(g.Layout(), f(g.Layout(), g.FlatCoords(), false)).SetSRID(g.SRID()), nil
+	case *geom.LinearRing:
+		return geom.NewLinearRingFlat(g.Layout(), f(g.Layout(), g.FlatCoords(), true)).SetSRID(g.SRID()), nil
+	case *geom.Polygon:
+		flatCoords, ends := transformPolygon(g.Layout(), g.FlatCoords(), 0, g.Ends(), f, nil)
+		return geom.NewPolygonFlat(g.Layout(), flatCoords, ends).SetSRID(g.SRID()), nil
+	case *geom.MultiLineString:
+		var flatCoords []float64
+		var ends []int
+		offset := 0
+		for _, end := range g.Ends() {
+			flatCoords = append(flatCoords, f(g.Layout(), g.FlatCoords()[offset:end], false)...)
+			ends = append(ends, len(flatCoords))
+			offset = end
+		}
+		return geom.NewMultiLineStringFlat(g.Layout(), flatCoords, ends).SetSRID(g.SRID()), nil
+	case *geom.MultiPolygon:
+		var flatCoords []float64
+		var endss [][]int
+		offset := 0
+		for _, ends := range g.Endss() {
+			var newEnds []int
+			flatCoords, newEnds = transformPolygon(g.Layout(), g.FlatCoords(), offset, ends, f, flatCoords)
+			if len(newEnds) > 0 {
+				endss = append(endss, newEnds)
+			}
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+		return geom.NewMultiPolygonFlat(g.Layout(), flatCoords, endss).SetSRID(g.SRID()), nil
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.SRID())
+		for _, child := range g.Geoms() {
+			newChild, err := transformLines(child, f)
+			if err != nil {
+				return nil, err
+			}
+			if err := gc.Push(newChild); err != nil {
+				return nil, err
+			}
+		}
+		return gc, nil
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// transformPolygon 函数转换多边形的每个线环并追加到 dst。外边界退化时整个多边形被移除，返回的 ends 为空
+func transformPolygon(layout geom.Layout, flatCoords []float64, offset int, ends []int, f lineTransformer, dst []float64) ([]float64, []int) {
+	stride := layout.Stride()
+	start := len(dst)
+	var newEnds []int
+	for i, end := range ends {
+		ring := f(layout, flatCoords[offset:end], true)
+		offset = end
+		if len(ring) < minRingSize*stride {
+			if i == 0 {
+				// 外边界退化，但仍需对洞调用 f 以保持调用的顺序
+				for _, end := range ends[1:] {
+					f(layout, flatCoords[offset:end], true)
+					offset = end
+				}
+				return dst[:start], nil
+			}
+			continue
+		}
+		dst = append(dst, ring...)
+		newEnds = append(newEnds, len(dst))
+	}
+	return dst, newEnds
+}
@@ -0,0 +1,29 @@
+package simplify_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy/simplify"
+)
+
+func ExampleDouglasPeucker() {
+	track := geom.NewLineString(geom.XYM).MustSetCoords([]geom.Coord{
+		{0, 0, 0}, {5, 0.1, 10}, {10, 0, 20}, {10, 10, 30},
+	})
+	simplified, _ := simplify.DouglasPeucker(track, 1)
+	s, _ := wkt.Marshal(simplified)
+	fmt.Println(s)
+	// Output: LINESTRING M (0 0 0, 10 0 20, 10 10 30)
+}
+
+func ExampleVisvalingamWhyatt() {
+	line := geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{
+		{0, 0}, {1, 0.1}, {2, 0}, {3, 5}, {4, 0},
+	})
+	simplified, _ := simplify.VisvalingamWhyatt(line, 1)
+	s, _ := wkt.Marshal(simplified)
+	fmt.Println(s)
+	// Output: LINESTRING (0 0, 2 0, 3 5, 4 0)
+}
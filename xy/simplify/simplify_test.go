@@ -0,0 +1,181 @@
+package simplify_test
+
+import (
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy/simplify"
+)
+
+type simplifyTestCase struct {
+	wkt       string
+	tolerance float64
+	expected  string
+}
+
+func testSimplify(t *testing.T, name string, f func(geom.T, float64) (geom.T, error), testCases []simplifyTestCase) {
+	for i, tc := range testCases {
+		g, err := wkt.Unmarshal(tc.wkt)
+		if err != nil {
+			t.Fatalf("%d: wkt.Unmarshal(%q) == _, %v", i, tc.wkt, err)
+		}
+		simplified, err := f(g, tc.tolerance)
+		if err != nil {
+			t.Errorf("%d: %s(%s, %v) == _, %v, want _, nil", i, name, tc.wkt, tc.tolerance, err)
+			continue
+		}
+		if got, err := wkt.Marshal(simplified); err != nil || got != tc.expected {
+			t.Errorf("%d: %s(%s, %v) == %s, %v, want %s", i, name, tc.wkt, tc.tolerance, got, err, tc.expected)
+		}
+		if after, _ := wkt.Marshal(g); after != tc.wkt {
+			t.Errorf("%d: %s(%s, %v) mutated the input to %s", i, name, tc.wkt, tc.tolerance, after)
+		}
+	}
+}
+
+func TestDouglasPeucker(t *testing.T) {
+	testSimplify(t, "DouglasPeucker", simplify.DouglasPeucker, []simplifyTestCase{
+		{
+			wkt:       "POINT (1 2)",
+			tolerance: 10,
+			expected:  "POINT (1 2)",
+		},
+		{
+			wkt:       "LINESTRING (0 0, 5 0.1, 10 0)",
+			tolerance: 1,
+			expected:  "LINESTRING (0 0, 10 0)",
+		},
+		{
+			wkt:       "LINESTRING (0 0, 5 0.1, 10 0)",
+			tolerance: 0.05,
+			expected:  "LINESTRING (0 0, 5 0.1, 10 0)",
+		},
+		{
+			wkt:       "LINESTRING (0 0, 1 0.5, 2 -0.5, 3 0.5, 4 0, 4 4, 5 4.2, 8 4)",
+			tolerance: 1,
+			expected:  "LINESTRING (0 0, 4 0, 4 4, 8 4)",
+		},
+		{
+			wkt:       "LINESTRING ZM (0 0 1 2, 5 0.1 3 4, 10 0 5 6, 10 10 7 8)",
+			tolerance: 1,
+			expected:  "LINESTRING ZM (0 0 1 2, 10 0 5 6, 10 10 7 8)",
+		},
+		{
+			wkt:       "MULTILINESTRING ((0 0, 5 0.1, 10 0), (0 1, 1 1))",
+			tolerance: 1,
+			expected:  "MULTILINESTRING ((0 0, 10 0), (0 1, 1 1))",
+		},
+		{
+			wkt:       "POLYGON ((0 0, 5 0.1, 10 0, 10 10, 0 10, 0 0), (4 4, 4.1 4, 4.1 4.1, 4 4))",
+			tolerance: 1,
+			expected:  "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+		},
+		{
+			wkt:       "POLYGON ((0 0, 0.1 0, 0.1 0.1, 0 0))",
+			tolerance: 1,
+			expected:  "POLYGON EMPTY",
+		},
+		{
+			wkt:       "MULTIPOLYGON (((0 0, 0.1 0, 0.1 0.1, 0 0)), ((0 0, 10 0, 10 10, 0 0)))",
+			tolerance: 1,
+			expected:  "MULTIPOLYGON (((0 0, 10 0, 10 10, 0 0)))",
+		},
+		{
+			wkt:       "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 5 0.1, 10 0))",
+			tolerance: 1,
+			expected:  "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (0 0, 10 0))",
+		},
+	})
+}
+
+func TestVisvalingamWhyatt(t *testing.T) {
+	testSimplify(t, "VisvalingamWhyatt", simplify.VisvalingamWhyatt, []simplifyTestCase{
+		{
+			wkt:       "LINESTRING (0 0, 1 0.1, 2 0, 3 5, 4 0)",
+			tolerance: 1,
+			expected:  "LINESTRING (0 0, 2 0, 3 5, 4 0)",
+		},
+		{
+			wkt:       "LINESTRING (0 0, 1 0.1, 2 0, 3 5, 4 0)",
+			tolerance: 6,
+			expected:  "LINESTRING (0 0, 3 5, 4 0)",
+		},
+		{
+			wkt:       "LINESTRING (0 0, 1 0.1, 2 0, 3 5, 4 0)",
+			tolerance: 11,
+			expected:  "LINESTRING (0 0, 4 0)",
+		},
+		{
+			wkt:       "LINESTRING M (0 0 0, 1 0.1 1, 2 0 2)",
+			tolerance: 1,
+			expected:  "LINESTRING M (0 0 0, 2 0 2)",
+		},
+		{
+			wkt:       "POLYGON ((0 0, 5 0.1, 10 0, 10 10, 0 10, 0 0), (4 4, 4.1 4, 4.1 4.1, 4 4))",
+			tolerance: 1,
+			expected:  "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))",
+		},
+	})
+}
+
+func TestTopologyPreserving(t *testing.T) {
+	testSimplify(t, "TopologyPreserving", simplify.TopologyPreserving, []simplifyTestCase{
+		{
+			wkt:       "LINESTRING (0 0, 5 0.1, 10 0)",
+			tolerance: 1,
+			expected:  "LINESTRING (0 0, 10 0)",
+		},
+		{
+			// 外边界不会跳过位于凸起部分中的洞
+			wkt:       "POLYGON ((0 0, 10 0, 10 10, 5 11, 0 10, 0 0), (4 10.2, 6 10.2, 5 10.6, 4 10.2))",
+			tolerance: 2,
+			expected:  "POLYGON ((0 0, 10 0, 10 10, 5 11, 0 10, 0 0), (4 10.2, 6 10.2, 5 10.6, 4 10.2))",
+		},
+		{
+			// 线环不会退化
+			wkt:       "POLYGON ((0 0, 0.1 0, 0.1 0.1, 0 0.1, 0 0))",
+			tolerance: 1,
+			expected:  "POLYGON ((0 0, 0.1 0, 0.1 0.1, 0 0.1, 0 0))",
+		},
+		{
+			// 化简第一条线会与第二条线相交
+			wkt:       "MULTILINESTRING ((0 0, 5 1.5, 10 0), (5 -1, 5 1))",
+			tolerance: 2,
+			expected:  "MULTILINESTRING ((0 0, 5 1.5, 10 0), (5 -1, 5 1))",
+		},
+		{
+			// 化简第一条线会跳过第二条线
+			wkt:       "MULTILINESTRING ((0 0, 5 1.5, 10 0), (4 0.5, 6 0.5))",
+			tolerance: 2,
+			expected:  "MULTILINESTRING ((0 0, 5 1.5, 10 0), (4 0.5, 6 0.5))",
+		},
+		{
+			wkt:       "MULTILINESTRING ((0 0, 5 1.5, 10 0), (4 5, 6 5))",
+			tolerance: 2,
+			expected:  "MULTILINESTRING ((0 0, 10 0), (4 5, 6 5))",
+		},
+		{
+			// 化简后不会产生自相交
+			wkt:       "LINESTRING (0 0, 5 1, 10 0, 10 -3, 5 -3, 5 0.5)",
+			tolerance: 1.5,
+			expected:  "LINESTRING (0 0, 5 1, 10 0, 10 -3, 5 -3, 5 0.5)",
+		},
+		{
+			wkt:       "LINESTRING (0 0, 5 1, 10 0, 10 -3, 5 -3, 5 0.5)",
+			tolerance: 3,
+			expected:  "LINESTRING (0 0, 5 1, 10 0, 10 -3, 5 0.5)",
+		},
+	})
+	if _, err := simplify.TopologyPreserving(nil, 1); err == nil {
+		t.Errorf("TopologyPreserving(nil, 1) == _, nil, want _, non-nil")
+	}
+}
+
+func TestDouglasPeuckerFlatKeepsEndpoints(t *testing.T) {
+	flatCoords := []float64{0, 0, 1, 1, 0, 0}
+	got := simplify.DouglasPeuckerFlat(geom.XY, flatCoords, 10)
+	if len(got) != 4 || got[0] != 0 || got[1] != 0 || got[2] != 0 || got[3] != 0 {
+		t.Errorf("DouglasPeuckerFlat(XY, %v, 10) == %v, want [0 0 0 0]", flatCoords, got)
+	}
+}
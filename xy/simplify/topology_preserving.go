@@ -0,0 +1,184 @@
+package simplify
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/internal"
+	"github.com/chengxiaoer/geomGo/xy/internal/lineintersector"
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+// taggedLine 是参与保持拓扑关系化简的一条线或线环
+type taggedLine struct {
+	layout     geom.Layout
+	stride     int
+	flatCoords []float64
+	minSize    int
+	// removed[i] 表示输入的第 i 条线段（第 i 个到第 i+1 个顶点）已经被化简后的线段替代
+	removed []bool
+	// result 是化简后保留的顶点的索引
+	result []int
+}
+
+func newTaggedLine(layout geom.Layout, flatCoords []float64, isRing bool) *taggedLine {
+	stride := layout.Stride()
+	minSize := minLineSize
+	if isRing {
+		minSize = minRingSize
+	}
+	n := len(flatCoords) / stride
+	var removed []bool
+	if n > 1 {
+		removed = make([]bool, n-1)
+	}
+	return &taggedLine{
+		layout:     layout,
+		stride:     stride,
+		flatCoords: flatCoords,
+		minSize:    minSize,
+		removed:    removed,
+	}
+}
+
+func (l *taggedLine) numCoords() int {
+	return len(l.flatCoords) / l.stride
+}
+
+func (l *taggedLine) coord(i int) geom.Coord {
+	return geom.Coord(l.flatCoords[i*l.stride : i*l.stride+2])
+}
+
+// resultCoords 方法返回化简后的坐标数组
+func (l *taggedLine) resultCoords() []float64 {
+	if l.result == nil {
+		return append([]float64(nil), l.flatCoords...)
+	}
+	result := make([]float64, 0, len(l.result)*l.stride)
+	for _, i := range l.result {
+		result = append(result, l.flatCoords[i*l.stride:(i+1)*l.stride]...)
+	}
+	return result
+}
+
+// segment 是化简后产生的线段
+type segment struct {
+	start, end geom.Coord
+}
+
+// topologyPreservingSimplifier 使用 Douglas-Peucker 算法依次化简每一条线，只有当化简后的线段与
+// 尚未化简的输入线段以及已经化简的输出线段都没有内部相交，并且不会跳过其他的线时，才接受这次化简
+type topologyPreservingSimplifier struct {
+	lines     []*taggedLine
+	tolerance float64
+	output    []segment
+	strategy  lineintersector.RobustLineIntersector
+}
+
+func newTopologyPreservingSimplifier(lines []*taggedLine, tolerance float64) *topologyPreservingSimplifier {
+	return &topologyPreservingSimplifier{
+		lines:     lines,
+		tolerance: tolerance,
+	}
+}
+
+func (s *topologyPreservingSimplifier) simplify() {
+	for _, line := range s.lines {
+		n := line.numCoords()
+		if n < 3 {
+			continue
+		}
+		line.result = []int{0}
+		s.simplifySection(line, 0, n-1, 0)
+	}
+}
+
+func (s *topologyPreservingSimplifier) simplifySection(line *taggedLine, i, j, depth int) {
+	depth++
+	if i+1 == j {
+		// 保留这条输入线段，它仍然在输入线段中参与相交检测
+		line.result = append(line.result, j)
+		return
+	}
+
+	isValidToSimplify := true
+	// 确保输出的线有足够的顶点: 如果在最坏的情况下顶点数目不足，就不能化简这一部分
+	if len(line.result) < line.minSize && depth+1 < line.minSize {
+		isValidToSimplify = false
+	}
+
+	furthest, distance := findFurthestPoint(line.flatCoords, line.stride, i, j)
+	if distance > s.tolerance {
+		isValidToSimplify = false
+	}
+
+	if isValidToSimplify && !s.hasBadIntersection(line, i, j) && !s.hasJump(line, i, j) {
+		for k := i; k < j; k++ {
+			line.removed[k] = true
+		}
+		s.output = append(s.output, segment{start: line.coord(i), end: line.coord(j)})
+		line.result = append(line.result, j)
+		return
+	}
+	s.simplifySection(line, i, furthest, depth)
+	s.simplifySection(line, furthest, j, depth)
+}
+
+// hasBadIntersection 方法检测候选线段 i-j 是否与其他线段存在内部相交
+func (s *topologyPreservingSimplifier) hasBadIntersection(line *taggedLine, i, j int) bool {
+	start, end := line.coord(i), line.coord(j)
+	for _, seg := range s.output {
+		if s.hasInteriorIntersection(start, end, seg.start, seg.end) {
+			return true
+		}
+	}
+	for _, other := range s.lines {
+		for k, removed := range other.removed {
+			// 被化简的这一部分的线段将被替代，不需要检测
+			if removed || (other == line && i <= k && k < j) {
+				continue
+			}
+			if s.hasInteriorIntersection(start, end, other.coord(k), other.coord(k+1)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasJump 方法检测化简第 i 个到第 j 个顶点之间的部分是否会使化简后的线段"跳过"其他的线或线环，
+// 即其他线的起点位于这一部分与候选线段围成的区域内部。例如化简外边界时使洞位于外边界之外
+func (s *topologyPreservingSimplifier) hasJump(line *taggedLine, i, j int) bool {
+	section := make([]float64, 0, (j-i+2)*line.stride)
+	section = append(section, line.flatCoords[i*line.stride:(j+1)*line.stride]...)
+	section = append(section, line.flatCoords[i*line.stride:(i+1)*line.stride]...)
+	for _, other := range s.lines {
+		if other == line || other.numCoords() == 0 {
+			continue
+		}
+		if xy.LocatePointInRing(line.layout, other.coord(0), section) == location.Interior {
+			return true
+		}
+	}
+	return false
+}
+
+// hasInteriorIntersection 方法检测两条线段是否相交于除了两条线段共同端点之外的点
+func (s *topologyPreservingSimplifier) hasInteriorIntersection(line1Start, line1End, line2Start, line2End geom.Coord) bool {
+	if math.Max(line1Start[0], line1End[0]) < math.Min(line2Start[0], line2End[0]) ||
+		math.Min(line1Start[0], line1End[0]) > math.Max(line2Start[0], line2End[0]) ||
+		math.Max(line1Start[1], line1End[1]) < math.Min(line2Start[1], line2End[1]) ||
+		math.Min(line1Start[1], line1End[1]) > math.Max(line2Start[1], line2End[1]) {
+		return false
+	}
+	result := lineintersector.LineIntersectsLine(s.strategy, line1Start, line1End, line2Start, line2End)
+	for _, p := range result.Intersection() {
+		isEndpoint1 := internal.Equal(p, 0, line1Start, 0) || internal.Equal(p, 0, line1End, 0)
+		isEndpoint2 := internal.Equal(p, 0, line2Start, 0) || internal.Equal(p, 0, line2End, 0)
+		if !isEndpoint1 || !isEndpoint2 {
+			return true
+		}
+	}
+	return false
+}
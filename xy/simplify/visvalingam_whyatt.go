@@ -0,0 +1,101 @@
+package simplify
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// VisvalingamWhyattFlat函数 使用 Visvalingam-Whyatt 算法化简坐标数组表示的线，返回一个新的坐标数组。
+// tolerance 是有效面积的阈值。起点和终点总是被保留
+func VisvalingamWhyattFlat(layout geom.Layout, flatCoords []float64, tolerance float64) []float64 {
+	stride := layout.Stride()
+	n := len(flatCoords) / stride
+	if n < 3 {
+		return append([]float64(nil), flatCoords...)
+	}
+
+	vertices := make([]vwVertex, n)
+	for i := range vertices {
+		vertices[i] = vwVertex{index: i, prev: i - 1, next: i + 1, area: math.Inf(1)}
+	}
+	vertices[n-1].next = -1
+	queue := make(vwQueue, 0, n-2)
+	for i := 1; i < n-1; i++ {
+		vertices[i].area = triangleArea(flatCoords, stride, i-1, i, i+1)
+		vertices[i].heapIndex = len(queue)
+		queue = append(queue, &vertices[i])
+	}
+	heap.Init(&queue)
+
+	for queue.Len() > 0 && queue[0].area < tolerance {
+		v := heap.Pop(&queue).(*vwVertex)
+		v.removed = true
+		prev, next := &vertices[v.prev], &vertices[v.next]
+		prev.next, next.prev = v.next, v.prev
+		// 一个顶点的有效面积不会小于已经被移除的顶点的有效面积，以保证移除的顺序
+		for _, u := range []*vwVertex{prev, next} {
+			if u.prev == -1 || u.next == -1 {
+				continue
+			}
+			u.area = math.Max(triangleArea(flatCoords, stride, u.prev, u.index, u.next), v.area)
+			heap.Fix(&queue, u.heapIndex)
+		}
+	}
+
+	var result []float64
+	for i := range vertices {
+		if !vertices[i].removed {
+			result = append(result, flatCoords[i*stride:(i+1)*stride]...)
+		}
+	}
+	return result
+}
+
+// triangleArea 函数返回第 i、j、k 个顶点构成的三角形的面积
+func triangleArea(flatCoords []float64, stride, i, j, k int) float64 {
+	ax, ay := flatCoords[i*stride], flatCoords[i*stride+1]
+	bx, by := flatCoords[j*stride], flatCoords[j*stride+1]
+	cx, cy := flatCoords[k*stride], flatCoords[k*stride+1]
+	return math.Abs((bx-ax)*(cy-ay)-(cx-ax)*(by-ay)) / 2
+}
+
+// vwVertex 是双向链表中的一个顶点，prev 和 next 为相邻顶点的索引，-1 表示不存在
+type vwVertex struct {
+	index, prev, next int
+	area              float64
+	heapIndex         int
+	removed           bool
+}
+
+// vwQueue 是按有效面积排序的最小堆
+type vwQueue []*vwVertex
+
+func (q vwQueue) Len() int { return len(q) }
+
+func (q vwQueue) Less(i, j int) bool {
+	if q[i].area == q[j].area {
+		return q[i].index < q[j].index
+	}
+	return q[i].area < q[j].area
+}
+
+func (q vwQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].heapIndex = i
+	q[j].heapIndex = j
+}
+
+func (q *vwQueue) Push(x interface{}) {
+	v := x.(*vwVertex)
+	v.heapIndex = len(*q)
+	*q = append(*q, v)
+}
+
+func (q *vwQueue) Pop() interface{} {
+	old := *q
+	v := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return v
+}
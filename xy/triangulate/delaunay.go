@@ -0,0 +1,205 @@
+package triangulate
+
+import (
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/bigxy"
+	"github.com/chengxiaoer/geomGo/xy/orientation"
+)
+
+// infinite 是三角剖分中无穷远点的索引，凸包的每条边与无穷远点组成一个虚拟三角形
+const infinite = -1
+
+// Delaunay函数 计算点集的 Delaunay 三角剖分，返回索引缓冲区。
+// 使用逐点插入（Bowyer-Watson）算法，方向和外接圆的判断使用强健的 bigxy.OrientationIndex 和 bigxy.IsInCircle，
+// 因此共圆和共线的点也能得到正确的结果。重复的点只使用第一个点的索引，所有的点共线或少于3个点时返回 nil
+func Delaunay(mp *geom.MultiPoint) []int {
+	stride := mp.Stride()
+	var points []geom.Coord
+	var ids []int
+	seen := make(map[[2]float64]bool)
+	for i, flatCoords := 0, mp.FlatCoords(); i < len(flatCoords); i += stride {
+		key := [2]float64{flatCoords[i], flatCoords[i+1]}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		points = append(points, geom.Coord(flatCoords[i:i+2]))
+		ids = append(ids, i/stride)
+	}
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+	// 按照坐标排序之后插入，使得每次插入的点都在上一个三角形附近
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := points[order[i]], points[order[j]]
+		return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+	})
+	t := &triangulation{points: points}
+	if !t.init(order) {
+		return nil
+	}
+	var indices []int
+	for _, tri := range t.triangles {
+		if tri.alive && !tri.isGhost() {
+			indices = append(indices, ids[tri.v[0]], ids[tri.v[1]], ids[tri.v[2]])
+		}
+	}
+	return indices
+}
+
+// triangle 是逆时针方向的三角形，n[i] 是与顶点 v[i] 相对的边的相邻三角形
+type triangle struct {
+	v     [3]int
+	n     [3]int
+	alive bool
+}
+
+func (t *triangle) isGhost() bool {
+	return t.v[0] == infinite || t.v[1] == infinite || t.v[2] == infinite
+}
+
+type triangulation struct {
+	points    []geom.Coord
+	triangles []triangle
+	last      int
+}
+
+// init 方法使用前三个不共线的点创建初始的三角形，然后插入其他的点。所有的点共线时返回 false
+func (t *triangulation) init(order []int) bool {
+	if len(order) < 3 {
+		return false
+	}
+	a, b := order[0], order[1]
+	k := 2
+	for ; k < len(order); k++ {
+		if t.orientation(a, b, order[k]) != orientation.Collinear {
+			break
+		}
+	}
+	if k == len(order) {
+		return false
+	}
+	c := order[k]
+	if t.orientation(a, b, c) == orientation.Clockwise {
+		a, b = b, a
+	}
+	t.triangles = []triangle{
+		{v: [3]int{a, b, c}, n: [3]int{2, 3, 1}, alive: true},
+		{v: [3]int{b, a, infinite}, n: [3]int{3, 2, 0}, alive: true},
+		{v: [3]int{c, b, infinite}, n: [3]int{1, 3, 0}, alive: true},
+		{v: [3]int{a, c, infinite}, n: [3]int{2, 1, 0}, alive: true},
+	}
+	for i, v := range order {
+		if i != 0 && i != 1 && i != k {
+			t.insert(v)
+		}
+	}
+	return true
+}
+
+func (t *triangulation) orientation(a, b, p int) orientation.Type {
+	return bigxy.OrientationIndex(t.points[a], t.points[b], t.points[p])
+}
+
+// conflicts 方法检测点 p 是否位于三角形的外接圆之内。
+// 对于虚拟三角形，外接圆退化为凸包的边外侧的半平面以及边的内部
+func (t *triangulation) conflicts(tri *triangle, p int) bool {
+	for k := 0; k < 3; k++ {
+		if tri.v[k] != infinite {
+			continue
+		}
+		a, b := tri.v[(k+1)%3], tri.v[(k+2)%3]
+		switch t.orientation(a, b, p) {
+		case orientation.CounterClockwise:
+			return true
+		case orientation.Collinear:
+			pa, pb, pp := t.points[a], t.points[b], t.points[p]
+			return (pp[0]-pa[0])*(pb[0]-pa[0])+(pp[1]-pa[1])*(pb[1]-pa[1]) > 0 &&
+				(pp[0]-pb[0])*(pa[0]-pb[0])+(pp[1]-pb[1])*(pa[1]-pb[1]) > 0
+		default:
+			return false
+		}
+	}
+	return bigxy.IsInCircle(t.points[tri.v[0]], t.points[tri.v[1]], t.points[tri.v[2]], t.points[p])
+}
+
+// locate 方法从最近创建的三角形开始沿着点 p 的方向移动，返回与点 p 冲突的一个三角形
+func (t *triangulation) locate(p int) int {
+	current := t.last
+	for step := 0; ; step++ {
+		tri := &t.triangles[current]
+		if tri.isGhost() {
+			return current
+		}
+		next := -1
+		for j := 0; j < 3; j++ {
+			k := (j + step) % 3
+			if t.orientation(tri.v[(k+1)%3], tri.v[(k+2)%3], p) == orientation.Clockwise {
+				next = tri.n[k]
+				break
+			}
+		}
+		if next < 0 {
+			return current
+		}
+		current = next
+	}
+}
+
+// insert 方法插入点 p：删除所有外接圆包含点 p 的三角形，然后将形成的空腔的每条边与点 p 连接为新的三角形
+func (t *triangulation) insert(p int) {
+	seed := t.locate(p)
+	inCavity := map[int]bool{seed: true}
+	stack := []int{seed}
+	type boundaryEdge struct {
+		u, w, outside int
+	}
+	var boundary []boundaryEdge
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		tri := t.triangles[i]
+		for k := 0; k < 3; k++ {
+			n := tri.n[k]
+			if inCavity[n] {
+				continue
+			}
+			if t.conflicts(&t.triangles[n], p) {
+				inCavity[n] = true
+				stack = append(stack, n)
+				continue
+			}
+			boundary = append(boundary, boundaryEdge{u: tri.v[(k+1)%3], w: tri.v[(k+2)%3], outside: n})
+		}
+	}
+	for i := range inCavity {
+		t.triangles[i].alive = false
+	}
+	// 每个新三角形为 (u, w, p)，与 u 相对的边为 (w, p)，与 w 相对的边为 (p, u)
+	byStart := make(map[int]int, len(boundary))
+	first := len(t.triangles)
+	for j, e := range boundary {
+		index := first + j
+		t.triangles = append(t.triangles, triangle{v: [3]int{e.u, e.w, p}, n: [3]int{-1, -1, e.outside}, alive: true})
+		outside := &t.triangles[e.outside]
+		for k := 0; k < 3; k++ {
+			if outside.v[(k+1)%3] == e.w && outside.v[(k+2)%3] == e.u {
+				outside.n[k] = index
+			}
+		}
+		byStart[e.u] = index
+	}
+	for j, e := range boundary {
+		index := first + j
+		// 边 (w, p) 与以 w 为起点的新三角形的边 (p, w) 相邻
+		neighbor := byStart[e.w]
+		t.triangles[index].n[0] = neighbor
+		t.triangles[neighbor].n[1] = index
+		if !t.triangles[index].isGhost() {
+			t.last = index
+		}
+	}
+}
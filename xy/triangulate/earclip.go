@@ -0,0 +1,394 @@
+package triangulate
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// EarClip函数 使用耳切法剖分多边形（包括洞），返回索引缓冲区。
+// 洞通过桥接边连接到外环上，相邻的重复点和共线的点被忽略。外环的点少于3个时返回 nil。
+// 算法参考 mapbox 的 earcut：找不到耳朵时依次尝试删除退化的点、消除局部的自相交以及沿对角线分割多边形，
+// 因此对于轻微无效的多边形也能得到结果，但结果不一定正确
+func EarClip(p *geom.Polygon) []int {
+	flatCoords, ends, stride := p.FlatCoords(), p.Ends(), p.Stride()
+	if len(ends) == 0 {
+		return nil
+	}
+	outer := newRing(flatCoords, 0, ends[0], stride, true)
+	if outer == nil || outer.next == outer.prev {
+		return nil
+	}
+	var holes []*earNode
+	for i := 1; i < len(ends); i++ {
+		hole := newRing(flatCoords, ends[i-1], ends[i], stride, false)
+		if hole == nil {
+			continue
+		}
+		if hole == hole.next {
+			hole.steiner = true
+		}
+		holes = append(holes, leftmost(hole))
+	}
+	sort.SliceStable(holes, func(i, j int) bool {
+		return holes[i].x < holes[j].x
+	})
+	for _, hole := range holes {
+		outer = eliminateHole(hole, outer)
+	}
+	var indices []int
+	earClipLinked(outer, &indices, 0)
+	return indices
+}
+
+// earNode 是多边形的双向循环链表中的一个点
+type earNode struct {
+	i          int
+	x, y       float64
+	prev, next *earNode
+	steiner    bool
+}
+
+// newRing 函数创建线环的双向循环链表，ccw 为 true 时链表为逆时针方向，否则为顺时针方向
+func newRing(flatCoords []float64, offset, end, stride int, ccw bool) *earNode {
+	var last *earNode
+	if ccw == (signedArea(flatCoords, offset, end, stride) > 0) {
+		for i := offset; i < end; i += stride {
+			last = insertNode(i/stride, flatCoords[i], flatCoords[i+1], last)
+		}
+	} else {
+		for i := end - stride; i >= offset; i -= stride {
+			last = insertNode(i/stride, flatCoords[i], flatCoords[i+1], last)
+		}
+	}
+	if last != nil && equals(last, last.next) {
+		removeNode(last)
+		last = last.next
+	}
+	return last
+}
+
+// signedArea 函数返回线环面积的两倍，逆时针方向时为正
+func signedArea(flatCoords []float64, offset, end, stride int) float64 {
+	var sum float64
+	for i, j := offset, end-stride; i < end; j, i = i, i+stride {
+		sum += (flatCoords[j] - flatCoords[i]) * (flatCoords[i+1] + flatCoords[j+1])
+	}
+	return sum
+}
+
+// earClipLinked 函数剖分链表表示的多边形，pass 表示找不到耳朵时已经进行的补救措施
+func earClipLinked(ear *earNode, indices *[]int, pass int) {
+	if ear == nil {
+		return
+	}
+	stop := ear
+	for ear.prev != ear.next {
+		prev, next := ear.prev, ear.next
+		if isEar(ear) {
+			*indices = append(*indices, prev.i, ear.i, next.i)
+			removeNode(ear)
+			ear, stop = next.next, next.next
+			continue
+		}
+		ear = next
+		if ear == stop {
+			switch pass {
+			case 0:
+				earClipLinked(filterPoints(ear, nil), indices, 1)
+			case 1:
+				ear = cureLocalIntersections(filterPoints(ear, nil), indices)
+				earClipLinked(ear, indices, 2)
+			case 2:
+				splitEarClip(ear, indices)
+			}
+			return
+		}
+	}
+}
+
+// isEar 函数检测 ear 与其前后两个点组成的三角形是否是一个耳朵，即 ear 是凸点并且三角形中没有其他的凹点
+func isEar(ear *earNode) bool {
+	a, b, c := ear.prev, ear, ear.next
+	if area(a, b, c) >= 0 {
+		return false
+	}
+	minX, maxX := math.Min(a.x, math.Min(b.x, c.x)), math.Max(a.x, math.Max(b.x, c.x))
+	minY, maxY := math.Min(a.y, math.Min(b.y, c.y)), math.Max(a.y, math.Max(b.y, c.y))
+	for p := c.next; p != a; p = p.next {
+		if p.x >= minX && p.x <= maxX && p.y >= minY && p.y <= maxY &&
+			pointInTriangle(a.x, a.y, b.x, b.y, c.x, c.y, p.x, p.y) && area(p.prev, p, p.next) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// filterPoints 函数删除从 start 到 end 之间重复的点和共线的点
+func filterPoints(start, end *earNode) *earNode {
+	if start == nil {
+		return nil
+	}
+	if end == nil {
+		end = start
+	}
+	p := start
+	for {
+		again := false
+		if !p.steiner && (equals(p, p.next) || area(p.prev, p, p.next) == 0) {
+			removeNode(p)
+			p, end = p.prev, p.prev
+			if p == p.next {
+				break
+			}
+			again = true
+		} else {
+			p = p.next
+		}
+		if !again && p == end {
+			break
+		}
+	}
+	return end
+}
+
+// cureLocalIntersections 函数消除相邻两条边之间的自相交
+func cureLocalIntersections(start *earNode, indices *[]int) *earNode {
+	p := start
+	for {
+		a, b := p.prev, p.next.next
+		if !equals(a, b) && intersects(a, p, p.next, b) && locallyInside(a, b) && locallyInside(b, a) {
+			*indices = append(*indices, a.i, p.i, b.i)
+			removeNode(p)
+			removeNode(p.next)
+			p, start = b, b
+		}
+		p = p.next
+		if p == start {
+			break
+		}
+	}
+	return filterPoints(p, nil)
+}
+
+// splitEarClip 函数寻找一条有效的对角线，将多边形分割为两个部分并分别剖分
+func splitEarClip(start *earNode, indices *[]int) {
+	a := start
+	for {
+		for b := a.next.next; b != a.prev; b = b.next {
+			if a.i != b.i && isValidDiagonal(a, b) {
+				c := splitPolygon(a, b)
+				a = filterPoints(a, a.next)
+				c = filterPoints(c, c.next)
+				earClipLinked(a, indices, 0)
+				earClipLinked(c, indices, 0)
+				return
+			}
+		}
+		a = a.next
+		if a == start {
+			return
+		}
+	}
+}
+
+// eliminateHole 函数使用桥接边将洞连接到外环上
+func eliminateHole(hole, outer *earNode) *earNode {
+	bridge := findHoleBridge(hole, outer)
+	if bridge == nil {
+		return outer
+	}
+	bridgeReverse := splitPolygon(bridge, hole)
+	filterPoints(bridgeReverse, bridgeReverse.next)
+	return filterPoints(bridge, bridge.next)
+}
+
+// findHoleBridge 函数寻找外环上与洞的最左点 hole 相互可见的点
+func findHoleBridge(hole, outer *earNode) *earNode {
+	hx, hy := hole.x, hole.y
+	qx := math.Inf(-1)
+	var m *earNode
+	// 向左发射射线，寻找与射线相交的最近的边，以及边上 x 较小的端点
+	p := outer
+	for {
+		if hy <= p.y && hy >= p.next.y && p.next.y != p.y {
+			x := p.x + (hy-p.y)*(p.next.x-p.x)/(p.next.y-p.y)
+			if x <= hx && x > qx {
+				qx = x
+				m = p
+				if p.next.x < p.x {
+					m = p.next
+				}
+				if x == hx {
+					return m
+				}
+			}
+		}
+		p = p.next
+		if p == outer {
+			break
+		}
+	}
+	if m == nil {
+		return nil
+	}
+	// 如果由洞的点、交点和端点组成的三角形中有其他的点，选择与射线夹角最小的点
+	stop := m
+	mx, my := m.x, m.y
+	tanMin := math.Inf(1)
+	p = m
+	for {
+		ax, cx := qx, hx
+		if hy < my {
+			ax, cx = hx, qx
+		}
+		if hx >= p.x && p.x >= mx && hx != p.x && pointInTriangle(ax, hy, mx, my, cx, hy, p.x, p.y) {
+			tan := math.Abs(hy-p.y) / (hx - p.x)
+			if locallyInside(p, hole) &&
+				(tan < tanMin || (tan == tanMin && (p.x > m.x || (p.x == m.x && sectorContainsSector(m, p))))) {
+				m = p
+				tanMin = tan
+			}
+		}
+		p = p.next
+		if p == stop {
+			break
+		}
+	}
+	return m
+}
+
+// sectorContainsSector 函数检测点 m 处的扇形是否包含点 p 处的扇形
+func sectorContainsSector(m, p *earNode) bool {
+	return area(m.prev, m, p.prev) < 0 && area(p.next, m, m.next) < 0
+}
+
+// leftmost 函数返回链表中最左（x 最小，相同时 y 最小）的点
+func leftmost(start *earNode) *earNode {
+	result := start
+	for p := start.next; p != start; p = p.next {
+		if p.x < result.x || (p.x == result.x && p.y < result.y) {
+			result = p
+		}
+	}
+	return result
+}
+
+// pointInTriangle 函数检测点 (px, py) 是否位于三角形之内或边上
+func pointInTriangle(ax, ay, bx, by, cx, cy, px, py float64) bool {
+	return (cx-px)*(ay-py) >= (ax-px)*(cy-py) &&
+		(ax-px)*(by-py) >= (bx-px)*(ay-py) &&
+		(bx-px)*(cy-py) >= (cx-px)*(by-py)
+}
+
+// isValidDiagonal 函数检测 a-b 是否是多边形的一条有效的对角线
+func isValidDiagonal(a, b *earNode) bool {
+	return a.next.i != b.i && a.prev.i != b.i && !intersectsPolygon(a, b) &&
+		(locallyInside(a, b) && locallyInside(b, a) && middleInside(a, b) &&
+			(area(a.prev, a, b.prev) != 0 || area(a, b.prev, b) != 0) ||
+			equals(a, b) && area(a.prev, a, a.next) > 0 && area(b.prev, b, b.next) > 0)
+}
+
+// area 函数返回三角形 p-q-r 面积的两倍，逆时针方向时为负
+func area(p, q, r *earNode) float64 {
+	return (q.y-p.y)*(r.x-q.x) - (q.x-p.x)*(r.y-q.y)
+}
+
+func equals(p1, p2 *earNode) bool {
+	return p1.x == p2.x && p1.y == p2.y
+}
+
+// intersects 函数检测线段 p1-q1 与线段 p2-q2 是否相交
+func intersects(p1, q1, p2, q2 *earNode) bool {
+	o1, o2 := sign(area(p1, q1, p2)), sign(area(p1, q1, q2))
+	o3, o4 := sign(area(p2, q2, p1)), sign(area(p2, q2, q1))
+	return o1 != o2 && o3 != o4 ||
+		o1 == 0 && onSegment(p1, p2, q1) ||
+		o2 == 0 && onSegment(p1, q2, q1) ||
+		o3 == 0 && onSegment(p2, p1, q2) ||
+		o4 == 0 && onSegment(p2, q1, q2)
+}
+
+// onSegment 函数检测与线段 p-r 共线的点 q 是否位于线段上
+func onSegment(p, q, r *earNode) bool {
+	return q.x <= math.Max(p.x, r.x) && q.x >= math.Min(p.x, r.x) && q.y <= math.Max(p.y, r.y) && q.y >= math.Min(p.y, r.y)
+}
+
+func sign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+// intersectsPolygon 函数检测线段 a-b 是否与多边形的边相交
+func intersectsPolygon(a, b *earNode) bool {
+	p := a
+	for {
+		if p.i != a.i && p.next.i != a.i && p.i != b.i && p.next.i != b.i && intersects(p, p.next, a, b) {
+			return true
+		}
+		p = p.next
+		if p == a {
+			return false
+		}
+	}
+}
+
+// locallyInside 函数检测对角线 a-b 在点 a 附近是否位于多边形之内
+func locallyInside(a, b *earNode) bool {
+	if area(a.prev, a, a.next) < 0 {
+		return area(a, b, a.next) >= 0 && area(a, a.prev, b) >= 0
+	}
+	return area(a, b, a.prev) < 0 || area(a, a.next, b) < 0
+}
+
+// middleInside 函数检测对角线 a-b 的中点是否位于多边形之内
+func middleInside(a, b *earNode) bool {
+	inside := false
+	px, py := (a.x+b.x)/2, (a.y+b.y)/2
+	p := a
+	for {
+		if (p.y > py) != (p.next.y > py) && p.next.y != p.y &&
+			px < (p.next.x-p.x)*(py-p.y)/(p.next.y-p.y)+p.x {
+			inside = !inside
+		}
+		p = p.next
+		if p == a {
+			return inside
+		}
+	}
+}
+
+// splitPolygon 函数沿对角线 a-b 将多边形分割为两个部分，a 和 b 被复制，返回第二个部分中 b 的拷贝
+func splitPolygon(a, b *earNode) *earNode {
+	a2 := &earNode{i: a.i, x: a.x, y: a.y}
+	b2 := &earNode{i: b.i, x: b.x, y: b.y}
+	an, bp := a.next, b.prev
+	a.next, b.prev = b, a
+	a2.next, an.prev = an, a2
+	b2.next, a2.prev = a2, b2
+	bp.next, b2.prev = b2, bp
+	return b2
+}
+
+func insertNode(i int, x, y float64, last *earNode) *earNode {
+	p := &earNode{i: i, x: x, y: y}
+	if last == nil {
+		p.prev, p.next = p, p
+	} else {
+		p.next, p.prev = last.next, last
+		last.next.prev = p
+		last.next = p
+	}
+	return p
+}
+
+func removeNode(p *earNode) {
+	p.next.prev = p.prev
+	p.prev.next = p.next
+}
@@ -0,0 +1,34 @@
+// Package triangulate 包含了平面（XY）几何图形的三角剖分算法。
+//
+// EarClip 使用耳切法剖分带洞的多边形，Delaunay 计算点集的 Delaunay 三角剖分。
+// 两者都返回索引缓冲区：每三个索引组成一个逆时针方向的三角形，索引 i 对应平面坐标数组中的 flatCoords[i*stride:(i+1)*stride]，
+// 可以直接用于 WebGL 等图形接口。ToMultiPolygon 将索引缓冲区转换为由三角形组成的 MultiPolygon
+package triangulate
+
+import (
+	"github.com/chengxiaoer/geomGo"
+)
+
+// ToMultiPolygon函数 将索引缓冲区 indices 转换为由三角形组成的 MultiPolygon，三角形的坐标从 flatCoords 中复制，包含所有的维度
+func ToMultiPolygon(layout geom.Layout, flatCoords []float64, indices []int) *geom.MultiPolygon {
+	stride := layout.Stride()
+	triangles := make([]float64, 0, len(indices)/3*4*stride)
+	endss := make([][]int, 0, len(indices)/3)
+	for i := 0; i+2 < len(indices); i += 3 {
+		for _, j := range [4]int{indices[i], indices[i+1], indices[i+2], indices[i]} {
+			triangles = append(triangles, flatCoords[j*stride:(j+1)*stride]...)
+		}
+		endss = append(endss, []int{len(triangles)})
+	}
+	return geom.NewMultiPolygonFlat(layout, triangles, endss)
+}
+
+// PolygonTriangles函数 使用 EarClip 剖分多边形，并返回由三角形组成的 MultiPolygon，SRID 与多边形相同
+func PolygonTriangles(p *geom.Polygon) *geom.MultiPolygon {
+	return ToMultiPolygon(p.Layout(), p.FlatCoords(), EarClip(p)).SetSRID(p.SRID())
+}
+
+// DelaunayTriangles函数 计算点集的 Delaunay 三角剖分，并返回由三角形组成的 MultiPolygon，SRID 与点集相同
+func DelaunayTriangles(mp *geom.MultiPoint) *geom.MultiPolygon {
+	return ToMultiPolygon(mp.Layout(), mp.FlatCoords(), Delaunay(mp)).SetSRID(mp.SRID())
+}
@@ -0,0 +1,19 @@
+package triangulate_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy/triangulate"
+)
+
+func ExamplePolygonTriangles() {
+	square := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}})
+	fmt.Println(triangulate.EarClip(square))
+	s, _ := wkt.Marshal(triangulate.PolygonTriangles(square))
+	fmt.Println(s)
+	// Output:
+	// [3 0 1 1 2 3]
+	// MULTIPOLYGON (((0 1, 0 0, 1 0, 0 1)), ((1 0, 1 1, 0 1, 1 0)))
+}
@@ -0,0 +1,270 @@
+package triangulate_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/bigxy"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy/triangulate"
+)
+
+// triangleArea 函数返回三角形的有向面积，逆时针方向时为正
+func triangleArea(flatCoords []float64, stride int, i, j, k int) float64 {
+	ax, ay := flatCoords[i*stride], flatCoords[i*stride+1]
+	bx, by := flatCoords[j*stride], flatCoords[j*stride+1]
+	cx, cy := flatCoords[k*stride], flatCoords[k*stride+1]
+	return ((bx-ax)*(cy-ay) - (cx-ax)*(by-ay)) / 2
+}
+
+// polygonArea 函数返回多边形的面积，即外环的面积减去洞的面积
+func polygonArea(p *geom.Polygon) float64 {
+	var result float64
+	offset := 0
+	for i, end := range p.Ends() {
+		var a float64
+		for j := offset; j+p.Stride() < end; j += p.Stride() {
+			a += p.FlatCoords()[j]*p.FlatCoords()[j+p.Stride()+1] - p.FlatCoords()[j+p.Stride()]*p.FlatCoords()[j+1]
+		}
+		if i == 0 {
+			result += math.Abs(a) / 2
+		} else {
+			result -= math.Abs(a) / 2
+		}
+		offset = end
+	}
+	return result
+}
+
+func TestEarClip(t *testing.T) {
+	for i, tc := range []struct {
+		desc         string
+		polygon      string
+		numTriangles int
+	}{
+		{
+			desc:         "triangle",
+			polygon:      "POLYGON ((0 0, 1 0, 0 1, 0 0))",
+			numTriangles: 1,
+		},
+		{
+			desc:         "clockwise square",
+			polygon:      "POLYGON ((0 0, 0 1, 1 1, 1 0, 0 0))",
+			numTriangles: 2,
+		},
+		{
+			desc:         "concave",
+			polygon:      "POLYGON ((0 0, 10 0, 10 10, 5 2, 0 10, 0 0))",
+			numTriangles: 3,
+		},
+		{
+			desc:         "collinear and repeated points",
+			polygon:      "POLYGON ((0 0, 5 0, 5 0, 10 0, 10 10, 0 10, 0 0))",
+			numTriangles: 2,
+		},
+		{
+			desc:         "hole",
+			polygon:      "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 8 2, 2 2))",
+			numTriangles: 8,
+		},
+		{
+			desc:         "two holes",
+			polygon:      "POLYGON ((0 0, 20 0, 20 10, 0 10, 0 0), (2 2, 8 2, 8 8, 2 8, 2 2), (12 2, 18 2, 18 8, 12 8, 12 2))",
+			numTriangles: 12,
+		},
+		{
+			desc:         "hole touching shell",
+			polygon:      "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (0 5, 5 2, 5 8, 0 5))",
+			numTriangles: 6,
+		},
+		{
+			desc:         "degenerate",
+			polygon:      "POLYGON ((0 0, 1 1, 0 0))",
+			numTriangles: 0,
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.polygon)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v", i, tc.desc, tc.polygon, err)
+		}
+		p := g.(*geom.Polygon)
+		indices := triangulate.EarClip(p)
+		if len(indices) != 3*tc.numTriangles {
+			t.Errorf("%d: %s: len(triangulate.EarClip(%s)) == %d, want %d", i, tc.desc, tc.polygon, len(indices), 3*tc.numTriangles)
+			continue
+		}
+		var total float64
+		for j := 0; j < len(indices); j += 3 {
+			a := triangleArea(p.FlatCoords(), p.Stride(), indices[j], indices[j+1], indices[j+2])
+			if a <= 0 {
+				t.Errorf("%d: %s: triangle %v has area %v, want > 0", i, tc.desc, indices[j:j+3], a)
+			}
+			total += a
+		}
+		if want := polygonArea(p); math.Abs(total-want) > 1e-9 {
+			t.Errorf("%d: %s: total area of triangles == %v, want %v", i, tc.desc, total, want)
+		}
+	}
+}
+
+func TestEarClipStar(t *testing.T) {
+	for n := 3; n < 50; n++ {
+		var coords []geom.Coord
+		for i := 0; i < 2*n; i++ {
+			r := 10.0
+			if i%2 == 1 {
+				r = 4
+			}
+			angle := float64(i) * math.Pi / float64(n)
+			coords = append(coords, geom.Coord{r * math.Cos(angle), r * math.Sin(angle)})
+		}
+		coords = append(coords, coords[0])
+		p := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{coords})
+		indices := triangulate.EarClip(p)
+		if len(indices) != 3*(2*n-2) {
+			t.Errorf("n=%d: len(triangulate.EarClip(star)) == %d, want %d", n, len(indices), 3*(2*n-2))
+		}
+		var total float64
+		for j := 0; j < len(indices); j += 3 {
+			total += triangleArea(p.FlatCoords(), p.Stride(), indices[j], indices[j+1], indices[j+2])
+		}
+		if want := polygonArea(p); math.Abs(total-want) > 1e-9*want {
+			t.Errorf("n=%d: total area of triangles == %v, want %v", n, total, want)
+		}
+	}
+}
+
+func TestDelaunay(t *testing.T) {
+	for i, tc := range []struct {
+		desc         string
+		points       []float64
+		numTriangles int
+	}{
+		{
+			desc:         "triangle",
+			points:       []float64{0, 0, 1, 0, 0, 1},
+			numTriangles: 1,
+		},
+		{
+			desc:         "square",
+			points:       []float64{0, 0, 1, 0, 1, 1, 0, 1},
+			numTriangles: 2,
+		},
+		{
+			desc:         "grid",
+			points:       []float64{0, 0, 1, 0, 2, 0, 0, 1, 1, 1, 2, 1, 0, 2, 1, 2, 2, 2},
+			numTriangles: 8,
+		},
+		{
+			desc:         "collinear start",
+			points:       []float64{0, 0, 1, 0, 2, 0, 3, 0, 1, 1},
+			numTriangles: 3,
+		},
+		{
+			desc:         "duplicates",
+			points:       []float64{0, 0, 1, 0, 0, 1, 1, 0, 0, 0},
+			numTriangles: 1,
+		},
+		{
+			desc:         "collinear",
+			points:       []float64{0, 0, 1, 1, 2, 2, 3, 3},
+			numTriangles: 0,
+		},
+		{
+			desc:         "two points",
+			points:       []float64{0, 0, 1, 1},
+			numTriangles: 0,
+		},
+	} {
+		mp := geom.NewMultiPointFlat(geom.XY, tc.points)
+		indices := triangulate.Delaunay(mp)
+		if len(indices) != 3*tc.numTriangles {
+			t.Errorf("%d: %s: len(triangulate.Delaunay(...)) == %d, want %d", i, tc.desc, len(indices), 3*tc.numTriangles)
+			continue
+		}
+		checkDelaunay(t, tc.desc, mp, indices)
+	}
+}
+
+func TestDelaunayRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 3; n < 200; n += 7 {
+		flatCoords := make([]float64, 0, 2*n)
+		for i := 0; i < n; i++ {
+			// 将坐标取整以产生大量共线和共圆的点
+			flatCoords = append(flatCoords, math.Floor(r.Float64()*20), math.Floor(r.Float64()*20))
+		}
+		mp := geom.NewMultiPointFlat(geom.XY, flatCoords)
+		checkDelaunay(t, "random", mp, triangulate.Delaunay(mp))
+	}
+}
+
+// checkDelaunay 函数检测三角形都是逆时针方向，外接圆中没有其他的点，并且三角形的面积之和等于凸包的面积
+func checkDelaunay(t *testing.T, desc string, mp *geom.MultiPoint, indices []int) {
+	flatCoords, stride := mp.FlatCoords(), mp.Stride()
+	var total float64
+	for j := 0; j < len(indices); j += 3 {
+		a := triangleArea(flatCoords, stride, indices[j], indices[j+1], indices[j+2])
+		if a <= 0 {
+			t.Errorf("%s: triangle %v has area %v, want > 0", desc, indices[j:j+3], a)
+		}
+		total += a
+		for k := 0; k < mp.NumPoints(); k++ {
+			if bigxy.IsInCircle(mp.Coord(indices[j]), mp.Coord(indices[j+1]), mp.Coord(indices[j+2]), mp.Coord(k)) {
+				t.Errorf("%s: point %v is inside the circumcircle of triangle %v", desc, mp.Coord(k), indices[j:j+3])
+			}
+		}
+	}
+	if want := hullArea(mp); math.Abs(total-want) > 1e-9*math.Max(1, want) {
+		t.Errorf("%s: total area of triangles == %v, want %v", desc, total, want)
+	}
+}
+
+// hullArea 函数使用 Andrew 单调链算法计算点集凸包的面积
+func hullArea(mp *geom.MultiPoint) float64 {
+	var points []geom.Coord
+	for i := 0; i < mp.NumPoints(); i++ {
+		points = append(points, mp.Coord(i))
+	}
+	for i := 1; i < len(points); i++ {
+		for j := i; j > 0 && (points[j][0] < points[j-1][0] || points[j][0] == points[j-1][0] && points[j][1] < points[j-1][1]); j-- {
+			points[j], points[j-1] = points[j-1], points[j]
+		}
+	}
+	cross := func(o, a, b geom.Coord) float64 {
+		return (a[0]-o[0])*(b[1]-o[1]) - (a[1]-o[1])*(b[0]-o[0])
+	}
+	var hull []geom.Coord
+	for _, pass := range [2]bool{false, true} {
+		start := len(hull)
+		for k := range points {
+			p := points[k]
+			if pass {
+				p = points[len(points)-1-k]
+			}
+			for len(hull) >= start+2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+	}
+	var a float64
+	for i := range hull {
+		j := (i + 1) % len(hull)
+		a += hull[i][0]*hull[j][1] - hull[j][0]*hull[i][1]
+	}
+	return a / 2
+}
+
+func TestToMultiPolygon(t *testing.T) {
+	flatCoords := []float64{0, 0, 1, 1, 0, 2, 0, 1, 3}
+	got := triangulate.ToMultiPolygon(geom.XYZ, flatCoords, []int{0, 1, 2})
+	want := geom.NewMultiPolygonFlat(geom.XYZ, []float64{0, 0, 1, 1, 0, 2, 0, 1, 3, 0, 0, 1}, [][]int{{12}})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("triangulate.ToMultiPolygon(...) == %v, want %v", got, want)
+	}
+}
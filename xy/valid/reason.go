@@ -0,0 +1,69 @@
+package valid
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+)
+
+// Type 枚举了几何图形无效的原因
+type Type int
+
+const (
+	// Valid 表示几何图形是有效的
+	Valid Type = iota
+	// InvalidCoordinate 表示坐标包含 NaN 或无穷大
+	InvalidCoordinate
+	// TooFewPoints 表示线或线环去除重复点之后的坐标数目太少
+	TooFewPoints
+	// RingNotClosed 表示线环的起点与终点不同
+	RingNotClosed
+	// RingSelfIntersection 表示线环自相交或自接触
+	RingSelfIntersection
+	// SelfIntersection 表示不同的线环相互交叉或部分重叠
+	SelfIntersection
+	// HoleOutsideShell 表示洞位于外边界之外
+	HoleOutsideShell
+	// NestedHoles 表示洞位于另一个洞之内
+	NestedHoles
+	// DisconnectedInterior 表示线环之间的接触将多边形的内部分割为多个部分
+	DisconnectedInterior
+	// NestedShells 表示多多边形中的一个多边形位于另一个多边形的内部
+	NestedShells
+	// UnsupportedType 表示不支持的几何图形类型
+	UnsupportedType
+)
+
+var typeLabels = [...]string{
+	Valid:                "Valid",
+	InvalidCoordinate:    "Invalid coordinate",
+	TooFewPoints:         "Too few distinct points",
+	RingNotClosed:        "Ring is not closed",
+	RingSelfIntersection: "Ring self-intersection",
+	SelfIntersection:     "Self-intersection",
+	HoleOutsideShell:     "Hole lies outside shell",
+	NestedHoles:          "Holes are nested",
+	DisconnectedInterior: "Interior is disconnected",
+	NestedShells:         "Nested shells",
+	UnsupportedType:      "Unsupported geometry type",
+}
+
+func (t Type) String() string {
+	if t < 0 || int(t) >= len(typeLabels) {
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+	return typeLabels[t]
+}
+
+// Reason 描述几何图形无效的原因以及出错的位置，Location 只包含 x、y 坐标，没有具体位置时为 nil
+type Reason struct {
+	Type     Type
+	Location geom.Coord
+}
+
+func (r Reason) String() string {
+	if r.Location == nil {
+		return r.Type.String()
+	}
+	return fmt.Sprintf("%s at %v", r.Type, r.Location)
+}
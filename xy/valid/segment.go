@@ -0,0 +1,100 @@
+package valid
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/internal/lineintersector"
+	"github.com/chengxiaoer/geomGo/xy/internal/planar"
+	"github.com/chengxiaoer/geomGo/xy/lineintersection"
+)
+
+// segment 是线或线环中的一条线段，part 和 ring 分别为所属的多边形（或线）和线环的序号，index 为线段在线环中的序号
+type segment struct {
+	p0, p1     geom.Coord
+	part, ring int
+	index      int
+	minX, maxX float64
+	minY, maxY float64
+}
+
+// line 是去除了连续重复点之后的线或线环的 x、y 坐标
+type line []float64
+
+// newLine 函数复制 x、y 坐标并去除连续的重复点
+func newLine(flatCoords []float64, offset, end, stride int) line {
+	return line(planar.XYCoords(flatCoords, offset, end, stride))
+}
+
+func (l line) numPoints() int {
+	return len(l) / 2
+}
+
+func (l line) point(i int) geom.Coord {
+	return geom.Coord(l[2*i : 2*i+2])
+}
+
+func (l line) isClosed() bool {
+	n := len(l)
+	return n >= 4 && l[0] == l[n-2] && l[1] == l[n-1]
+}
+
+// segments 方法追加线的所有线段
+func (l line) segments(part, ring int, dst []segment) []segment {
+	for i := 0; i+1 < l.numPoints(); i++ {
+		p0, p1 := l.point(i), l.point(i+1)
+		dst = append(dst, segment{
+			p0: p0, p1: p1,
+			part: part, ring: ring, index: i,
+			minX: math.Min(p0[0], p1[0]), maxX: math.Max(p0[0], p1[0]),
+			minY: math.Min(p0[1], p1[1]), maxY: math.Max(p0[1], p1[1]),
+		})
+	}
+	return dst
+}
+
+// forEachPair 函数使用扫描线算法对包围盒相交的每一对线段调用 f，f 返回 false 时停止并返回 false
+func forEachPair(segs []segment, f func(a, b *segment) bool) bool {
+	sort.Slice(segs, func(i, j int) bool { return segs[i].minX < segs[j].minX })
+	for i := range segs {
+		a := &segs[i]
+		for j := i + 1; j < len(segs) && segs[j].minX <= a.maxX; j++ {
+			b := &segs[j]
+			if b.minY > a.maxY || a.minY > b.maxY {
+				continue
+			}
+			if !f(a, b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func intersect(a, b *segment) lineintersection.Result {
+	return lineintersector.LineIntersectsLine(lineintersector.RobustLineIntersector{}, a.p0, a.p1, b.p0, b.p1)
+}
+
+func equal2D(c1, c2 geom.Coord) bool {
+	return c1[0] == c2[0] && c1[1] == c2[1]
+}
+
+// isEndpoint 函数检测 c 是否为线段的端点
+func isEndpoint(s *segment, c geom.Coord) bool {
+	return equal2D(s.p0, c) || equal2D(s.p1, c)
+}
+
+// adjacentInRing 函数检测同一个闭合线环中的两条线段是否相邻，n 为线环的线段数目
+func adjacentInRing(a, b *segment, n int) bool {
+	i, j := a.index, b.index
+	if i > j {
+		i, j = j, i
+	}
+	return j == i+1 || i == 0 && j == n-1
+}
+
+// clone 函数复制交点，交点的底层数组属于 lineintersector
+func clone(c geom.Coord) geom.Coord {
+	return geom.Coord{c[0], c[1]}
+}
@@ -0,0 +1,108 @@
+package valid
+
+import (
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/lineintersection"
+)
+
+// IsSimple函数 检测几何图形是否是简单的，即除了边界点之外没有自相交或自接触：
+// 多点中没有重复的点；线除了闭合线的起点和终点之外没有自相交；多线中的每条线都是简单的，
+// 并且不同的线只在双方的边界点（按照 mod-2 规则）上相交；多边形的每个线环都是简单的；几何图形集合中的每个几何图形都是简单的
+func IsSimple(g geom.T) bool {
+	switch g := g.(type) {
+	case *geom.Point:
+		return true
+	case *geom.MultiPoint:
+		seen := make(map[[2]float64]bool)
+		for i := 0; i < len(g.FlatCoords()); i += g.Stride() {
+			key := [2]float64{g.FlatCoords()[i], g.FlatCoords()[i+1]}
+			if seen[key] {
+				return false
+			}
+			seen[key] = true
+		}
+		return true
+	case *geom.LineString, *geom.LinearRing:
+		return isSimpleLines([]line{newLine(g.FlatCoords(), 0, len(g.FlatCoords()), g.Stride())}, false)
+	case *geom.MultiLineString:
+		return isSimpleLines(splitLines(g.FlatCoords(), 0, g.Ends(), g.Stride()), true)
+	case *geom.Polygon:
+		for _, ring := range splitLines(g.FlatCoords(), 0, g.Ends(), g.Stride()) {
+			if !isSimpleLines([]line{ring}, false) {
+				return false
+			}
+		}
+		return true
+	case *geom.MultiPolygon:
+		offset := 0
+		for _, ends := range g.Endss() {
+			for _, ring := range splitLines(g.FlatCoords(), offset, ends, g.Stride()) {
+				if !isSimpleLines([]line{ring}, false) {
+					return false
+				}
+			}
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+		return true
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if !IsSimple(child) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func splitLines(flatCoords []float64, offset int, ends []int, stride int) []line {
+	lines := make([]line, len(ends))
+	for i, end := range ends {
+		lines[i] = newLine(flatCoords, offset, end, stride)
+		offset = end
+	}
+	return lines
+}
+
+// isSimpleLines 函数检测每条线是否是简单的，multi 为 true 时还检测不同的线是否只在双方的边界点上相交
+func isSimpleLines(lines []line, multi bool) bool {
+	var segs []segment
+	boundary := make(map[[2]float64]int)
+	for i, l := range lines {
+		segs = l.segments(i, 0, segs)
+		if l.numPoints() >= 2 && !l.isClosed() {
+			boundary[[2]float64{l[0], l[1]}]++
+			boundary[[2]float64{l[len(l)-2], l[len(l)-1]}]++
+		}
+	}
+	isBoundary := func(l line, p geom.Coord) bool {
+		n := l.numPoints()
+		isEnd := equal2D(p, l.point(0)) || equal2D(p, l.point(n-1))
+		return isEnd && boundary[[2]float64{p[0], p[1]}]%2 == 1
+	}
+	return forEachPair(segs, func(a, b *segment) bool {
+		result := intersect(a, b)
+		if !result.HasIntersection() {
+			return true
+		}
+		p := result.Intersection()[0]
+		if a.part != b.part {
+			return multi && result.Type() == lineintersection.PointIntersection &&
+				isBoundary(lines[a.part], p) && isBoundary(lines[b.part], p)
+		}
+		if a.index > b.index {
+			a, b = b, a
+		}
+		if result.Type() == lineintersection.CollinearIntersection {
+			return false
+		}
+		if b.index == a.index+1 {
+			return true
+		}
+		l := lines[a.part]
+		return l.isClosed() && a.index == 0 && b.index == l.numPoints()-2 && equal2D(p, l.point(0))
+	})
+}
@@ -0,0 +1,392 @@
+// Package valid 包含了检测几何图形是否符合 OGC Simple Features 规范的有效性和简单性的函数。
+//
+// 几何图形的构造函数只检查坐标数组的结构，IsValid 进一步检查几何图形的拓扑：线环必须闭合且至少包含4个坐标，
+// 线环不能自相交，线环之间只能在有限个点上接触，洞必须位于外边界之内且不能相互嵌套，多边形的内部必须是连通的，
+// 多多边形中的多边形不能相互嵌套。只使用坐标的 x、y 值，连续的重复点被忽略
+package valid
+
+import (
+	"math"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/lineintersection"
+	"github.com/chengxiaoer/geomGo/xy/location"
+)
+
+// IsValid函数 检测几何图形是否有效，无效时返回第一个发现的原因
+func IsValid(g geom.T) (bool, Reason) {
+	reason := validate(g)
+	return reason.Type == Valid, reason
+}
+
+func validate(g geom.T) Reason {
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		return checkCoords(g.FlatCoords(), g.Stride())
+	case *geom.LineString:
+		if reason := checkCoords(g.FlatCoords(), g.Stride()); reason.Type != Valid {
+			return reason
+		}
+		return checkLineString(g.FlatCoords(), 0, len(g.FlatCoords()), g.Stride())
+	case *geom.MultiLineString:
+		if reason := checkCoords(g.FlatCoords(), g.Stride()); reason.Type != Valid {
+			return reason
+		}
+		offset := 0
+		for _, end := range g.Ends() {
+			if reason := checkLineString(g.FlatCoords(), offset, end, g.Stride()); reason.Type != Valid {
+				return reason
+			}
+			offset = end
+		}
+		return Reason{}
+	case *geom.LinearRing:
+		if reason := checkCoords(g.FlatCoords(), g.Stride()); reason.Type != Valid || len(g.FlatCoords()) == 0 {
+			return reason
+		}
+		ring, reason := checkRing(g.FlatCoords(), 0, len(g.FlatCoords()), g.Stride())
+		if reason.Type != Valid {
+			return reason
+		}
+		return checkPolygonal([][]line{{ring}})
+	case *geom.Polygon:
+		if reason := checkCoords(g.FlatCoords(), g.Stride()); reason.Type != Valid {
+			return reason
+		}
+		rings, reason := checkRings(g.FlatCoords(), 0, g.Ends(), g.Stride())
+		if reason.Type != Valid {
+			return reason
+		}
+		return checkPolygonal([][]line{rings})
+	case *geom.MultiPolygon:
+		if reason := checkCoords(g.FlatCoords(), g.Stride()); reason.Type != Valid {
+			return reason
+		}
+		var polygons [][]line
+		offset := 0
+		for _, ends := range g.Endss() {
+			rings, reason := checkRings(g.FlatCoords(), offset, ends, g.Stride())
+			if reason.Type != Valid {
+				return reason
+			}
+			polygons = append(polygons, rings)
+			if len(ends) > 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+		return checkPolygonal(polygons)
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			if reason := validate(child); reason.Type != Valid {
+				return reason
+			}
+		}
+		return Reason{}
+	default:
+		return Reason{Type: UnsupportedType}
+	}
+}
+
+// checkCoords 函数检测所有坐标的 x、y 值是否为有限的数
+func checkCoords(flatCoords []float64, stride int) Reason {
+	for i := 0; i+1 < len(flatCoords); i += stride {
+		x, y := flatCoords[i], flatCoords[i+1]
+		if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+			return Reason{Type: InvalidCoordinate, Location: geom.Coord{x, y}}
+		}
+	}
+	return Reason{}
+}
+
+// checkLineString 函数检测非空的线至少包含两个不同的点
+func checkLineString(flatCoords []float64, offset, end, stride int) Reason {
+	if end == offset {
+		return Reason{}
+	}
+	if l := newLine(flatCoords, offset, end, stride); l.numPoints() < 2 {
+		return Reason{Type: TooFewPoints, Location: clone(l.point(0))}
+	}
+	return Reason{}
+}
+
+// checkRing 函数检测线环是否闭合并且至少包含4个坐标，返回去除重复点之后的线环
+func checkRing(flatCoords []float64, offset, end, stride int) (line, Reason) {
+	if end == offset {
+		return nil, Reason{Type: TooFewPoints}
+	}
+	if flatCoords[offset] != flatCoords[end-stride] || flatCoords[offset+1] != flatCoords[end-stride+1] {
+		return nil, Reason{Type: RingNotClosed, Location: geom.Coord{flatCoords[offset], flatCoords[offset+1]}}
+	}
+	ring := newLine(flatCoords, offset, end, stride)
+	if ring.numPoints() < 4 {
+		return nil, Reason{Type: TooFewPoints, Location: clone(ring.point(0))}
+	}
+	return ring, Reason{}
+}
+
+func checkRings(flatCoords []float64, offset int, ends []int, stride int) ([]line, Reason) {
+	rings := make([]line, len(ends))
+	for i, end := range ends {
+		ring, reason := checkRing(flatCoords, offset, end, stride)
+		if reason.Type != Valid {
+			return nil, reason
+		}
+		rings[i] = ring
+		offset = end
+	}
+	return rings, Reason{}
+}
+
+// checkPolygonal 函数检测多边形（每个多边形为线环的数组，第一个为外边界）的拓扑关系
+func checkPolygonal(polygons [][]line) Reason {
+	if reason := checkIntersections(polygons); reason.Type != Valid {
+		return reason
+	}
+	for _, rings := range polygons {
+		if reason := checkHoles(rings); reason.Type != Valid {
+			return reason
+		}
+	}
+	return checkShells(polygons)
+}
+
+// touch 是线环经过接触点的记录，neighbours 为线环中与接触点相邻的两个点
+type touch struct {
+	part, ring int
+	neighbours [2]geom.Coord
+}
+
+// touchPoint 是线环之间的接触点
+type touchPoint struct {
+	location geom.Coord
+	touches  []touch
+}
+
+// checkIntersections 函数检测线环的自相交、线环之间的交叉和重叠，以及多边形内部的连通性
+func checkIntersections(polygons [][]line) Reason {
+	var segs []segment
+	for part, rings := range polygons {
+		for i, ring := range rings {
+			segs = ring.segments(part, i, segs)
+		}
+	}
+	var reason Reason
+	var points []*touchPoint
+	index := make(map[[2]float64]*touchPoint)
+	addTouch := func(p geom.Coord, s *segment) {
+		key := [2]float64{p[0], p[1]}
+		tp, ok := index[key]
+		if !ok {
+			tp = &touchPoint{location: clone(p)}
+			index[key] = tp
+			points = append(points, tp)
+		}
+		for _, t := range tp.touches {
+			if t.part == s.part && t.ring == s.ring {
+				return
+			}
+		}
+		tp.touches = append(tp.touches, touch{part: s.part, ring: s.ring, neighbours: neighbours(polygons[s.part][s.ring], s, p)})
+	}
+	forEachPair(segs, func(a, b *segment) bool {
+		result := intersect(a, b)
+		if !result.HasIntersection() {
+			return true
+		}
+		p := result.Intersection()[0]
+		if a.part == b.part && a.ring == b.ring {
+			n := polygons[a.part][a.ring].numPoints() - 1
+			if !adjacentInRing(a, b, n) || result.Type() == lineintersection.CollinearIntersection {
+				reason = Reason{Type: RingSelfIntersection, Location: clone(p)}
+				return false
+			}
+			return true
+		}
+		if result.Type() == lineintersection.CollinearIntersection || !isEndpoint(a, p) && !isEndpoint(b, p) {
+			reason = Reason{Type: SelfIntersection, Location: clone(p)}
+			return false
+		}
+		addTouch(p, a)
+		addTouch(p, b)
+		return true
+	})
+	if reason.Type != Valid {
+		return reason
+	}
+
+	// 线环和接触点构成一个二分图，同一个多边形中的二分图存在环时多边形的内部不连通
+	uf := newUnionFind()
+	for _, tp := range points {
+		for i, t1 := range tp.touches {
+			for _, t2 := range tp.touches[i+1:] {
+				if crosses(tp.location, t1.neighbours, t2.neighbours) {
+					return Reason{Type: SelfIntersection, Location: tp.location}
+				}
+			}
+		}
+		for _, t := range tp.touches {
+			ringNode := node{part: t.part, ring: t.ring}
+			pointNode := node{part: t.part, ring: -1, x: tp.location[0], y: tp.location[1]}
+			if !uf.union(ringNode, pointNode) {
+				return Reason{Type: DisconnectedInterior, Location: tp.location}
+			}
+		}
+	}
+	return Reason{}
+}
+
+// neighbours 函数返回闭合线环中与线段 s 上的点 p 相邻的两个点
+func neighbours(ring line, s *segment, p geom.Coord) [2]geom.Coord {
+	var k int
+	switch {
+	case equal2D(p, s.p0):
+		k = s.index
+	case equal2D(p, s.p1):
+		k = s.index + 1
+	default:
+		return [2]geom.Coord{s.p0, s.p1}
+	}
+	n := ring.numPoints() - 1
+	return [2]geom.Coord{ring.point((k + n - 1) % n), ring.point((k + 1) % n)}
+}
+
+// crosses 函数检测在点 p 接触的两个线环是否相互交叉，即第二个线环的两条边分别位于第一个线环的两条边的两侧
+func crosses(p geom.Coord, a, b [2]geom.Coord) bool {
+	angle := func(c geom.Coord) float64 {
+		return math.Atan2(c[1]-p[1], c[0]-p[0])
+	}
+	a0 := angle(a[0])
+	relative := func(c geom.Coord) float64 {
+		r := math.Mod(angle(c)-a0, 2*math.Pi)
+		if r < 0 {
+			r += 2 * math.Pi
+		}
+		return r
+	}
+	a1 := relative(a[1])
+	b0, b1 := relative(b[0]), relative(b[1])
+	if b0 == 0 || b0 == a1 || b1 == 0 || b1 == a1 {
+		// 有公共的边，重叠已经在线段相交时检测
+		return false
+	}
+	return (b0 < a1) != (b1 < a1)
+}
+
+// checkHoles 函数检测洞是否位于外边界之内并且没有相互嵌套
+func checkHoles(rings []line) Reason {
+	if len(rings) < 2 {
+		return Reason{}
+	}
+	shell := rings[0]
+	for _, hole := range rings[1:] {
+		p, ok := pointNotOnRing(hole, shell)
+		if !ok {
+			return Reason{Type: SelfIntersection, Location: clone(hole.point(0))}
+		}
+		if xy.LocatePointInRing(geom.XY, p, shell) != location.Interior {
+			return Reason{Type: HoleOutsideShell, Location: p}
+		}
+	}
+	for i, hole := range rings[1:] {
+		for j, other := range rings[1:] {
+			if i == j || !boundsWithin(hole, other) {
+				continue
+			}
+			p, ok := pointNotOnRing(hole, other)
+			if !ok {
+				return Reason{Type: SelfIntersection, Location: clone(hole.point(0))}
+			}
+			if xy.LocatePointInRing(geom.XY, p, other) == location.Interior {
+				return Reason{Type: NestedHoles, Location: p}
+			}
+		}
+	}
+	return Reason{}
+}
+
+// checkShells 函数检测多多边形中的外边界是否位于另一个多边形的内部（而不是洞中）
+func checkShells(polygons [][]line) Reason {
+	for i, rings := range polygons {
+		if len(rings) == 0 {
+			continue
+		}
+		shell := rings[0]
+	others:
+		for j, other := range polygons {
+			if i == j || len(other) == 0 || !boundsWithin(shell, other[0]) {
+				continue
+			}
+			p, ok := pointNotOnRing(shell, other[0])
+			if !ok {
+				return Reason{Type: SelfIntersection, Location: clone(shell.point(0))}
+			}
+			if xy.LocatePointInRing(geom.XY, p, other[0]) != location.Interior {
+				continue
+			}
+			for _, hole := range other[1:] {
+				if q, ok := pointNotOnRing(shell, hole); ok && xy.LocatePointInRing(geom.XY, q, hole) == location.Interior {
+					continue others
+				}
+			}
+			return Reason{Type: NestedShells, Location: p}
+		}
+	}
+	return Reason{}
+}
+
+// pointNotOnRing 函数返回线环 a 中不在线环 b 上的一个顶点或线段中点
+func pointNotOnRing(a, b line) (geom.Coord, bool) {
+	for i := 0; i < a.numPoints(); i++ {
+		if p := a.point(i); xy.LocatePointInRing(geom.XY, p, b) != location.Boundary {
+			return clone(p), true
+		}
+	}
+	for i := 0; i+1 < a.numPoints(); i++ {
+		p0, p1 := a.point(i), a.point(i+1)
+		if p := (geom.Coord{(p0[0] + p1[0]) / 2, (p0[1] + p1[1]) / 2}); xy.LocatePointInRing(geom.XY, p, b) != location.Boundary {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// boundsWithin 函数检测 a 的包围盒是否位于 b 的包围盒之内
+func boundsWithin(a, b line) bool {
+	ba := geom.NewBounds(geom.XY).Extend(geom.NewLineStringFlat(geom.XY, a))
+	bb := geom.NewBounds(geom.XY).Extend(geom.NewLineStringFlat(geom.XY, b))
+	return bb.Min(0) <= ba.Min(0) && ba.Max(0) <= bb.Max(0) && bb.Min(1) <= ba.Min(1) && ba.Max(1) <= bb.Max(1)
+}
+
+// node 是并查集中的线环或接触点，接触点的 ring 为 -1
+type node struct {
+	part, ring int
+	x, y       float64
+}
+
+// unionFind 是并查集
+type unionFind map[node]node
+
+func newUnionFind() unionFind {
+	return make(unionFind)
+}
+
+func (uf unionFind) find(x node) node {
+	parent, ok := uf[x]
+	if !ok || parent == x {
+		return x
+	}
+	root := uf.find(parent)
+	uf[x] = root
+	return root
+}
+
+// union 方法合并两个集合，两者已经在同一个集合中时返回 false
+func (uf unionFind) union(x, y node) bool {
+	rx, ry := uf.find(x), uf.find(y)
+	if rx == ry {
+		return false
+	}
+	uf[rx] = ry
+	return true
+}
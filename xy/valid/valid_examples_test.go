@@ -0,0 +1,21 @@
+package valid_test
+
+import (
+	"fmt"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/valid"
+)
+
+func ExampleIsValid() {
+	bowTie := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 10, 10, 10, 0, 0, 10, 0, 0}, []int{10})
+	ok, reason := valid.IsValid(bowTie)
+	fmt.Println(ok, reason)
+	// Output: false Ring self-intersection at [5 5]
+}
+
+func ExampleIsSimple() {
+	ls := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 10, 10, 0, 0, 10})
+	fmt.Println(valid.IsSimple(ls))
+	// Output: false
+}
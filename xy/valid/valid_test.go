@@ -0,0 +1,129 @@
+package valid_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy/valid"
+)
+
+func TestIsValid(t *testing.T) {
+	for i, tc := range []struct {
+		desc     string
+		wkt      string
+		expected valid.Type
+		// location 为 nil 时不检查出错的位置
+		location geom.Coord
+	}{
+		{desc: "point", wkt: "POINT (1 2)", expected: valid.Valid},
+		{desc: "empty point", wkt: "POINT EMPTY", expected: valid.Valid},
+		{desc: "NaN", wkt: "POINT (NaN 2)", expected: valid.InvalidCoordinate},
+		{desc: "infinite", wkt: "LINESTRING (0 0, Inf 2)", expected: valid.InvalidCoordinate},
+		{desc: "line", wkt: "LINESTRING (0 0, 1 1, 0 1, 1 0)", expected: valid.Valid},
+		{desc: "empty line", wkt: "LINESTRING EMPTY", expected: valid.Valid},
+		{desc: "line with one distinct point", wkt: "LINESTRING (1 1, 1 1)", expected: valid.TooFewPoints, location: geom.Coord{1, 1}},
+		{desc: "multi line", wkt: "MULTILINESTRING ((0 0, 1 1), (2 2, 2 2))", expected: valid.TooFewPoints, location: geom.Coord{2, 2}},
+		{desc: "square", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0))", expected: valid.Valid},
+		{desc: "empty polygon", wkt: "POLYGON EMPTY", expected: valid.Valid},
+		{desc: "repeated points", wkt: "POLYGON ((0 0, 0 0, 10 0, 10 10, 10 10, 0 10, 0 0))", expected: valid.Valid},
+		{desc: "square with hole", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 8 2, 2 2))", expected: valid.Valid},
+		{desc: "hole touching shell", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (0 5, 5 2, 5 8, 0 5))", expected: valid.Valid},
+		{desc: "hole touching shell edge", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (5 0, 8 5, 2 5, 5 0))", expected: valid.Valid},
+		{desc: "holes touching", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 5 5, 2 8, 2 2), (8 2, 5 5, 8 8, 8 2))", expected: valid.Valid},
+		{desc: "unclosed ring", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10))", expected: valid.RingNotClosed, location: geom.Coord{0, 0}},
+		{desc: "collapsed ring", wkt: "POLYGON ((0 0, 10 0, 0 0))", expected: valid.TooFewPoints, location: geom.Coord{0, 0}},
+		{desc: "collapsed by repeated points", wkt: "POLYGON ((0 0, 10 0, 10 0, 0 0, 0 0))", expected: valid.TooFewPoints, location: geom.Coord{0, 0}},
+		{desc: "bow tie", wkt: "POLYGON ((0 0, 10 10, 10 0, 0 10, 0 0))", expected: valid.RingSelfIntersection, location: geom.Coord{5, 5}},
+		{desc: "self touching ring", wkt: "POLYGON ((0 0, 10 0, 10 10, 5 0, 0 10, 0 0))", expected: valid.RingSelfIntersection, location: geom.Coord{5, 0}},
+		{desc: "spike", wkt: "POLYGON ((0 0, 10 0, 10 10, 10 20, 10 10, 0 10, 0 0))", expected: valid.RingSelfIntersection},
+		{desc: "hole outside shell", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (20 20, 21 20, 21 21, 20 21, 20 20))", expected: valid.HoleOutsideShell, location: geom.Coord{20, 20}},
+		{desc: "shell inside hole", wkt: "POLYGON ((2 2, 8 2, 8 8, 2 8, 2 2), (0 0, 10 0, 10 10, 0 10, 0 0))", expected: valid.HoleOutsideShell, location: geom.Coord{0, 0}},
+		{desc: "hole crossing shell", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (5 5, 15 5, 15 6, 5 6, 5 5))", expected: valid.SelfIntersection},
+		{desc: "hole crossing shell at vertices", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 5 0, 5 -2, 7 0, 8 2, 2 2))", expected: valid.SelfIntersection},
+		{desc: "hole overlapping shell edge", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 0, 8 0, 5 5, 2 0))", expected: valid.SelfIntersection},
+		{desc: "duplicate rings", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (0 0, 10 0, 10 10, 0 10, 0 0))", expected: valid.SelfIntersection},
+		{desc: "nested holes", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 9 1, 9 9, 1 9, 1 1), (2 2, 3 2, 3 3, 2 3, 2 2))", expected: valid.NestedHoles, location: geom.Coord{2, 2}},
+		{desc: "hole touching shell twice", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (0 5, 5 0, 10 5, 5 10, 0 5))", expected: valid.DisconnectedInterior},
+		{desc: "holes forming a cycle", wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 4 2, 3 4, 2 2), (4 2, 6 2, 5 4, 4 2), (3 4, 5 4, 4 6, 3 4))", expected: valid.DisconnectedInterior},
+		{desc: "disjoint multi polygon", wkt: "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((2 0, 3 0, 3 1, 2 1, 2 0)))", expected: valid.Valid},
+		{desc: "multi polygon touching at a point", wkt: "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((1 1, 2 1, 2 2, 1 2, 1 1)))", expected: valid.Valid},
+		{desc: "multi polygon touching at two points", wkt: "MULTIPOLYGON (((0 0, 2 0, 1 -2, 0 0)), ((2 0, 4 0, 3 -2, 2 0)), ((0 0, 2 3, 4 0, 2 1, 0 0)))", expected: valid.Valid},
+		{desc: "polygon in hole", wkt: "MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 8 2, 8 8, 2 8, 2 2)), ((3 3, 7 3, 7 7, 3 7, 3 3)))", expected: valid.Valid},
+		{desc: "overlapping polygons", wkt: "MULTIPOLYGON (((0 0, 2 0, 2 2, 0 2, 0 0)), ((1 1, 3 1, 3 3, 1 3, 1 1)))", expected: valid.SelfIntersection},
+		{desc: "polygons sharing an edge", wkt: "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((1 0, 2 0, 2 1, 1 1, 1 0)))", expected: valid.SelfIntersection},
+		{desc: "nested shells", wkt: "MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0)), ((2 2, 3 2, 3 3, 2 3, 2 2)))", expected: valid.NestedShells, location: geom.Coord{2, 2}},
+		{desc: "valid collection", wkt: "GEOMETRYCOLLECTION (POINT (1 2), POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0)))", expected: valid.Valid},
+		{desc: "invalid collection", wkt: "GEOMETRYCOLLECTION (POINT (1 2), POLYGON ((0 0, 10 10, 10 0, 0 10, 0 0)))", expected: valid.RingSelfIntersection, location: geom.Coord{5, 5}},
+	} {
+		g, err := wkt.Unmarshal(tc.wkt)
+		if err != nil {
+			t.Fatalf("%d: %s: %v", i, tc.desc, err)
+		}
+		ok, reason := valid.IsValid(g)
+		if ok != (tc.expected == valid.Valid) || reason.Type != tc.expected {
+			t.Errorf("%d: %s: IsValid(%s) == %v, %v, want %v", i, tc.desc, tc.wkt, ok, reason, tc.expected)
+			continue
+		}
+		if tc.location != nil && !reflect.DeepEqual(reason.Location, tc.location) {
+			t.Errorf("%d: %s: IsValid(%s) location == %v, want %v", i, tc.desc, tc.wkt, reason.Location, tc.location)
+		}
+	}
+}
+
+func TestIsValidLinearRing(t *testing.T) {
+	for i, tc := range []struct {
+		ring     *geom.LinearRing
+		expected valid.Type
+	}{
+		{ring: geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}), expected: valid.Valid},
+		{ring: geom.NewLinearRingFlat(geom.XYZ, []float64{0, 0, 1, 1, 0, 2, 1, 1, 3, 0, 0, 4}), expected: valid.Valid},
+		{ring: geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1}), expected: valid.RingNotClosed},
+		{ring: geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 1, 1, 1, 0, 0, 1, 0, 0}), expected: valid.RingSelfIntersection},
+		{ring: geom.NewLinearRingFlat(geom.XY, []float64{0, 0, math.NaN(), 0, 1, 1, 0, 0}), expected: valid.InvalidCoordinate},
+	} {
+		if _, reason := valid.IsValid(tc.ring); reason.Type != tc.expected {
+			t.Errorf("%d: IsValid(%v) == %v, want %v", i, tc.ring.FlatCoords(), reason, tc.expected)
+		}
+	}
+}
+
+func TestIsSimple(t *testing.T) {
+	for i, tc := range []struct {
+		wkt      string
+		expected bool
+	}{
+		{wkt: "POINT (1 2)", expected: true},
+		{wkt: "MULTIPOINT ((0 0), (1 1))", expected: true},
+		{wkt: "MULTIPOINT ((0 0), (1 1), (0 0))", expected: false},
+		{wkt: "LINESTRING EMPTY", expected: true},
+		{wkt: "LINESTRING (0 0, 1 1)", expected: true},
+		{wkt: "LINESTRING (0 0, 1 1, 1 1, 2 0)", expected: true},
+		{wkt: "LINESTRING (0 0, 10 10, 10 0, 0 10)", expected: false},
+		{wkt: "LINESTRING (0 0, 10 0, 10 10, 5 0)", expected: false},
+		{wkt: "LINESTRING (0 0, 2 0, 1 0)", expected: false},
+		{wkt: "LINESTRING (0 0, 1 0, 1 1, 0 0)", expected: true},
+		{wkt: "LINESTRING (0 0, 1 0, 1 1, 0 0, 0 -1)", expected: false},
+		{wkt: "MULTILINESTRING ((0 0, 1 0), (2 0, 3 0))", expected: true},
+		{wkt: "MULTILINESTRING ((0 0, 2 2), (0 2, 2 0))", expected: false},
+		{wkt: "MULTILINESTRING ((0 0, 2 2), (1 1, 2 0))", expected: false},
+		{wkt: "MULTILINESTRING ((0 0, 1 1), (1 1, 2 0))", expected: false},
+		{wkt: "MULTILINESTRING ((0 0, 1 1), (1 1, 2 0), (1 1, 1 2))", expected: true},
+		{wkt: "MULTILINESTRING ((0 0, 2 0), (1 0, 3 0))", expected: false},
+		{wkt: "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 8 2, 2 2))", expected: true},
+		{wkt: "POLYGON ((0 0, 10 10, 10 0, 0 10, 0 0))", expected: false},
+		{wkt: "MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((0 0, 10 10, 10 0, 0 10, 0 0)))", expected: false},
+		{wkt: "GEOMETRYCOLLECTION (POINT (0 0), LINESTRING (0 0, 1 1))", expected: true},
+		{wkt: "GEOMETRYCOLLECTION (POINT (0 0), LINESTRING (0 0, 10 10, 10 0, 0 10))", expected: false},
+	} {
+		g, err := wkt.Unmarshal(tc.wkt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := valid.IsSimple(g); got != tc.expected {
+			t.Errorf("%d: IsSimple(%s) == %v, want %v", i, tc.wkt, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Package voronoi 计算平面（XY）点集的 Voronoi 图。
+//
+// 每个点的 Voronoi 单元是平面上到该点的距离不大于到其他任何点的距离的区域。
+// 单元由点与其在 Delaunay 三角剖分中的相邻点之间的垂直平分线围成，外侧的单元是无界的，因此总是裁剪到一个矩形范围之内
+package voronoi
+
+import (
+	"math"
+	"sort"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/xy/triangulate"
+)
+
+// Diagram函数 计算点集的 Voronoi 图，返回的 MultiPolygon 按点第一次出现的顺序包含各点的单元，SRID 与点集相同。
+// 单元的外环为逆时针方向，只使用点的 X 和 Y 坐标。
+// 单元裁剪到 clip 范围之内，clip 为 nil 时使用点集的范围向四周扩展其宽度和高度中的较大值（所有点相同时扩展1）。
+// X 和 Y 坐标相同的点共用一个单元，这样单元只在边界上相邻，互不重叠。单元与 clip 范围不相交的点
+// （只可能位于 clip 范围之外）没有单元，因此同时返回每个点的单元在 MultiPolygon 中的索引，没有单元的点的索引为 -1
+func Diagram(mp *geom.MultiPoint, clip *geom.Bounds) (*geom.MultiPolygon, []int) {
+	n := mp.NumPoints()
+	if n == 0 {
+		return geom.NewMultiPolygon(geom.XY).SetSRID(mp.SRID()), []int{}
+	}
+	stride := mp.Stride()
+	flatCoords := mp.FlatCoords()
+	site := func(i int) geom.Coord {
+		return geom.Coord(flatCoords[i*stride : i*stride+2])
+	}
+
+	// 重复的点映射到第一个相同的点
+	first := make([]int, n)
+	seen := make(map[[2]float64]int, n)
+	var unique []int
+	for i := 0; i < n; i++ {
+		key := [2]float64{flatCoords[i*stride], flatCoords[i*stride+1]}
+		if j, ok := seen[key]; ok {
+			first[i] = j
+			continue
+		}
+		seen[key] = i
+		first[i] = i
+		unique = append(unique, i)
+	}
+
+	neighbors := make(map[int][]int, len(unique))
+	edges := make(map[[2]int]bool)
+	addEdge := func(a, b int) {
+		if a == b || edges[[2]int{a, b}] {
+			return
+		}
+		edges[[2]int{a, b}], edges[[2]int{b, a}] = true, true
+		neighbors[a] = append(neighbors[a], b)
+		neighbors[b] = append(neighbors[b], a)
+	}
+	if indices := triangulate.Delaunay(mp); indices != nil {
+		for i := 0; i+2 < len(indices); i += 3 {
+			addEdge(indices[i], indices[i+1])
+			addEdge(indices[i+1], indices[i+2])
+			addEdge(indices[i+2], indices[i])
+		}
+	} else {
+		// 所有的点共线或少于3个点时，每个点只与排序之后相邻的点相邻
+		sorted := append([]int(nil), unique...)
+		sort.Slice(sorted, func(i, j int) bool {
+			a, b := site(sorted[i]), site(sorted[j])
+			return a[0] < b[0] || a[0] == b[0] && a[1] < b[1]
+		})
+		for i := 1; i < len(sorted); i++ {
+			addEdge(sorted[i-1], sorted[i])
+		}
+	}
+
+	envelope := clipEnvelope(mp, clip)
+	cells := make(map[int][]float64, len(unique))
+	for _, i := range unique {
+		var cell []float64
+		if envelope != nil {
+			cell = envelope
+			for _, j := range neighbors[i] {
+				if cell = clipHalfPlane(cell, site(i), site(j)); cell == nil {
+					break
+				}
+			}
+		}
+		cells[i] = cell
+	}
+
+	var cellCoords []float64
+	var endss [][]int
+	index := make([]int, n)
+	for i := 0; i < n; i++ {
+		if first[i] != i {
+			index[i] = index[first[i]]
+			continue
+		}
+		cell := cells[i]
+		if len(cell) == 0 {
+			index[i] = -1
+			continue
+		}
+		index[i] = len(endss)
+		cellCoords = append(cellCoords, cell...)
+		cellCoords = append(cellCoords, cell[0], cell[1])
+		endss = append(endss, []int{len(cellCoords)})
+	}
+	return geom.NewMultiPolygonFlat(geom.XY, cellCoords, endss).SetSRID(mp.SRID()), index
+}
+
+// clipEnvelope 函数返回裁剪范围的逆时针方向的四个顶点，范围为空时返回 nil
+func clipEnvelope(mp *geom.MultiPoint, clip *geom.Bounds) []float64 {
+	var minX, minY, maxX, maxY float64
+	if clip != nil {
+		if clip.IsEmpty() {
+			return nil
+		}
+		minX, minY, maxX, maxY = clip.Min(0), clip.Min(1), clip.Max(0), clip.Max(1)
+	} else {
+		b := geom.NewBounds(geom.XY).Extend(mp)
+		minX, minY, maxX, maxY = b.Min(0), b.Min(1), b.Max(0), b.Max(1)
+		margin := math.Max(maxX-minX, maxY-minY)
+		if margin == 0 {
+			margin = 1
+		}
+		minX, minY, maxX, maxY = minX-margin, minY-margin, maxX+margin, maxY+margin
+	}
+	return []float64{minX, minY, maxX, minY, maxX, maxY, minX, maxY}
+}
+
+// clipHalfPlane 函数使用 Sutherland-Hodgman 算法将凸多边形 polygon（未闭合的顶点序列）裁剪到距离点 p 不大于距离点 q 的半平面，
+// 结果少于3个顶点时返回 nil
+func clipHalfPlane(polygon []float64, p, q geom.Coord) []float64 {
+	dx, dy := q[0]-p[0], q[1]-p[1]
+	mx, my := (p[0]+q[0])/2, (p[1]+q[1])/2
+	side := func(x, y float64) float64 {
+		return (x-mx)*dx + (y-my)*dy
+	}
+	n := len(polygon) / 2
+	result := make([]float64, 0, len(polygon)+2)
+	for i := 0; i < n; i++ {
+		ax, ay := polygon[2*i], polygon[2*i+1]
+		j := (i + 1) % n
+		bx, by := polygon[2*j], polygon[2*j+1]
+		sa, sb := side(ax, ay), side(bx, by)
+		if sa <= 0 {
+			result = appendVertex(result, ax, ay)
+		}
+		if sa < 0 && sb > 0 || sa > 0 && sb < 0 {
+			t := sa / (sa - sb)
+			result = appendVertex(result, ax+t*(bx-ax), ay+t*(by-ay))
+		}
+	}
+	if k := len(result); k >= 4 && result[0] == result[k-2] && result[1] == result[k-1] {
+		result = result[:k-2]
+	}
+	if len(result) < 6 {
+		return nil
+	}
+	return result
+}
+
+// appendVertex 函数在顶点与最后一个顶点不同时将其添加到 polygon 的末尾
+func appendVertex(polygon []float64, x, y float64) []float64 {
+	if k := len(polygon); k >= 2 && polygon[k-2] == x && polygon[k-1] == y {
+		return polygon
+	}
+	return append(polygon, x, y)
+}
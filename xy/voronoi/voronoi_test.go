@@ -0,0 +1,247 @@
+package voronoi_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/chengxiaoer/geomGo"
+	"github.com/chengxiaoer/geomGo/encoding/wkt"
+	"github.com/chengxiaoer/geomGo/xy"
+	"github.com/chengxiaoer/geomGo/xy/location"
+	"github.com/chengxiaoer/geomGo/xy/valid"
+	"github.com/chengxiaoer/geomGo/xy/voronoi"
+)
+
+// ringArea 函数返回闭合线环的有向面积，逆时针方向时为正
+func ringArea(flatCoords []float64) float64 {
+	var a float64
+	for i := 0; i+3 < len(flatCoords); i += 2 {
+		a += flatCoords[i]*flatCoords[i+3] - flatCoords[i+2]*flatCoords[i+1]
+	}
+	return a / 2
+}
+
+// checkDiagram 函数检查单元的数目、索引、方向、有效性、单元的面积之和、多多边形的面积和周长，
+// 以及单元的每个顶点到所属的点的距离不大于到其他点的距离。相同的点必须共用一个索引，单元不能重叠
+func checkDiagram(t *testing.T, desc string, mp *geom.MultiPoint, clip *geom.Bounds, cells *geom.MultiPolygon, index []int) {
+	if got, want := len(index), mp.NumPoints(); got != want {
+		t.Fatalf("%s: len(index) == %d, want %d", desc, got, want)
+	}
+	seen := make(map[[2]float64]int)
+	next := 0
+	for i, j := range index {
+		p := mp.Point(i).Coords()
+		key := [2]float64{p[0], p[1]}
+		if k, ok := seen[key]; ok {
+			if j != k {
+				t.Errorf("%s: index[%d] == %d, want %d as the same site", desc, i, j, k)
+			}
+			continue
+		}
+		seen[key] = j
+		switch {
+		case j == -1:
+			if clip == nil || clip.OverlapsPoint(geom.XY, p[:2]) {
+				t.Errorf("%s: site %d has no cell, want a cell", desc, i)
+			}
+		case j != next:
+			t.Fatalf("%s: index[%d] == %d, want %d", desc, i, j, next)
+		default:
+			next++
+		}
+	}
+	if got := cells.NumPolygons(); got != next {
+		t.Fatalf("%s: cells.NumPolygons() == %d, want %d", desc, got, next)
+	}
+	distance := func(c geom.Coord, i int) float64 {
+		p := mp.Point(i).Coords()
+		return math.Hypot(c[0]-p[0], c[1]-p[1])
+	}
+	// 相邻的单元共用边，因此逐个检查单元的有效性，单元互不重叠由面积之和等于裁剪范围的面积保证
+	var sumArea, sumLength float64
+	for j := 0; j < cells.NumPolygons(); j++ {
+		cell := cells.Polygon(j)
+		if ok, reason := valid.IsValid(cell); !ok {
+			t.Errorf("%s: cell %d is invalid: %v", desc, j, reason)
+		}
+		a := ringArea(cell.FlatCoords())
+		sumArea += a
+		sumLength += cell.Length()
+		if a <= 0 {
+			t.Errorf("%s: cell %d has area %v, want > 0", desc, j, a)
+		}
+	}
+	for i, j := range index {
+		if j == -1 {
+			continue
+		}
+		cell := cells.Polygon(j)
+		if p := mp.Point(i).Coords(); (clip == nil || clip.OverlapsPoint(geom.XY, p[:2])) && xy.LocatePointInPolygon(cell, p) == location.Exterior {
+			t.Errorf("%s: site %d is outside of its cell", desc, i)
+		}
+		for j := 0; j < cell.NumCoords(); j++ {
+			c := cell.Coord(j)
+			d := distance(c, i)
+			for k := 0; k < mp.NumPoints(); k++ {
+				if dk := distance(c, k); dk < d-1e-9*math.Max(1, d) {
+					t.Errorf("%s: vertex %v of cell %d is closer to site %d (%v < %v)", desc, c, i, k, dk, d)
+				}
+			}
+		}
+	}
+	if got := cells.Area(); math.Abs(got-sumArea) > 1e-9*math.Max(1, sumArea) {
+		t.Errorf("%s: cells.Area() == %v, want %v", desc, got, sumArea)
+	}
+	if got := cells.Length(); math.Abs(got-sumLength) > 1e-9*math.Max(1, sumLength) {
+		t.Errorf("%s: cells.Length() == %v, want %v", desc, got, sumLength)
+	}
+	if clip != nil {
+		want := (clip.Max(0) - clip.Min(0)) * (clip.Max(1) - clip.Min(1))
+		if math.Abs(sumArea-want) > 1e-9*math.Max(1, want) {
+			t.Errorf("%s: total area of cells == %v, want %v", desc, sumArea, want)
+		}
+	}
+}
+
+func TestDiagram(t *testing.T) {
+	for i, tc := range []struct {
+		desc   string
+		points string
+		clip   *geom.Bounds
+		want   string
+		index  []int
+	}{
+		{
+			desc:   "single point",
+			points: "MULTIPOINT (1 1)",
+			clip:   geom.NewBounds(geom.XY).Set(0, 0, 2, 2),
+			want:   "MULTIPOLYGON (((0 0, 2 0, 2 2, 0 2, 0 0)))",
+			index:  []int{0},
+		},
+		{
+			desc:   "two points",
+			points: "MULTIPOINT (1 1, 3 1)",
+			clip:   geom.NewBounds(geom.XY).Set(0, 0, 4, 2),
+			want:   "MULTIPOLYGON (((0 0, 2 0, 2 2, 0 2, 0 0)), ((2 0, 4 0, 4 2, 2 2, 2 0)))",
+			index:  []int{0, 1},
+		},
+		{
+			desc:   "collinear",
+			points: "MULTIPOINT (3 1, 1 1, 5 1)",
+			clip:   geom.NewBounds(geom.XY).Set(0, 0, 6, 2),
+			want:   "MULTIPOLYGON (((2 0, 4 0, 4 2, 2 2, 2 0)), ((0 0, 2 0, 2 2, 0 2, 0 0)), ((4 0, 6 0, 6 2, 4 2, 4 0)))",
+			index:  []int{0, 1, 2},
+		},
+		{
+			desc:   "duplicates",
+			points: "MULTIPOINT (1 1, 3 1, 1 1)",
+			clip:   geom.NewBounds(geom.XY).Set(0, 0, 4, 2),
+			want:   "MULTIPOLYGON (((0 0, 2 0, 2 2, 0 2, 0 0)), ((2 0, 4 0, 4 2, 2 2, 2 0)))",
+			index:  []int{0, 1, 0},
+		},
+		{
+			desc:   "duplicates outside clip",
+			points: "MULTIPOINT (10 1, 1 1, 10 1, 1 1)",
+			clip:   geom.NewBounds(geom.XY).Set(0, 0, 2, 2),
+			want:   "MULTIPOLYGON (((0 0, 2 0, 2 2, 0 2, 0 0)))",
+			index:  []int{-1, 0, -1, 0},
+		},
+		{
+			desc:   "square",
+			points: "MULTIPOINT (1 1, 3 1, 3 3, 1 3)",
+			clip:   geom.NewBounds(geom.XY).Set(0, 0, 4, 4),
+			want:   "MULTIPOLYGON (((0 0, 2 0, 2 2, 0 2, 0 0)), ((2 0, 4 0, 4 2, 2 2, 2 0)), ((4 2, 4 4, 2 4, 2 2, 4 2)), ((2 4, 0 4, 0 2, 2 2, 2 4)))",
+			index:  []int{0, 1, 2, 3},
+		},
+		{
+			desc:   "outside clip",
+			points: "MULTIPOINT (10 1, 1 1)",
+			clip:   geom.NewBounds(geom.XY).Set(0, 0, 2, 2),
+			want:   "MULTIPOLYGON (((0 0, 2 0, 2 2, 0 2, 0 0)))",
+			index:  []int{-1, 0},
+		},
+		{
+			desc:   "outside clip with cell",
+			points: "MULTIPOINT (1 1, 3 1)",
+			clip:   geom.NewBounds(geom.XY).Set(0, 0, 2.5, 2),
+			want:   "MULTIPOLYGON (((0 0, 2 0, 2 2, 0 2, 0 0)), ((2 0, 2.5 0, 2.5 2, 2 2, 2 0)))",
+			index:  []int{0, 1},
+		},
+		{
+			desc:   "default clip",
+			points: "MULTIPOINT (0 0, 2 0)",
+			want:   "MULTIPOLYGON (((-2 -2, 1 -2, 1 2, -2 2, -2 -2)), ((1 -2, 4 -2, 4 2, 1 2, 1 -2)))",
+			index:  []int{0, 1},
+		},
+		{
+			desc:   "empty",
+			points: "MULTIPOINT EMPTY",
+			want:   "MULTIPOLYGON EMPTY",
+			index:  []int{},
+		},
+	} {
+		g, err := wkt.Unmarshal(tc.points)
+		if err != nil {
+			t.Fatalf("%d: %s: wkt.Unmarshal(%q) == _, %v, want _, nil", i, tc.desc, tc.points, err)
+		}
+		mp := g.(*geom.MultiPoint)
+		cells, index := voronoi.Diagram(mp, tc.clip)
+		if got, err := wkt.Marshal(cells); err != nil || got != tc.want {
+			t.Errorf("%d: %s: Diagram(...) == %s, _, %v, want %s, _, nil", i, tc.desc, got, err, tc.want)
+		}
+		if !reflect.DeepEqual(index, tc.index) {
+			t.Errorf("%d: %s: Diagram(...) == _, %v, want _, %v", i, tc.desc, index, tc.index)
+		}
+		checkDiagram(t, tc.desc, mp, tc.clip, cells, index)
+	}
+}
+
+func TestDiagramSRID(t *testing.T) {
+	mp := geom.NewMultiPoint(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 1}, {1, 0, 2}, {0, 1, 3}}).SetSRID(4326)
+	cells, _ := voronoi.Diagram(mp, nil)
+	if got := cells.SRID(); got != 4326 {
+		t.Errorf("cells.SRID() == %d, want 4326", got)
+	}
+	if got := cells.Layout(); got != geom.XY {
+		t.Errorf("cells.Layout() == %v, want %v", got, geom.XY)
+	}
+}
+
+func TestDiagramRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		n := 3 + r.Intn(50)
+		coords := make([]geom.Coord, n)
+		for j := range coords {
+			if i%2 == 0 {
+				// 整数坐标会产生大量的重复、共线和共圆的点
+				coords[j] = geom.Coord{float64(r.Intn(5)), float64(r.Intn(5))}
+			} else {
+				coords[j] = geom.Coord{r.Float64() * 10, r.Float64() * 10}
+			}
+		}
+		mp := geom.NewMultiPoint(geom.XY).MustSetCoords(coords)
+		clip := geom.NewBounds(geom.XY).Set(-1, -1, 11, 11)
+		cells, index := voronoi.Diagram(mp, clip)
+		checkDiagram(t, "random", mp, clip, cells, index)
+	}
+}
+
+func TestDiagramOutsideClip(t *testing.T) {
+	mp := geom.NewMultiPoint(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 0}, {5, 5}, {0, 1}})
+	clip := geom.NewBounds(geom.XY).Set(-1, -1, 2, 2)
+	cells, index := voronoi.Diagram(mp, clip)
+	if want := []int{0, 1, -1, 2}; !reflect.DeepEqual(index, want) {
+		t.Errorf("Diagram(...) == _, %v, want _, %v", index, want)
+	}
+	checkDiagram(t, "outside clip", mp, clip, cells, index)
+	// 多多边形的每个成员都是非空的单元，因此可以直接用于其他操作
+	if _, err := xy.Centroid(cells); err != nil {
+		t.Errorf("xy.Centroid(cells) == _, %v, want _, nil", err)
+	}
+	if got, want := cells.Area(), 9.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("cells.Area() == %v, want %v", got, want)
+	}
+}